@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/Emptyless/go-spectral/formatter"
+)
+
+// defaultRulesetFile matches the ruleset file used if no --ruleset is supplied, equal to the spectral CLI
+var defaultRulesetFile = regexp.MustCompile(`^\.?spectral\.(?:ya?ml|json|m?js)$`)
+
+// ErrNoDocuments when the lint command is run without documents
+var ErrNoDocuments = errors.New("enter at least one document or glob to lint")
+
+// ErrNoRuleset when no --ruleset is supplied and no default ruleset file exists in the working directory
+var ErrNoRuleset = errors.New("no ruleset has been found, provide a ruleset using the --ruleset argument or make sure your ruleset file matches .?spectral.(js|ya?ml|json)")
+
+// ErrOutputRequiresSingleFormat when --output is supplied with multiple formats
+var ErrOutputRequiresSingleFormat = errors.New("--output can only be used with a single --format, use --output.<format> instead")
+
+// lintOptions of the lint command
+type lintOptions struct {
+	documents            []string
+	ruleset              string
+	formats              []string
+	output               string
+	outputs              map[string]*string
+	failSeverity         gospectral.Severity
+	displayOnlyFailures  bool
+	ignoreUnknownFormat  bool
+	failOnUnmatchedGlobs bool
+}
+
+// stringsFlag is a repeatable flag.Value
+type stringsFlag []string
+
+// String implementation of flag.Value
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set implementation of flag.Value
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+
+	return nil
+}
+
+// unique values in the order they first occur
+func unique(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	res := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+
+		seen[value] = struct{}{}
+		res = append(res, value)
+	}
+
+	return res
+}
+
+// parseLint parses the arguments of the lint command. Flags and documents may be interleaved, e.g.
+// `lint ./openapi.yaml --format json ./asyncapi.yaml`.
+func parseLint(args []string, stderr io.Writer) (*lintOptions, error) {
+	opts := &lintOptions{outputs: map[string]*string{}}
+	flags := flag.NewFlagSet("go-spectral lint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: go-spectral lint <documents..> [options]\n\nOptions:\n")
+		flags.PrintDefaults()
+	}
+
+	formats := stringsFlag{}
+	for _, name := range []string{"format", "f"} {
+		flags.Var(&formats, name, "formatter to use for outputting results, repeatable: "+strings.Join(formatter.Names(), ", ")+" (default stylish)")
+	}
+	for _, name := range []string{"output", "o"} {
+		flags.StringVar(&opts.output, name, "", "where to output results, can be a single file name or a --output.<format> per format")
+	}
+	for _, name := range formatter.Names() {
+		opts.outputs[name] = flags.String("output."+name, "", "where to output results in the "+name+" format")
+	}
+	for _, name := range []string{"ruleset", "r"} {
+		flags.StringVar(&opts.ruleset, name, "", "path/URL to a ruleset file (default .?spectral.(js|ya?ml|json) in the working directory)")
+	}
+	for _, name := range []string{"fail-severity", "F"} {
+		flags.TextVar(&opts.failSeverity, name, gospectral.SeverityError, "results of this level or above will trigger a failure exit code: error, warn, info or hint")
+	}
+	for _, name := range []string{"display-only-failures", "D"} {
+		flags.BoolVar(&opts.displayOnlyFailures, name, false, "only output results equal to or greater than --fail-severity")
+	}
+	flags.BoolVar(&opts.ignoreUnknownFormat, "ignore-unknown-format", false, "do not warn about unmatched formats")
+	flags.BoolVar(&opts.failOnUnmatchedGlobs, "fail-on-unmatched-globs", false, "fail on unmatched glob patterns")
+
+	// parse the flags in between the documents
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		if args = flags.Args(); len(args) == 0 {
+			break
+		}
+
+		opts.documents = append(opts.documents, args[0])
+		args = args[1:]
+	}
+
+	opts.formats = unique(formats)
+	if len(opts.formats) == 0 {
+		opts.formats = []string{"stylish"}
+	}
+
+	return opts, nil
+}
+
+// validate the lintOptions before linting
+func (o *lintOptions) validate() error {
+	if len(o.documents) == 0 {
+		return ErrNoDocuments
+	}
+
+	for _, format := range o.formats {
+		if _, err := formatter.Lookup(format); err != nil {
+			return err
+		}
+	}
+
+	if o.output != "" && len(o.formats) > 1 {
+		return ErrOutputRequiresSingleFormat
+	}
+
+	return nil
+}
+
+// lint the documents, write the results in every format and return the exit code
+func lint(ctx context.Context, args []string, stdout, stderr io.Writer, options ...gospectral.Option) int {
+	// the flag.FlagSet reports parse errors along with the usage
+	opts, err := parseLint(args, stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	} else if err != nil {
+		return exitError
+	}
+
+	if err := opts.validate(); err != nil {
+		_, _ = fmt.Fprintf(stderr, "%v\n", err)
+
+		return exitError
+	}
+
+	if opts.ruleset == "" {
+		if opts.ruleset, err = findRuleset(); err != nil {
+			_, _ = fmt.Fprintf(stderr, "%v\n", err)
+
+			return exitError
+		}
+	}
+
+	lintOptions := gospectral.DefaultLintOptions()
+	lintOptions.IgnoreUnknownFormat = opts.ignoreUnknownFormat
+	lintOptions.FailOnUnmatchedGlobs = opts.failOnUnmatchedGlobs
+
+	options = slices.Concat(options, []gospectral.Option{gospectral.WithLintOptions(lintOptions), gospectral.WithFailSeverity(opts.failSeverity)})
+	linter, err := gospectral.New(options...)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "%v\n", err)
+
+		return exitError
+	}
+
+	code := exitOK
+	output, err := linter.Lint(ctx, opts.documents, opts.ruleset)
+	var lintFailedErr *gospectral.LintFailedError
+	if errors.As(err, &lintFailedErr) {
+		code = exitLintFailed
+	}
+
+	// report the other errors, e.g. a *gospectral.NetworkDeniedError joined with the output, which is still written
+	if errs := otherErrors(err); len(errs) > 0 {
+		for _, otherErr := range errs {
+			_, _ = fmt.Fprintf(stderr, "%v\n", otherErr)
+		}
+
+		if output == nil {
+			return exitError
+		}
+
+		code = exitError
+	}
+
+	if opts.displayOnlyFailures {
+		output = output.Filter(opts.failSeverity)
+	}
+
+	if len(output.Filter(opts.failSeverity)) == 0 {
+		_, _ = fmt.Fprintf(stderr, "No results with a severity of '%s' or higher found!\n", opts.failSeverity)
+	}
+
+	for _, format := range opts.formats {
+		if err := writeOutput(stdout, opts.outputPath(format), format, output); err != nil {
+			_, _ = fmt.Fprintf(stderr, "%v\n", err)
+
+			return exitError
+		}
+	}
+
+	return code
+}
+
+// otherErrors than the *gospectral.LintFailedError of the (joined) error of a lint
+func otherErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var others []error
+	for _, joinedErr := range errs {
+		var lintFailedErr *gospectral.LintFailedError
+		if !errors.As(joinedErr, &lintFailedErr) {
+			others = append(others, joinedErr)
+		}
+	}
+
+	return others
+}
+
+// outputPath of the format, empty if it is written to stdout
+func (o *lintOptions) outputPath(format string) string {
+	if path := *o.outputs[format]; path != "" {
+		return path
+	}
+
+	return o.output
+}
+
+// writeOutput in the format to the file at path or stdout if path is empty
+func writeOutput(stdout io.Writer, path string, format string, output gospectral.Output) error {
+	if path == "" {
+		return formatter.Format(stdout, format, output)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := formatter.Format(file, format, output); err != nil {
+		_ = file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// findRuleset in the working directory matching the defaultRulesetFile
+func findRuleset() (string, error) {
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() && defaultRulesetFile.MatchString(entry.Name()) {
+			return "./" + entry.Name(), nil
+		}
+	}
+
+	return "", ErrNoRuleset
+}
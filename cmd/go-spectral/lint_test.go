@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOptions replace the dist with a script returning a warning and an error
+var testOptions = []gospectral.Option{
+	gospectral.WithDist([]byte("")),
+	gospectral.WithScript([]byte(`JSON.stringify([
+    { source: "/wd/openapi.yaml", code: "info-contact", path: ["info"], message: "Info object must have \"contact\" object.", severity: 1, range: { start: { line: 1, character: 5 }, end: { line: 3, character: 20 } } },
+    { source: "/wd/openapi.yaml", code: "oas3-schema", path: ["info", "version"], message: "\"version\" property type must be string.", severity: 0, range: { start: { line: 4, character: 11 }, end: { line: 4, character: 14 } } }
+])`)),
+	gospectral.WithWorkingDirectory("/wd"),
+}
+
+func TestLint_ExitsWithLintFailedOnErrors(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "--ruleset", "./.spectral.yaml"}, &stdout, &stderr, testOptions...)
+
+	// Assert
+	assert.Equal(t, exitLintFailed, code)
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, `
+/wd/openapi.yaml
+ 2:6   warning  info-contact  Info object must have "contact" object.  info
+ 5:12  error    oas3-schema   "version" property type must be string.  info.version
+
+✖ 2 problems (1 error, 1 warning, 0 infos, 0 hints)
+`, stdout.String())
+}
+
+func TestLint_ExitsOKBelowFailSeverity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	options := append([]gospectral.Option{}, testOptions...)
+	options = append(options, gospectral.WithScript([]byte(`JSON.stringify([{ code: "info-contact", severity: 1 }])`)))
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml", "-f", "json"}, &stdout, &stderr, options...)
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "No results with a severity of 'error' or higher found!\n", stderr.String())
+	assert.Contains(t, stdout.String(), `"code": "info-contact"`)
+}
+
+func TestLint_DisplaysOnlyFailures(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := run(context.Background(), []string{"lint", "-F", "error", "-D", "--format=json", "./openapi.yaml", "-r", "./.spectral.yaml"}, &stdout, &stderr, testOptions...)
+
+	// Assert
+	assert.Equal(t, exitLintFailed, code)
+	var output gospectral.Output
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &output))
+	require.Len(t, output, 1)
+	assert.Equal(t, "oas3-schema", output[0].Code)
+}
+
+func TestLint_WritesOutputPerFormat(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	file := filepath.Join(t.TempDir(), "results.json")
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml", "-f", "json", "-f", "text", "--output.json", file}, &stdout, &stderr, testOptions...)
+
+	// Assert
+	assert.Equal(t, exitLintFailed, code)
+	assert.Equal(t, `/wd/openapi.yaml:2:6 warn info-contact "Info object must have "contact" object."
+/wd/openapi.yaml:5:12 error oas3-schema ""version" property type must be string."
+`, stdout.String())
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var output gospectral.Output
+	require.NoError(t, json.Unmarshal(data, &output))
+	assert.Len(t, output, 2)
+}
+
+func TestLint_WritesOutputToFile(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	file := filepath.Join(t.TempDir(), "results.txt")
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml", "-f", "text", "-o", file}, &stdout, &stderr, testOptions...)
+
+	// Assert
+	assert.Equal(t, exitLintFailed, code)
+	assert.Empty(t, stdout.String())
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "oas3-schema")
+}
+
+func TestLint_ExitsWithErrorWhenLintFails(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	options := []gospectral.Option{gospectral.WithDist([]byte("")), gospectral.WithScript([]byte("Promise.reject('boom')"))}
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml"}, &stdout, &stderr, options...)
+
+	// Assert
+	assert.Equal(t, exitError, code)
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "boom: promise rejected\n", stderr.String())
+}
+
+func TestLint_WritesOutputAndExitsWithErrorWhenRequestIsDenied(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	options := []gospectral.Option{
+		gospectral.WithDist([]byte("")),
+		gospectral.WithScript([]byte(`fetch("https://rulesets.example.com/company.yaml")
+	.catch(function() {})
+	.then(function() { return JSON.stringify([{ code: "oas3-schema", message: "denied", severity: 0 }]); })`)),
+		gospectral.WithNetworkPolicy(gospectral.NetworkPolicy{}),
+	}
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml", "-f", "json"}, &stdout, &stderr, options...)
+
+	// Assert
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stdout.String(), `"code": "oas3-schema"`)
+	assert.Contains(t, stderr.String(), "https://rulesets.example.com/company.yaml")
+}
+
+func TestLint_DoesNotModifyOptions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	options := make([]gospectral.Option, len(testOptions), len(testOptions)+2)
+	copy(options, testOptions)
+
+	// Act
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml"}, &stdout, &stderr, options...)
+
+	// Assert
+	assert.Equal(t, exitLintFailed, code)
+	assert.Nil(t, options[:cap(options)][len(testOptions)])
+}
+
+func TestLint_RejectsInvalidArguments(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		args   []string
+		stderr string
+	}{
+		"no documents": {
+			args:   []string{"lint", "-r", "./.spectral.yaml"},
+			stderr: ErrNoDocuments.Error(),
+		},
+		"unknown format": {
+			args:   []string{"lint", "./openapi.yaml", "-f", "yaml"},
+			stderr: "'yaml' (expected one of code-climate, github-actions, html, json, junit, markdown, sarif, stylish, teamcity, text): unknown format",
+		},
+		"output with multiple formats": {
+			args:   []string{"lint", "./openapi.yaml", "-f", "json", "-f", "text", "-o", "results"},
+			stderr: ErrOutputRequiresSingleFormat.Error(),
+		},
+		"unknown fail severity": {
+			args:   []string{"lint", "./openapi.yaml", "-F", "fatal"},
+			stderr: `invalid value "fatal" for flag -F: 'fatal': unknown severity`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var stdout, stderr bytes.Buffer
+
+			// Act
+			code := run(context.Background(), test.args, &stdout, &stderr, testOptions...)
+
+			// Assert
+			assert.Equal(t, exitError, code)
+			assert.Empty(t, stdout.String())
+			assert.Contains(t, stderr.String(), test.stderr)
+		})
+	}
+}
+
+func TestParseLint_InterleavesFlagsAndDocuments(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stderr bytes.Buffer
+
+	// Act
+	opts, err := parseLint([]string{"./a.yaml", "--format", "json", "./b.yaml", "-F", "warn", "./specs/*.yaml"}, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"./a.yaml", "./b.yaml", "./specs/*.yaml"}, opts.documents)
+	assert.Equal(t, []string{"json"}, opts.formats)
+	assert.Equal(t, gospectral.SeverityWarn, opts.failSeverity)
+}
+
+func TestParseLint_DeduplicatesFormats(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stderr bytes.Buffer
+
+	// Act
+	opts, err := parseLint([]string{"./openapi.yaml", "-f", "json", "-f", "text", "--format", "json"}, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"json", "text"}, opts.formats)
+}
+
+func TestParseLint_Defaults(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stderr bytes.Buffer
+
+	// Act
+	opts, err := parseLint([]string{"./openapi.yaml"}, &stderr)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stylish"}, opts.formats)
+	assert.Equal(t, gospectral.SeverityError, opts.failSeverity)
+	assert.Empty(t, opts.ruleset)
+	assert.False(t, opts.displayOnlyFailures)
+	assert.False(t, opts.ignoreUnknownFormat)
+	assert.False(t, opts.failOnUnmatchedGlobs)
+}
+
+func TestLint_PassesLintOptionsToDist(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	options := []gospectral.Option{
+		gospectral.WithDist([]byte("")),
+		gospectral.WithScript([]byte(`JSON.stringify([{ code: lintOptions.ignoreUnknownFormat + ":" + lintOptions.failOnUnmatchedGlobs, severity: 3 }])`)),
+	}
+
+	// Act
+	defaultCode := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml", "-f", "text"}, &stdout, &stderr, options...)
+	code := run(context.Background(), []string{"lint", "./openapi.yaml", "-r", "./.spectral.yaml", "-f", "text", "--ignore-unknown-format", "--fail-on-unmatched-globs"}, &stdout, &stderr, options...)
+
+	// Assert
+	assert.Equal(t, exitOK, defaultCode)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, ":1:1 hint false:false \"\"\n:1:1 hint true:true \"\"\n", stdout.String())
+}
@@ -0,0 +1,70 @@
+// Command go-spectral lints OpenAPI documents with the embedded Spectral dist, mirroring the spectral CLI, e.g.
+//
+//	go-spectral lint ./openapi.yaml --ruleset ./.spectral.yaml --format stylish --fail-severity warn
+//
+// Since the dist is embedded and evaluated in Go, no Node.js installation is required.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	log "github.com/sirupsen/logrus"
+)
+
+// Exit codes, equal to those of the spectral CLI
+const (
+	// exitOK when no result is at least as severe as the fail severity
+	exitOK = 0
+	// exitLintFailed when a result is at least as severe as the fail severity
+	exitLintFailed = 1
+	// exitError on invalid arguments or when the documents could not be linted
+	exitError = 2
+)
+
+// usage of the go-spectral command
+const usage = `Usage: go-spectral <command> [options]
+
+Commands:
+  lint <documents..>  lint JSON/YAML documents from files or globs
+
+Run 'go-spectral lint --help' for the options of the lint command.
+`
+
+func main() {
+	log.SetOutput(os.Stderr)
+	log.SetLevel(log.WarnLevel)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+
+	os.Exit(code)
+}
+
+// run the command in args, writing results to stdout and diagnostics to stderr, returning the exit code. The options
+// are passed on to the gospectral.Linter.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, options ...gospectral.Option) int {
+	if len(args) == 0 {
+		_, _ = io.WriteString(stderr, usage)
+
+		return exitError
+	}
+
+	switch args[0] {
+	case "lint":
+		return lint(ctx, args[1:], stdout, stderr, options...)
+	case "-h", "-help", "--help", "help":
+		_, _ = io.WriteString(stdout, usage)
+
+		return exitOK
+	default:
+		_, _ = fmt.Fprintf(stderr, "unknown command '%s'\n\n%s", args[0], usage)
+
+		return exitError
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_WithoutCommandPrintsUsage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := run(context.Background(), nil, &stdout, &stderr)
+
+	// Assert
+	assert.Equal(t, exitError, code)
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, usage, stderr.String())
+}
+
+func TestRun_Help(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := run(context.Background(), []string{"--help"}, &stdout, &stderr)
+
+	// Assert
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, usage, stdout.String())
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	code := run(context.Background(), []string{"bundle"}, &stdout, &stderr)
+
+	// Assert
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr.String(), "unknown command 'bundle'")
+}
@@ -0,0 +1,95 @@
+package gospectral
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// invalidRefCode of the spectral rule reporting a $ref that could not be resolved
+const invalidRefCode = "invalid-ref"
+
+// denial is the error of an access denied during a lint, e.g. a *NetworkDeniedError
+type denial interface {
+	error
+
+	// reference of the denied access (e.g. a URL) as it is mentioned by the invalid-ref Rule of the $ref
+	reference() string
+
+	// attribute the denied access to the $ref at the path in the source
+	attribute(source string, path Path)
+}
+
+// denialsKey of the denials in the context of a lint
+type denialsKey struct{}
+
+// denials are the accesses denied during a lint. Requests are made concurrently, so it is guarded by a mutex.
+type denials struct {
+	mu     sync.Mutex
+	denied []denial
+}
+
+// withDenials returns the context recording the accesses denied during a lint
+func withDenials(ctx context.Context) (context.Context, *denials) {
+	d := &denials{}
+
+	return context.WithValue(ctx, denialsKey{}, d), d
+}
+
+// denialsOf the context, nil if the context does not record denied accesses
+func denialsOf(ctx context.Context) *denials {
+	d, _ := ctx.Value(denialsKey{}).(*denials)
+
+	return d
+}
+
+// add a denied access, which is a no-op if d is nil
+func (d *denials) add(denied denial) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.denied = append(d.denied, denied)
+}
+
+// errs of the denied accesses, attributed to the invalid-ref Rule of the output mentioning the reference if any.
+// Every reference is only reported once.
+func (d *denials) errs(output Output) []error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := make(map[string]bool, len(d.denied))
+	errs := make([]error, 0, len(d.denied))
+	for _, denied := range d.denied {
+		if seen[denied.reference()] {
+			continue
+		}
+		seen[denied.reference()] = true
+
+		for _, rule := range output {
+			if rule.Code == invalidRefCode && strings.Contains(rule.Message, denied.reference()) {
+				denied.attribute(rule.Source, rule.Path)
+
+				break
+			}
+		}
+
+		errs = append(errs, denied)
+	}
+
+	return errs
+}
+
+// join the errors of the denied accesses with err, returning err if no access was denied
+func (d *denials) join(output Output, err error) error {
+	errs := d.errs(output)
+	if len(errs) == 0 {
+		return err
+	}
+
+	return errors.Join(append(errs, err)...)
+}
@@ -0,0 +1,26 @@
+package gospectral
+
+import (
+	"github.com/Emptyless/go-spectral/internal/task"
+	"github.com/dop251/goja"
+)
+
+// EnableQueueMicrotask sets the queueMicrotask global. The event loop (see eventloop.EventLoop) provides the timer
+// globals such as setTimeout and setImmediate but has no notion of microtasks, so these are queued on the goja job
+// queue, see task.Microtask.
+func EnableQueueMicrotask(runtime *goja.Runtime) error {
+	return runtime.Set("queueMicrotask", func(call goja.FunctionCall) goja.Value {
+		callback, ok := goja.AssertFunction(call.Argument(0))
+		if !ok {
+			panic(runtime.NewTypeError("The \"callback\" argument must be of type function"))
+		}
+
+		task.Microtask(runtime, func() {
+			if _, err := callback(goja.Undefined()); err != nil {
+				panic(err)
+			}
+		})
+
+		return goja.Undefined()
+	})
+}
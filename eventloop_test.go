@@ -0,0 +1,42 @@
+package gospectral
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableQueueMicrotask(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+
+	// Act
+	err := EnableQueueMicrotask(runtime)
+
+	// Assert
+	require.NoError(t, err)
+	res, runErr := runtime.RunString(`
+		var calls = [];
+		queueMicrotask(function() { calls.push("microtask"); });
+		calls.push("script");
+		calls;
+	`)
+	require.NoError(t, runErr)
+	assert.Equal(t, []any{"script", "microtask"}, res.Export())
+}
+
+func TestEnableQueueMicrotask_RequiresCallback(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	require.NoError(t, EnableQueueMicrotask(runtime))
+
+	// Act
+	_, err := runtime.RunString(`queueMicrotask("not a function")`)
+
+	// Assert
+	require.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package formatter
+
+import (
+	"crypto/md5" //nolint:gosec // used for fingerprints, not for security
+	"encoding/hex"
+	"encoding/json"
+	"io"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// codeClimateIssue as consumed by e.g. GitLab code quality reports
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Categories  []string            `json:"categories"`
+	Location    codeClimateLocation `json:"location"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+}
+
+// codeClimateLocation of an issue
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+// codeClimateLines are 1-based
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// codeClimateSeverities by Severity
+var codeClimateSeverities = map[gospectral.Severity]string{
+	gospectral.SeverityError: "critical",
+	gospectral.SeverityWarn:  "major",
+	gospectral.SeverityInfo:  "minor",
+	gospectral.SeverityHint:  "info",
+}
+
+// CodeClimate writes a Code Climate JSON report with an issue per Rule
+func CodeClimate(w io.Writer, output gospectral.Output, options ...Option) error {
+	opts, err := newOptions(options)
+	if err != nil {
+		return err
+	}
+
+	issues := make([]codeClimateIssue, 0, len(output))
+	for _, rule := range sorted(output) {
+		b, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		fingerprint := md5.Sum(b) //nolint:gosec // used for fingerprints, not for security
+
+		issues = append(issues, codeClimateIssue{
+			Type:        "issue",
+			CheckName:   rule.Code,
+			Description: rule.Message,
+			Categories:  []string{"Style"},
+			Location: codeClimateLocation{
+				Path:  relativePath(rule.Source, opts.BaseDirectory),
+				Lines: codeClimateLines{Begin: rule.Range.Start.Line + 1, End: rule.Range.End.Line + 1},
+			},
+			Severity:    codeClimateSeverities[rule.Severity],
+			Fingerprint: hex.EncodeToString(fingerprint[:]),
+		})
+	}
+
+	b, err := json.MarshalIndent(issues, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
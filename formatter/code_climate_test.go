@@ -0,0 +1,35 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeClimate(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := CodeClimate(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	var issues []codeClimateIssue
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &issues))
+	require.Len(t, issues, 3)
+
+	issue := issues[2]
+	assert.Equal(t, "issue", issue.Type)
+	assert.Equal(t, "oas3-schema", issue.CheckName)
+	assert.Equal(t, `"type" property must be equal to one of the allowed values.`, issue.Description)
+	assert.Equal(t, []string{"Style"}, issue.Categories)
+	assert.Equal(t, codeClimateLocation{Path: "/api/openapi.yaml", Lines: codeClimateLines{Begin: 10, End: 10}}, issue.Location)
+	assert.Equal(t, "critical", issue.Severity)
+	assert.Len(t, issue.Fingerprint, 32)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+}
@@ -0,0 +1,161 @@
+// Package formatter renders a gospectral.Output in the formats of the Spectral CLI, e.g. stylish, junit or sarif.
+package formatter
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// Formatter writes the gospectral.Output to w
+type Formatter func(w io.Writer, output gospectral.Output, options ...Option) error
+
+// Options of a Formatter
+type Options struct {
+	// BaseDirectory the absolute sources are made relative to (e.g. the gospectral.Config.WorkingDirectory) by the
+	// formats that report paths of a repository, i.e. sarif, code-climate and github-actions. Defaults to the current
+	// working directory if empty.
+	BaseDirectory string
+}
+
+// Option to customize the Options of a Formatter
+type Option func(options *Options) error
+
+// WithBaseDirectory sets the Options.BaseDirectory, e.g. to the working directory of the gospectral.Linter
+func WithBaseDirectory(directory string) Option {
+	return func(options *Options) error {
+		options.BaseDirectory = directory
+
+		return nil
+	}
+}
+
+// newOptions applies the Option's to the default Options
+func newOptions(options []Option) (Options, error) {
+	var opts Options
+	for _, option := range options {
+		if err := option(&opts); err != nil {
+			return Options{}, err
+		}
+	}
+
+	return opts, nil
+}
+
+// formatters by name, matching the names of the Spectral CLI --format flag
+var formatters = map[string]Formatter{
+	"json":           JSON,
+	"stylish":        Stylish,
+	"text":           Text,
+	"teamcity":       TeamCity,
+	"junit":          JUnit,
+	"sarif":          SARIF,
+	"github-actions": GitHubActions,
+	"html":           HTML,
+	"markdown":       Markdown,
+	"code-climate":   CodeClimate,
+}
+
+// ErrUnknownFormat when no Formatter exists with the requested name
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Names of the available formats in alphabetical order
+func Names() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// Lookup the Formatter by name, e.g. "stylish"
+func Lookup(name string) (Formatter, error) {
+	formatter, ok := formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("'%s' (expected one of %s): %w", name, strings.Join(Names(), ", "), ErrUnknownFormat)
+	}
+
+	return formatter, nil
+}
+
+// Format the gospectral.Output using the Formatter with name and the Option's and write it to w
+func Format(w io.Writer, name string, output gospectral.Output, options ...Option) error {
+	formatter, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+
+	return formatter(w, output, options...)
+}
+
+// sorted copy of the output by source, position, severity and code
+func sorted(output gospectral.Output) gospectral.Output {
+	rules := slices.Clone(output)
+	slices.SortStableFunc(rules, func(a, b gospectral.Rule) int {
+		return cmp.Or(
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Range.Start.Line, b.Range.Start.Line),
+			cmp.Compare(a.Range.Start.Character, b.Range.Start.Character),
+			cmp.Compare(a.Severity, b.Severity),
+			cmp.Compare(a.Code, b.Code),
+		)
+	})
+
+	return rules
+}
+
+// groupBySource returns the sources in order of appearance and the rules per source
+func groupBySource(output gospectral.Output) ([]string, map[string]gospectral.Output) {
+	var sources []string
+	groups := map[string]gospectral.Output{}
+	for _, rule := range output {
+		if _, ok := groups[rule.Source]; !ok {
+			sources = append(sources, rule.Source)
+		}
+		groups[rule.Source] = append(groups[rule.Source], rule)
+	}
+
+	return sources, groups
+}
+
+// printPath joins the path segments with a dot, e.g. paths./users.get
+func printPath(path []string) string {
+	return strings.Join(path, ".")
+}
+
+// relativePath of the source to the base directory (or the current working directory if empty) if it is absolute and
+// within it
+func relativePath(source string, base string) string {
+	if !filepath.IsAbs(source) {
+		return source
+	}
+
+	base, err := filepath.Abs(base)
+	if err != nil {
+		return source
+	}
+
+	rel, err := filepath.Rel(base, source)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return source
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// pluralize the word if count is not 1
+func pluralize(word string, count int) string {
+	if count == 1 {
+		return word
+	}
+
+	return word + "s"
+}
@@ -0,0 +1,130 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRule with a Range starting at start and ending at end (zero-based line and character)
+func testRule(source, code, message string, severity gospectral.Severity, path []string, start, end [2]int) gospectral.Rule {
+	rule := gospectral.Rule{Source: source, Code: code, Message: message, Severity: severity, Path: path}
+	rule.Range.Start.Line, rule.Range.Start.Character = start[0], start[1]
+	rule.Range.End.Line, rule.Range.End.Character = end[0], end[1]
+
+	return rule
+}
+
+// testOutput is an unsorted Output with two sources
+var testOutput = gospectral.Output{
+	testRule("/api/openapi.yaml", "oas3-schema", `"type" property must be equal to one of the allowed values.`, gospectral.SeverityError, []string{"components", "schemas", "User", "type"}, [2]int{9, 12}, [2]int{9, 18}),
+	testRule("/api/openapi.yaml", "info-contact", `Info object must have "contact" object.`, gospectral.SeverityWarn, []string{"info"}, [2]int{1, 5}, [2]int{3, 20}),
+	testRule("/api/common.yaml", "info-description", "Info \"description\" must be present and non-empty string.", gospectral.SeverityHint, []string{"info"}, [2]int{0, 0}, [2]int{0, 4}),
+}
+
+func TestNames(t *testing.T) {
+	t.Parallel()
+	// Act
+	names := Names()
+
+	// Assert
+	assert.Equal(t, []string{"code-climate", "github-actions", "html", "json", "junit", "markdown", "sarif", "stylish", "teamcity", "text"}, names)
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			formatter, err := Lookup(name)
+
+			// Assert
+			require.NoError(t, err)
+			assert.NotNil(t, formatter)
+		})
+	}
+}
+
+func TestLookup_UnknownFormat(t *testing.T) {
+	t.Parallel()
+	// Act
+	formatter, err := Lookup("yaml")
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownFormat)
+	assert.Nil(t, formatter)
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := Format(&buf, "text", testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotEmpty(t, buf.String())
+}
+
+func TestFormat_UnknownFormat(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := Format(&buf, "yaml", testOutput)
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownFormat)
+	assert.Empty(t, buf.String())
+}
+
+func TestFormat_EmptyOutput(t *testing.T) {
+	t.Parallel()
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var buf bytes.Buffer
+
+			// Act
+			err := Format(&buf, name, nil)
+
+			// Assert
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFormat_RelativeToBaseDirectory(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		expected string
+	}{
+		"sarif":          {expected: `"uri": "openapi.yaml"`},
+		"code-climate":   {expected: `"path": "openapi.yaml"`},
+		"github-actions": {expected: "file=openapi.yaml,"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var buf bytes.Buffer
+
+			// Act
+			err := Format(&buf, name, testOutput, WithBaseDirectory("/api"))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), tt.expected)
+			assert.NotContains(t, buf.String(), "/api/openapi.yaml")
+		})
+	}
+}
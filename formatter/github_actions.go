@@ -0,0 +1,49 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// gitHubActionsCommands by Severity
+var gitHubActionsCommands = map[gospectral.Severity]string{
+	gospectral.SeverityError: "error",
+	gospectral.SeverityWarn:  "warning",
+	gospectral.SeverityInfo:  "notice",
+	gospectral.SeverityHint:  "notice",
+}
+
+var (
+	// gitHubActionsDataEscaper escapes the message of a workflow command
+	gitHubActionsDataEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	// gitHubActionsPropertyEscaper escapes the properties of a workflow command
+	gitHubActionsPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// GitHubActions writes a GitHub Actions workflow command per Rule such that the results are shown as annotations
+func GitHubActions(w io.Writer, output gospectral.Output, options ...Option) error {
+	opts, err := newOptions(options)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range sorted(output) {
+		properties := strings.Join([]string{
+			"title=" + gitHubActionsPropertyEscaper.Replace(rule.Code),
+			"file=" + gitHubActionsPropertyEscaper.Replace(relativePath(rule.Source, opts.BaseDirectory)),
+			fmt.Sprintf("col=%d", rule.Range.Start.Character+1),
+			fmt.Sprintf("endColumn=%d", rule.Range.End.Character+1),
+			fmt.Sprintf("line=%d", rule.Range.Start.Line+1),
+			fmt.Sprintf("endLine=%d", rule.Range.End.Line+1),
+		}, ",")
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", gitHubActionsCommands[rule.Severity], properties, gitHubActionsDataEscaper.Replace(rule.Message)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
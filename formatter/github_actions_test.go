@@ -0,0 +1,31 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubActions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+	output := gospectral.Output{
+		testRule("/api/openapi.yaml", "info-contact", "Info object must have \"contact\" object.\n100% sure", gospectral.SeverityWarn, []string{"info"}, [2]int{1, 5}, [2]int{3, 20}),
+		testRule("/api/openapi.yaml", "oas3-schema", "invalid", gospectral.SeverityError, []string{"info"}, [2]int{4, 0}, [2]int{4, 2}),
+		testRule("/api/openapi.yaml", "info-description", "missing", gospectral.SeverityHint, []string{"info"}, [2]int{5, 0}, [2]int{5, 2}),
+	}
+
+	// Act
+	err := GitHubActions(&buf, output)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `::warning title=info-contact,file=/api/openapi.yaml,col=6,endColumn=21,line=2,endLine=4::Info object must have "contact" object.%0A100%25 sure
+::error title=oas3-schema,file=/api/openapi.yaml,col=1,endColumn=3,line=5,endLine=5::invalid
+::notice title=info-description,file=/api/openapi.yaml,col=1,endColumn=3,line=6,endLine=6::missing
+`, buf.String())
+}
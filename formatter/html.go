@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"html/template"
+	"io"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// htmlTemplate of the report with a table per source
+var htmlTemplate = template.Must(template.New("html").Funcs(template.FuncMap{
+	"inc":  func(i int) int { return i + 1 },
+	"path": printPath,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Spectral Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ddd; padding: 0.4em; text-align: left; vertical-align: top; }
+.error { color: #c62828; } .warn { color: #ef6c00; } .info { color: #1565c0; } .hint { color: #2e7d32; }
+</style>
+</head>
+<body>
+<h1>Spectral Report</h1>
+<p>{{ .Total }} problem(s) ({{ .Errors }} error(s), {{ .Warnings }} warning(s), {{ .Infos }} info(s), {{ .Hints }} hint(s))</p>
+{{- range .Sources }}
+<h2>{{ .Source }}</h2>
+<table>
+<thead><tr><th>Line</th><th>Severity</th><th>Code</th><th>Message</th><th>Path</th></tr></thead>
+<tbody>
+{{- range .Rules }}
+<tr><td>{{ inc .Range.Start.Line }}:{{ inc .Range.Start.Character }}</td><td class="{{ .Severity }}">{{ .Severity }}</td><td>{{ .Code }}</td><td>{{ .Message }}</td><td>{{ path .Path }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+</body>
+</html>
+`))
+
+// htmlSource groups the rules of a source
+type htmlSource struct {
+	Source string
+	Rules  gospectral.Output
+}
+
+// htmlReport is the data of the htmlTemplate
+type htmlReport struct {
+	Total, Errors, Warnings, Infos, Hints int
+	Sources                               []htmlSource
+}
+
+// HTML writes a standalone HTML page with a table of the rules per source
+func HTML(w io.Writer, output gospectral.Output, _ ...Option) error {
+	report := htmlReport{Total: len(output)}
+	sources, groups := groupBySource(sorted(output))
+	for _, source := range sources {
+		report.Sources = append(report.Sources, htmlSource{Source: source, Rules: groups[source]})
+	}
+
+	for _, rule := range output {
+		switch rule.Severity {
+		case gospectral.SeverityError:
+			report.Errors++
+		case gospectral.SeverityWarn:
+			report.Warnings++
+		case gospectral.SeverityInfo:
+			report.Infos++
+		case gospectral.SeverityHint:
+			report.Hints++
+		}
+	}
+
+	return htmlTemplate.Execute(w, report)
+}
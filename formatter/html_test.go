@@ -0,0 +1,26 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := HTML(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	html := buf.String()
+	assert.Contains(t, html, "<title>Spectral Report</title>")
+	assert.Contains(t, html, "3 problem(s) (1 error(s), 1 warning(s), 0 info(s), 1 hint(s))")
+	assert.Contains(t, html, "<h2>/api/common.yaml</h2>")
+	assert.Contains(t, html, `<tr><td>2:6</td><td class="warn">warn</td><td>info-contact</td><td>Info object must have &#34;contact&#34; object.</td><td>info</td></tr>`)
+}
@@ -0,0 +1,24 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// JSON writes the gospectral.Output as an indented JSON array
+func JSON(w io.Writer, output gospectral.Output, _ ...Option) error {
+	if output == nil {
+		output = gospectral.Output{}
+	}
+
+	b, err := json.MarshalIndent(output, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
@@ -0,0 +1,39 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := JSON(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	var actual gospectral.Output
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+	assert.Equal(t, testOutput, actual)
+}
+
+func TestJSON_EmptyOutput(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := JSON(&buf, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "[]", buf.String())
+}
@@ -0,0 +1,79 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// junitTestSuites is the root element of a JUnit report
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite per source
+type junitTestSuite struct {
+	Package   string          `xml:"package,attr"`
+	Time      int             `xml:"time,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Name      string          `xml:"name,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase per Rule
+type junitTestCase struct {
+	Time      int          `xml:"time,attr"`
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Failure   junitFailure `xml:"failure"`
+}
+
+// junitFailure describing the Rule
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Details string `xml:",cdata"`
+}
+
+// JUnit writes a JUnit XML report with a test suite per source and a failed test case per Rule
+func JUnit(w io.Writer, output gospectral.Output, _ ...Option) error {
+	report := junitTestSuites{}
+	sources, groups := groupBySource(sorted(output))
+	for _, source := range sources {
+		suite := junitTestSuite{
+			Package:  "org.spectral",
+			Tests:    len(groups[source]),
+			Failures: len(groups[source]),
+			Name:     source,
+		}
+
+		for _, rule := range groups[source] {
+			path := printPath(rule.Path)
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      fmt.Sprintf("org.spectral.%s(%s)", rule.Code, path),
+				ClassName: strings.TrimSuffix(source, filepath.Ext(source)),
+				Failure: junitFailure{
+					Message: rule.Message,
+					Details: fmt.Sprintf("line %d, col %d, %s (%s) at path #%s", rule.Range.Start.Line+1, rule.Range.Start.Character+1, rule.Message, rule.Code, path),
+				},
+			})
+		}
+
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, xml.Header+string(b)+"\n")
+
+	return err
+}
@@ -0,0 +1,40 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJUnit(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := JUnit(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.TestSuites, 2)
+	assert.Equal(t, "/api/common.yaml", report.TestSuites[0].Name)
+	assert.Equal(t, 1, report.TestSuites[0].Failures)
+
+	suite := report.TestSuites[1]
+	assert.Equal(t, "/api/openapi.yaml", suite.Name)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 2, suite.Failures)
+	require.Len(t, suite.TestCases, 2)
+	assert.Equal(t, "org.spectral.info-contact(info)", suite.TestCases[0].Name)
+	assert.Equal(t, "/api/openapi", suite.TestCases[0].ClassName)
+	assert.Equal(t, `Info object must have "contact" object.`, suite.TestCases[0].Failure.Message)
+	assert.Equal(t, `line 2, col 6, Info object must have "contact" object. (info-contact) at path #info`, suite.TestCases[0].Failure.Details)
+}
@@ -0,0 +1,43 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// markdownEscaper escapes table cells
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ")
+
+// Markdown writes a Markdown table with a row per Rule
+func Markdown(w io.Writer, output gospectral.Output, _ ...Option) error {
+	var sb strings.Builder
+	sb.WriteString("| Code | Path | Message | Severity | Start | End | Source |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+	for _, rule := range sorted(output) {
+		fmt.Fprintf(&sb, "| %s | %s | %s | %s | %d:%d | %d:%d | %s |\n",
+			markdownCode(rule),
+			markdownEscaper.Replace(printPath(rule.Path)),
+			markdownEscaper.Replace(rule.Message),
+			rule.Severity,
+			rule.Range.Start.Line+1, rule.Range.Start.Character+1,
+			rule.Range.End.Line+1, rule.Range.End.Character+1,
+			markdownEscaper.Replace(rule.Source),
+		)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// markdownCode is the code of the Rule, linking to the documentation of the rule if available
+func markdownCode(rule gospectral.Rule) string {
+	if rule.DocumentationURL == "" {
+		return markdownEscaper.Replace(rule.Code)
+	}
+
+	return "[" + markdownEscaper.Replace(rule.Code) + "](" + markdownEscaper.Replace(rule.DocumentationURL) + ")"
+}
@@ -0,0 +1,44 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdown(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+	output := gospectral.Output{
+		testRule("/api/openapi.yaml", "info-contact", "must | have", gospectral.SeverityWarn, []string{"info"}, [2]int{1, 5}, [2]int{3, 20}),
+	}
+
+	// Act
+	err := Markdown(&buf, output)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `| Code | Path | Message | Severity | Start | End | Source |
+| --- | --- | --- | --- | --- | --- | --- |
+| info-contact | info | must \| have | warn | 2:6 | 4:21 | /api/openapi.yaml |
+`, buf.String())
+}
+
+func TestMarkdown_LinksDocumentation(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+	rule := testRule("/api/openapi.yaml", "info-contact", "m", gospectral.SeverityWarn, []string{"info"}, [2]int{1, 5}, [2]int{3, 20})
+	rule.DocumentationURL = "https://example.com/oas#info-contact"
+
+	// Act
+	err := Markdown(&buf, gospectral.Output{rule})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "| [info-contact](https://example.com/oas#info-contact) | info | m | warn | 2:6 | 4:21 | /api/openapi.yaml |\n")
+}
@@ -0,0 +1,186 @@
+package formatter
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+const (
+	// sarifSchema of the SARIF 2.1.0 format
+	sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifVersion of the SARIF format
+	sarifVersion = "2.1.0"
+	// sarifInformationURI of the tool producing the results
+	sarifInformationURI = "https://github.com/stoplightio/spectral"
+)
+
+// sarifLog is the root object of a SARIF report
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// sarifRun of the tool
+type sarifRun struct {
+	Tool      sarifTool       `json:"tool"`
+	Artifacts []sarifArtifact `json:"artifacts"`
+	Results   []sarifResult   `json:"results"`
+}
+
+// sarifTool is Spectral
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+// sarifDriver with the reporting descriptors of the rules
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+// sarifRule is a reporting descriptor per code
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+	HelpURI          string        `json:"helpUri,omitempty"`
+}
+
+// sarifArtifact is a linted source
+type sarifArtifact struct {
+	Location sarifArtifactLocation `json:"location"`
+}
+
+// sarifArtifactLocation of a source
+type sarifArtifactLocation struct {
+	URI   string `json:"uri"`
+	Index *int   `json:"index,omitempty"`
+}
+
+// sarifResult per Rule
+type sarifResult struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+// sarifMessage is a plain text message
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLocation of a result
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+// sarifPhysicalLocation in an artifact
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+// sarifRegion with 1-based lines and columns
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+// sarifLevels by Severity
+var sarifLevels = map[gospectral.Severity]string{
+	gospectral.SeverityError: "error",
+	gospectral.SeverityWarn:  "warning",
+	gospectral.SeverityInfo:  "note",
+	gospectral.SeverityHint:  "note",
+}
+
+// SARIF writes a SARIF 2.1.0 report, e.g. to upload to GitHub code scanning
+func SARIF(w io.Writer, output gospectral.Output, options ...Option) error {
+	opts, err := newOptions(options)
+	if err != nil {
+		return err
+	}
+
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "spectral",
+			InformationURI: sarifInformationURI,
+			Rules:          []sarifRule{},
+		}},
+		Artifacts: []sarifArtifact{},
+		Results:   []sarifResult{},
+	}
+
+	rules := map[string]int{}
+	artifacts := map[string]int{}
+	for _, rule := range sorted(output) {
+		ruleIndex, ok := rules[rule.Code]
+		if !ok {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			rules[rule.Code] = ruleIndex
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifReportingDescriptor(rule))
+		}
+
+		uri := sarifURI(rule.Source, opts.BaseDirectory)
+		artifactIndex, ok := artifacts[uri]
+		if !ok {
+			artifactIndex = len(run.Artifacts)
+			artifacts[uri] = artifactIndex
+			run.Artifacts = append(run.Artifacts, sarifArtifact{Location: sarifArtifactLocation{URI: uri}})
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			Level:     sarifLevels[rule.Severity],
+			Message:   sarifMessage{Text: rule.Message},
+			RuleID:    rule.Code,
+			RuleIndex: ruleIndex,
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: uri, Index: &artifactIndex},
+				Region: sarifRegion{
+					StartLine:   rule.Range.Start.Line + 1,
+					StartColumn: rule.Range.Start.Character + 1,
+					EndLine:     rule.Range.End.Line + 1,
+					EndColumn:   rule.Range.End.Character + 1,
+				},
+			}}},
+		})
+	}
+
+	b, err := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
+
+// sarifReportingDescriptor of the rule that produced the Rule
+func sarifReportingDescriptor(rule gospectral.Rule) sarifRule {
+	descriptor := sarifRule{ID: rule.Code, HelpURI: rule.DocumentationURL}
+	if rule.Description != "" {
+		descriptor.ShortDescription = &sarifMessage{Text: rule.Description}
+	}
+
+	return descriptor
+}
+
+// sarifURI of the source relative to the base directory if possible
+func sarifURI(source string, base string) string {
+	source = relativePath(source, base)
+	if filepath.IsAbs(source) {
+		return (&url.URL{Scheme: "file", Path: filepath.ToSlash(source)}).String()
+	}
+
+	return (&url.URL{Path: source}).String()
+}
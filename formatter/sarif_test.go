@@ -0,0 +1,76 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSARIF(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := SARIF(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	assert.Equal(t, "spectral", run.Tool.Driver.Name)
+	assert.Equal(t, []sarifRule{{ID: "info-description"}, {ID: "info-contact"}, {ID: "oas3-schema"}}, run.Tool.Driver.Rules)
+	assert.Equal(t, []sarifArtifact{{Location: sarifArtifactLocation{URI: "file:///api/common.yaml"}}, {Location: sarifArtifactLocation{URI: "file:///api/openapi.yaml"}}}, run.Artifacts)
+	require.Len(t, run.Results, 3)
+
+	result := run.Results[2]
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "oas3-schema", result.RuleID)
+	assert.Equal(t, 2, result.RuleIndex)
+	assert.Equal(t, "file:///api/openapi.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 1, *result.Locations[0].PhysicalLocation.ArtifactLocation.Index)
+	assert.Equal(t, sarifRegion{StartLine: 10, StartColumn: 13, EndLine: 10, EndColumn: 19}, result.Locations[0].PhysicalLocation.Region)
+}
+
+func TestSARIF_EmptyOutput(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := SARIF(&buf, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"results": []`)
+}
+
+func TestSARIF_DescribesRules(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+	rule := testRule("/api/openapi.yaml", "info-contact", "m", gospectral.SeverityWarn, []string{"info"}, [2]int{1, 5}, [2]int{3, 20})
+	rule.Description = "Info object must have \"contact\" object."
+	rule.DocumentationURL = "https://example.com/oas#info-contact"
+
+	// Act
+	err := SARIF(&buf, gospectral.Output{rule})
+
+	// Assert
+	require.NoError(t, err)
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, []sarifRule{{
+		ID:               "info-contact",
+		ShortDescription: &sarifMessage{Text: "Info object must have \"contact\" object."},
+		HelpURI:          "https://example.com/oas#info-contact",
+	}}, log.Runs[0].Tool.Driver.Rules)
+}
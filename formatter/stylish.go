@@ -0,0 +1,86 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// Stylish writes the gospectral.Output grouped by source as aligned tables followed by a summary, e.g.
+//
+//	/api/openapi.yaml
+//	 2:6  warning  info-contact  Info object must have "contact" object.  info
+//
+//	✖ 1 problem (0 errors, 1 warning, 0 infos, 0 hints)
+func Stylish(w io.Writer, output gospectral.Output, _ ...Option) error {
+	if len(output) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	counts := map[gospectral.Severity]int{}
+	sources, groups := groupBySource(sorted(output))
+	for _, source := range sources {
+		rows := make([][]string, 0, len(groups[source]))
+		for _, rule := range groups[source] {
+			counts[rule.Severity]++
+			rows = append(rows, []string{
+				strconv.Itoa(rule.Range.Start.Line+1) + ":" + strconv.Itoa(rule.Range.Start.Character+1),
+				stylishSeverity(rule.Severity),
+				rule.Code,
+				rule.Message,
+				printPath(rule.Path),
+			})
+		}
+
+		sb.WriteString("\n" + source + "\n")
+		writeTable(&sb, rows)
+		sb.WriteString("\n")
+	}
+
+	errs, warnings, infos, hints := counts[gospectral.SeverityError], counts[gospectral.SeverityWarn], counts[gospectral.SeverityInfo], counts[gospectral.SeverityHint]
+	fmt.Fprintf(&sb, "✖ %d %s (%d %s, %d %s, %d %s, %d %s)\n",
+		len(output), pluralize("problem", len(output)),
+		errs, pluralize("error", errs),
+		warnings, pluralize("warning", warnings),
+		infos, pluralize("info", infos),
+		hints, pluralize("hint", hints),
+	)
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// stylishSeverity is the name of the severity as printed by the stylish formatter
+func stylishSeverity(severity gospectral.Severity) string {
+	if severity == gospectral.SeverityWarn {
+		return "warning"
+	}
+
+	return severity.String()
+}
+
+// writeTable writes the rows with the columns padded to equal width and separated by two spaces
+func writeTable(sb *strings.Builder, rows [][]string) {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+
+	for _, row := range rows {
+		line := ""
+		for i, cell := range row {
+			line += "  " + cell + strings.Repeat(" ", widths[i]-len([]rune(cell)))
+		}
+		sb.WriteString(strings.TrimRight(line, " ")[1:] + "\n")
+	}
+}
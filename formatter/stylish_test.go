@@ -0,0 +1,45 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStylish(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := Stylish(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `
+/api/common.yaml
+ 1:1  hint  info-description  Info "description" must be present and non-empty string.  info
+
+
+/api/openapi.yaml
+ 2:6    warning  info-contact  Info object must have "contact" object.                      info
+ 10:13  error    oas3-schema   "type" property must be equal to one of the allowed values.  components.schemas.User.type
+
+✖ 3 problems (1 error, 1 warning, 0 infos, 1 hint)
+`, buf.String())
+}
+
+func TestStylish_EmptyOutput(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := Stylish(&buf, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,32 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// teamCityEscaper escapes values in TeamCity service messages
+var teamCityEscaper = strings.NewReplacer("|", "||", "'", "|'", "\n", "|n", "\r", "|r", "[", "|[", "]", "|]")
+
+// TeamCity writes TeamCity service messages, an inspectionType per code and an inspection per Rule
+func TeamCity(w io.Writer, output gospectral.Output, _ ...Option) error {
+	var sb strings.Builder
+	inspectionTypes := map[string]bool{}
+	for _, rule := range sorted(output) {
+		code := teamCityEscaper.Replace(rule.Code)
+		if !inspectionTypes[code] {
+			inspectionTypes[code] = true
+			fmt.Fprintf(&sb, "##teamcity[inspectionType category='openapi' id='%s' name='%s' description='%s']\n", code, code, code)
+		}
+
+		fmt.Fprintf(&sb, "##teamcity[inspection typeId='%s' file='%s' line='%d' message='%s -- %s']\n",
+			code, teamCityEscaper.Replace(rule.Source), rule.Range.Start.Line+1, rule.Severity, teamCityEscaper.Replace(rule.Message))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
@@ -0,0 +1,30 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	gospectral "github.com/Emptyless/go-spectral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamCity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+	output := gospectral.Output{
+		testRule("/api/openapi.yaml", "info-contact", "Info object must have 'contact' object.", gospectral.SeverityWarn, []string{"info"}, [2]int{1, 5}, [2]int{3, 20}),
+		testRule("/api/openapi.yaml", "info-contact", "[duplicate]", gospectral.SeverityWarn, []string{"info"}, [2]int{2, 5}, [2]int{3, 20}),
+	}
+
+	// Act
+	err := TeamCity(&buf, output)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `##teamcity[inspectionType category='openapi' id='info-contact' name='info-contact' description='info-contact']
+##teamcity[inspection typeId='info-contact' file='/api/openapi.yaml' line='2' message='warn -- Info object must have |'contact|' object.']
+##teamcity[inspection typeId='info-contact' file='/api/openapi.yaml' line='3' message='warn -- |[duplicate|]']
+`, buf.String())
+}
@@ -0,0 +1,19 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+
+	gospectral "github.com/Emptyless/go-spectral"
+)
+
+// Text writes a line per Rule in the form source:line:character severity code "message"
+func Text(w io.Writer, output gospectral.Output, _ ...Option) error {
+	for _, rule := range sorted(output) {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d %s %s \"%s\"\n", rule.Source, rule.Range.Start.Line+1, rule.Range.Start.Character+1, rule.Severity, rule.Code, rule.Message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
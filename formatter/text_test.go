@@ -0,0 +1,25 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestText(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var buf bytes.Buffer
+
+	// Act
+	err := Text(&buf, testOutput)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `/api/common.yaml:1:1 hint info-description "Info "description" must be present and non-empty string."
+/api/openapi.yaml:2:6 warn info-contact "Info object must have "contact" object."
+/api/openapi.yaml:10:13 error oas3-schema ""type" property must be equal to one of the allowed values."
+`, buf.String())
+}
@@ -0,0 +1,180 @@
+package gospectral
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// goFunctions global variable name of the object holding the Config.Functions in the runtime
+const goFunctions = "goSpectralFunctions"
+
+// FunctionsDir is the directory relative to the ruleset in which the Config.Functions are served, equal to the
+// default functionsDir of a spectral ruleset
+const FunctionsDir = "functions"
+
+// Function is a custom rule function implemented in Go, called with the input targeted by the `then` of a rule, the
+// `functionOptions` of the rule and a FunctionContext. Every returned Result is reported as a failure of the rule.
+type Function func(input any, options map[string]any, ctx FunctionContext) []Result
+
+// FunctionContext of a Function call
+type FunctionContext struct {
+	// Rule is the name of the rule calling the Function, e.g. operation-id-kebab-case
+	Rule string
+
+	// Document is the source of the document of the input
+	Document string
+
+	// Path to the input in the Document
+	Path Path
+}
+
+// Result of a Function, reported by spectral as a failure of the rule
+type Result struct {
+	// Message of the failure
+	Message string
+
+	// Path to the failing node, defaults to the path of the input if empty
+	Path Path
+}
+
+// ErrInvalidFunction when a Function is nil or has a name that cannot be loaded as a spectral function
+var ErrInvalidFunction = errors.New("invalid function")
+
+// WithFunction registers the Go Function in the runtime under name such that rulesets can use it as a custom
+// function, e.g.
+//
+//	functions: [myGoFunc]
+//	rules:
+//	  my-rule:
+//	    given: $.info
+//	    then:
+//	      function: myGoFunc
+//
+// The Function is served as a JS function module in the FunctionsDir next to the ruleset. Only the
+// Ruleset.FunctionsDir of a Ruleset supplied with WithRuleset changes the directory: a functionsDir declared in a
+// ruleset file (or the Config.RulesetContent) is not read, so such a ruleset must keep the default functionsDir to
+// use a Function.
+func WithFunction(name string, fn Function) Option {
+	return func(config *Config) error {
+		if fn == nil || name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+			return fmt.Errorf("'%s': %w", name, ErrInvalidFunction)
+		}
+
+		if config.Functions == nil {
+			config.Functions = map[string]Function{}
+		}
+		config.Functions[name] = fn
+
+		return nil
+	}
+}
+
+// functionFiles are the JS function modules of the Config.Functions, keyed by their path in the functionsDir
+// relative to the ruleset
+func functionFiles(functions map[string]Function, ruleset string, functionsDir string) map[string][]byte {
+	files := make(map[string][]byte, len(functions))
+	for name := range functions {
+		files[path.Join(path.Dir(ruleset), functionsDir, name+".js")] = functionModule(name)
+	}
+
+	return files
+}
+
+// functionModule is the source of the JS function module calling the Function with name
+func functionModule(name string) []byte {
+	quoted := strconv.Quote(name)
+
+	return []byte("export default function (input, options, context) {\n" +
+		"    return " + goFunctions + "[" + quoted + "](input, options, context);\n" +
+		"}\n")
+}
+
+// EnableFunctions sets the goSpectralFunctions global to an object with the functions, called by the JS function
+// modules served for the Config.Functions
+func EnableFunctions(runtime *goja.Runtime, functions map[string]Function) error {
+	object := runtime.NewObject()
+	for name, fn := range functions {
+		if err := object.Set(name, callFunction(runtime, name, fn)); err != nil {
+			return err
+		}
+	}
+
+	return runtime.GlobalObject().Set(goFunctions, object)
+}
+
+// callFunction converts the arguments of a spectral function call for the Function and converts the Result's to
+// IFunctionResult's. A panic of the Function is thrown as a JS error instead of crashing the runtime.
+func callFunction(runtime *goja.Runtime, name string, fn Function) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		input := call.Argument(0).Export()
+		options, _ := call.Argument(1).Export().(map[string]any)
+		ctx := functionContext(runtime, call.Argument(2))
+
+		var results []Result
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					panic(runtime.NewGoError(fmt.Errorf("function %s panicked: %v", name, r)))
+				}
+			}()
+
+			results = fn(input, options, ctx)
+		}()
+
+		if len(results) == 0 {
+			return goja.Undefined()
+		}
+
+		values := make([]any, len(results))
+		for i, result := range results {
+			value := map[string]any{"message": result.Message}
+			if result.Path != nil {
+				value["path"] = []string(result.Path)
+			}
+			values[i] = value
+		}
+
+		return runtime.ToValue(values)
+	}
+}
+
+// functionContext of the spectral function context, i.e. { rule: { name }, document: { source }, path }
+func functionContext(runtime *goja.Runtime, value goja.Value) FunctionContext {
+	var ctx FunctionContext
+	if goja.IsUndefined(value) || goja.IsNull(value) {
+		return ctx
+	}
+
+	object := value.ToObject(runtime)
+	if rule, ok := object.Get("rule").(*goja.Object); ok {
+		ctx.Rule = stringOf(rule.Get("name"))
+	}
+
+	if document, ok := object.Get("document").(*goja.Object); ok {
+		ctx.Document = stringOf(document.Get("source"))
+	}
+
+	if p := object.Get("path"); p != nil && !goja.IsUndefined(p) && !goja.IsNull(p) {
+		// the path contains strings and numbers (array indices), which Path converts to strings
+		if data, err := json.Marshal(p.Export()); err == nil {
+			_ = json.Unmarshal(data, &ctx.Path)
+		}
+	}
+
+	return ctx
+}
+
+// stringOf the value or "" if it is undefined or null
+func stringOf(value goja.Value) string {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return ""
+	}
+
+	return value.String()
+}
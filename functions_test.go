@@ -0,0 +1,117 @@
+package gospectral
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kebabCase is a Function reporting keys of the input that are not kebab-case
+func kebabCase(input any, _ map[string]any, ctx FunctionContext) []Result {
+	var results []Result
+	for key := range input.(map[string]any) {
+		if strings.ToLower(key) != key {
+			results = append(results, Result{Message: ctx.Rule + ": " + key + " is not kebab-case in " + ctx.Document, Path: append(slices.Clone(ctx.Path), key)})
+		}
+	}
+
+	return results
+}
+
+func TestWithFunction_RejectsInvalidFunction(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		name string
+		fn   Function
+	}{
+		"empty name":  {name: "", fn: kebabCase},
+		"nested name": {name: "casing/kebab", fn: kebabCase},
+		"parent name": {name: "..", fn: kebabCase},
+		"nil":         {name: "kebabCase", fn: nil},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := New(WithFunction(test.name, test.fn))
+
+			// Assert
+			require.ErrorIs(t, err, ErrInvalidFunction)
+		})
+	}
+}
+
+func TestLint_ServesFunctionModuleNextToRuleset(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`fs.promises.readFile(require('path').resolve(process.cwd(), "rulesets/functions/kebabCase.js")).then(function(content) {
+		return JSON.stringify([{ code: String(content) }]);
+	})`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./rulesets/.spectral.yaml", WithDist([]byte("")), WithScript(script),
+		WithFunction("kebabCase", kebabCase), WithWorkingDirectory("/wd"))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, `export default function (input, options, context) {
+    return goSpectralFunctions["kebabCase"](input, options, context);
+}
+`, output[0].Code)
+}
+
+func TestLint_CallsFunction(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`var results = goSpectralFunctions.kebabCase({ "user-id": 1, "userName": 2 }, null, {
+		rule: { name: "kebab-keys" },
+		document: { source: "/wd/openapi.yaml" },
+		path: ["paths", "/users", "get", "parameters", 0]
+	});
+	JSON.stringify(results.map(function(result) { return { code: result.path.join("."), message: result.message }; }))`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript(script),
+		WithFunction("kebabCase", kebabCase))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "paths./users.get.parameters.0.userName", output[0].Code)
+	assert.Equal(t, "kebab-keys: userName is not kebab-case in /wd/openapi.yaml", output[0].Message)
+}
+
+func TestLint_CallsFunctionWithoutResults(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`JSON.stringify([{ code: typeof goSpectralFunctions.kebabCase({ "user-id": 1 }, { max: 1 }) }])`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript(script),
+		WithFunction("kebabCase", kebabCase))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "undefined", output[0].Code)
+}
+
+func TestLint_ThrowsWhenFunctionPanics(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`var message;
+	try { goSpectralFunctions.kebabCase("not an object"); } catch (e) { message = e.message; }
+	JSON.stringify([{ code: message }])`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript(script),
+		WithFunction("kebabCase", kebabCase))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "function kebabCase panicked: interface conversion: interface {} is string, not map[string]interface {}", output[0].Code)
+}
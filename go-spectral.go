@@ -1,34 +1,36 @@
 package gospectral
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
-	"os"
+	"net/http"
 	"reflect"
 	"strings"
-	"sync"
 	"unsafe"
 
 	"github.com/dop251/goja"
-	noderequire "github.com/dop251/goja_nodejs/require"
 )
 
 // Config to instantiate the runner
 type Config struct {
 	// Dist is the source JS file exporting
 	// 1) function: formatOutput
-	// 2) promise: lint output using global variables lintDocuments and lintRuleset
+	// 2) function: lint(documents, options) returning a promise of the lint output
 	//
-	// See the index.js for more details
+	// See the index.js for more details. The Dist is loaded once per runtime and linted with many times.
 	Dist []byte
 
-	// Script that evaluates the Dist, i.e. by the default
-	// by using the output of the lint promise and wrapping
-	// it in a formatOutput promise
+	// Script that evaluates the Dist, i.e. by the default by calling lint with the global variables lintDocuments
+	// and lintOptions and wrapping the output in a formatOutput promise
 	Script []byte
 
+	// LintOptions passed to the lint function of the Dist, defaults to DefaultLintOptions
+	LintOptions LintOptions
+
 	// FS to use when loading document(s) and ruleset. If set, when a file is loaded (e.g. an OpenAPI document or
 	// a ruleset) it is first searched in the FS *without* the WorkingDirectory reference. If the file is not found
 	// in the FS, continue the search relative to the WorkingDirectory. This mechanism allows to bundle static files
@@ -38,144 +40,161 @@ type Config struct {
 	// WorkingDirectory, defaults to os.Getcwd() if ""
 	WorkingDirectory string
 
-	// BeforeModule hook to customize behavior before (or instead of) enabling a module
+	// RulesetContent if not nil is the ruleset served from memory instead of loading it from a file
+	RulesetContent []byte
+
+	// Ruleset if not nil is serialized into the RulesetContent, see WithRuleset
+	Ruleset *Ruleset
+
+	// Functions are custom rule functions implemented in Go by name, see WithFunction
+	Functions map[string]Function
+
+	// HTTPClient performing the requests of the http and https packages and the fetch global, e.g. to resolve
+	// remote $ref's and rulesets. Defaults to http.DefaultClient
+	HTTPClient *http.Client
+
+	// NetworkPolicy if not nil restricts the requests made with the HTTPClient, see WithNetworkPolicy
+	NetworkPolicy *NetworkPolicy
+
+	// Sandbox confines the file access of a lint, defaults to SandboxNone, see WithSandbox
+	Sandbox Sandbox
+
+	// Stdout receives the writes to process.stdout, which are discarded if nil, see WithStdout
+	Stdout io.Writer
+
+	// Stderr receives the writes to process.stderr, which are discarded if nil, see WithStderr
+	Stderr io.Writer
+
+	// Env are the variables of process.env, which take precedence over the inherited environment, see WithEnv
+	Env map[string]string
+
+	// InheritEnv copies the environment of the program (os.Environ) into process.env, defaults to true, see
+	// WithInheritEnv
+	InheritEnv bool
+
+	// Argv of process.argv, defaults to process.DefaultArgv if nil, see WithArgv
+	Argv []string
+
+	// BeforeModule hook to customize behavior before (or instead of) enabling a module. Defaults to
+	// DefaultBeforeModule. The in-memory documents (see LintBytes) are served before the fs.FS and working directory
+	// the BeforeModule enables the fs module with, as long as it is enabled by the nodefs package
 	BeforeModule BeforeModule
 
 	// AfterModule hook to customize runtime or registry state
 	AfterModule AfterModule
+
+	// FailSeverity if not nil makes Lint return a *LintFailedError if any Rule is at least as severe
+	FailSeverity *Severity
+
+	// PoolSize is the maximum number of idle runtimes a Linter keeps for reuse, defaults to runtime.GOMAXPROCS(0)
+	PoolSize int
 }
 
 // Output is a slice of Rule
 type Output []Rule
 
-// Rule is an instance of a failure during Lint
+// Rule is an instance of a failure during Lint, carrying every field of the spectral JSON formatter
 type Rule struct {
-	Source   string   `json:"source"`
+	// Source is the document the Rule was found in, which is a referenced document if the failure is in a $ref
+	Source string `json:"source"`
+
+	// Document is the linted document that (possibly through a $ref) led to the Source, empty if it is ambiguous
+	Document string `json:"document,omitempty"`
+
 	Code     string   `json:"code"`
-	Path     []string `json:"path"`
+	Path     Path     `json:"path"`
 	Message  string   `json:"message"`
-	Severity int      `json:"severity"`
-	Range    struct {
-		Start struct {
-			Line      int `json:"line"`
-			Character int `json:"character"`
-		} `json:"start"`
-		End struct {
-			Line      int `json:"line"`
-			Character int `json:"character"`
-		} `json:"end"`
-	}
-}
-
-// lintDocuments global variable name when providing a custom dist
-const lintDocuments = "lintDocuments"
+	Severity Severity `json:"severity"`
+	Range    Range    `json:"range"`
 
-const lintRuleset = "lintRuleset"
+	// DocumentationURL of the rule, e.g. https://docs.stoplight.io/docs/spectral/4dec24461f3af-open-api-rules#info-contact
+	DocumentationURL string `json:"documentationUrl,omitempty"`
 
-// Option that can be supplied to modify the Config
-type Option func(config *Config) error
+	// Description of the rule
+	Description string `json:"description,omitempty"`
 
-// lock the Lint method to a single invocation at once
-var lock sync.Mutex
+	// Ruleset is the source of the ruleset that defines the rule, empty for built-in rulesets like spectral:oas
+	Ruleset string `json:"ruleset,omitempty"`
+}
 
-// Lint OpenAPI documents (e.g. openapi.yaml) with a Spectral ruleset, e.g. `extends: ["spectral:oas"]`
-func Lint(documents []string, ruleset string, options ...Option) (Output, error) { //nolint:cyclop // accepted complexity
-	// make the Lint method somewhat thread safe (depends on global variables in node packages)
-	lock.Lock()
-	defer lock.Unlock()
-
-	// instantiate default Config
-	cfg := &Config{
-		Dist:         DefaultDist(),
-		Script:       DefaultScript(),
-		BeforeModule: nil,
-		AfterModule:  nil,
-	}
+// Position in a document, both the Line and Character are zero-based
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
 
-	// apply Option's
-	for _, option := range options {
-		if err := option(cfg); err != nil {
-			return nil, err
-		}
-	}
+// Range in a document from Start up to and including End
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
 
-	// Set working directory if ""
-	if cfg.WorkingDirectory == "" {
-		wd, err := os.Getwd()
-		if err != nil {
-			return nil, err
-		}
+// Path to the failing node in the Rule.Source, e.g. ["paths", "/users", "get", "parameters", "0"]
+type Path []string
 
-		cfg.WorkingDirectory = wd
+// UnmarshalJSON implementation of Path, converting array indices (numbers) to strings
+func (p *Path) UnmarshalJSON(data []byte) error {
+	var segments []json.RawMessage
+	if err := json.Unmarshal(data, &segments); err != nil {
+		return err
 	}
 
-	// Set default BeforeModule if nil such that node:fs and node:process can use the working directory and/or virtual file system
-	if cfg.BeforeModule == nil {
-		cfg.BeforeModule = DefaultBeforeModule(cfg.WorkingDirectory, cfg.FS)
+	if segments == nil {
+		*p = nil
+		return nil
 	}
 
-	// initiate runtime with NodeJS modules
-	runtime := goja.New()
-	registry := noderequire.NewRegistry(noderequire.WithLoader(func(path string) ([]byte, error) {
-		if path == DistName {
-			return cfg.Dist, nil
+	path := make(Path, len(segments))
+	for i, segment := range segments {
+		var index json.Number
+		if err := json.Unmarshal(segment, &path[i]); err == nil {
+			continue
+		} else if err := json.Unmarshal(segment, &index); err != nil {
+			return fmt.Errorf("invalid path segment %s: %w", segment, err)
 		}
 
-		return noderequire.DefaultSourceLoader(path)
-	}))
-	require, loadModulesErr := LoadModules(runtime, registry, cfg.BeforeModule, cfg.AfterModule)
-	if loadModulesErr != nil {
-		return nil, loadModulesErr
+		path[i] = index.String()
 	}
 
-	// set the __dirname global to the working directory
-	if err := runtime.GlobalObject().Set("__dirname", runtime.ToValue(cfg.WorkingDirectory)); err != nil {
-		return nil, err
-	}
+	*p = path
 
-	// set the lintDocuments global variable
-	if err := runtime.GlobalObject().Set(lintDocuments, runtime.ToValue(documents)); err != nil {
-		return nil, err
-	}
+	return nil
+}
 
-	// set the lintRuleset global variable
-	if err := runtime.GlobalObject().Set(lintRuleset, runtime.ToValue(ruleset)); err != nil {
-		return nil, err
-	}
+// lintDocuments global variable name when providing a custom dist
+const lintDocuments = "lintDocuments"
 
-	if err := EnableDist(require); err != nil {
-		return nil, &EvaluateError{Err: err}
-	}
+// lintRuleset global variable name when providing a custom dist
+const lintRuleset = "lintRuleset"
 
-	// run the script
-	v, err := runtime.RunString(string(cfg.Script))
-	if err != nil {
-		return nil, &EvaluateError{Err: err}
-	}
+// Option that can be supplied to modify the Config
+type Option func(config *Config) error
 
-	// if the result is a goja.Promise, wait for completion
-	value := v.Export()
-	promise, ok := value.(*goja.Promise)
-	if ok {
-		for promise.State() == goja.PromiseStatePending {
-			continue
-		}
-		if promise.State() == goja.PromiseStateRejected {
-			return nil, fmt.Errorf("%s: %w", promise.Result().String(), ErrPromiseRejected)
-		}
-		value = promise.Result().String()
-	}
+// Lint OpenAPI documents (e.g. openapi.yaml) with a Spectral ruleset, e.g. `extends: ["spectral:oas"]`
+func Lint(documents []string, ruleset string, options ...Option) (Output, error) {
+	return LintContext(context.Background(), documents, ruleset, options...)
+}
 
-	if _, ok := value.(string); !ok {
-		return nil, fmt.Errorf("invalid value type of Lint result '%T': %w", value, ErrUnknownReturn)
+// LintContext is Lint with a context.Context. If the context is cancelled or its deadline passes, the runtime is
+// interrupted and a *ContextError wrapping ctx.Err() is returned.
+func LintContext(ctx context.Context, documents []string, ruleset string, options ...Option) (Output, error) {
+	linter, err := New(options...)
+	if err != nil {
+		return nil, err
 	}
 
-	var output Output
-	if err := json.Unmarshal([]byte(value.(string)), &output); err != nil {
+	return linter.Lint(ctx, documents, ruleset)
+}
+
+// LintBytes lints in-memory documents with a Spectral ruleset. Relative $ref's are resolved against the
+// Document.Name, first in memory and then using the Config.FS or system file system.
+func LintBytes(ctx context.Context, documents []Document, ruleset string, options ...Option) (Output, error) {
+	linter, err := New(options...)
+	if err != nil {
 		return nil, err
 	}
 
-	return output, nil
+	return linter.LintBytes(ctx, documents, ruleset)
 }
 
 // WithWorkingDirectory sets the working directory used to load system files (e.g. .spectral.yaml)
@@ -187,6 +206,20 @@ func WithWorkingDirectory(workingDirectory string) Option {
 	}
 }
 
+// WithFailSeverity sets the Config.FailSeverity such that Lint returns a *LintFailedError (along with the Output)
+// if any Rule is at least as severe as severity, equivalent to spectral --fail-severity
+func WithFailSeverity(severity Severity) Option {
+	return func(config *Config) error {
+		if !severity.valid() {
+			return fmt.Errorf("%d: %w", int(severity), ErrUnknownSeverity)
+		}
+
+		config.FailSeverity = &severity
+
+		return nil
+	}
+}
+
 // WithFS sets the Config.FS to load documents and rulesets from. This can be useful when using e.g. embed.FS as a
 // means to bundle specs/rulesets.
 func WithFS(fs fs.FS) Option {
@@ -203,6 +236,45 @@ var ErrUnknownReturn = errors.New("unknown return")
 // ErrPromiseRejected when the JS promise is rejected
 var ErrPromiseRejected = errors.New("promise rejected")
 
+// ErrPromisePending when the JS promise is still pending after the event loop ran out of work
+var ErrPromisePending = errors.New("promise pending without remaining work on the event loop")
+
+// ErrUncaughtException when a process.nextTick or queueMicrotask callback throws, which stops the event loop
+var ErrUncaughtException = errors.New("uncaught exception")
+
+// LintFailedError when the Output contains a Rule at least as severe as the Config.FailSeverity. The Output is
+// returned by Lint as well.
+type LintFailedError struct {
+	// Output of the lint
+	Output Output
+
+	// FailSeverity the Output was gated on
+	FailSeverity Severity
+
+	// Failures is the number of Rule's at least as severe as the FailSeverity
+	Failures int
+}
+
+// Error implementation of LintFailedError
+func (e LintFailedError) Error() string {
+	return fmt.Sprintf("lint failed: %d result(s) with a severity of '%s' or higher", e.Failures, e.FailSeverity)
+}
+
+// ContextError when the context.Context passed to LintContext is cancelled or its deadline passes
+type ContextError struct {
+	Err error
+}
+
+// Error implementation of ContextError
+func (e ContextError) Error() string {
+	return "lint interrupted: " + e.Err.Error()
+}
+
+// Unwrap returns the context error, e.g. context.Canceled or context.DeadlineExceeded
+func (e ContextError) Unwrap() error {
+	return e.Err
+}
+
 // EvaluateError translates various failure cases in an easier to understand format
 type EvaluateError struct {
 	Err error
@@ -1,8 +1,11 @@
 package gospectral
 
 import (
+	"context"
 	"embed"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -70,3 +73,252 @@ func TestLint_ReturnsErrorOnNotExistingPath(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, output)
 }
+
+func TestLintContext_ReturnsContextErrorOnDeadline(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	output, err := LintContext(ctx, []string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript([]byte("new Promise(function(res) { setTimeout(res, 60000); })")))
+
+	// Assert
+	var contextErr *ContextError
+	require.ErrorAs(t, err, &contextErr)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, output)
+}
+
+func TestLintContext_InterruptsRunningScriptOnCancel(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	// Act
+	output, err := LintContext(ctx, []string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript([]byte("for (;;) {}")))
+
+	// Assert
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, output)
+}
+
+func TestLintContext_ReturnsContextErrorWhenAlreadyCancelled(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	output, err := LintContext(ctx, []string{"./openapi.yaml"}, "./.spectral.yaml")
+
+	// Assert
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, output)
+}
+
+func TestLint_RunsTimersAndMicrotasksOnEventLoop(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := `new Promise(function(res) {
+		var calls = [];
+		setTimeout(function() {
+			calls.push("timeout");
+			setImmediate(function() {
+				calls.push("immediate");
+				queueMicrotask(function() { calls.push("microtask"); });
+				process.nextTick(function() {
+					calls.push("nextTick");
+					setTimeout(function() { res(JSON.stringify([{ code: calls.join(",") }])); }, 1);
+				});
+			});
+		}, 10);
+	})`
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript([]byte(script)))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "timeout,immediate,nextTick,microtask", output[0].Code)
+}
+
+func TestLint_ReturnsErrorWhenPromiseNeverSettles(t *testing.T) {
+	t.Parallel()
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript([]byte("new Promise(function() {})")))
+
+	// Assert
+	require.ErrorIs(t, err, ErrPromisePending)
+	assert.Nil(t, output)
+}
+
+func TestLint_ReturnsErrorWhenCallbackThrows(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script string
+	}{
+		"nextTick": {
+			script: `new Promise(function(res) {
+				process.nextTick(function() { throw new TypeError("boom"); });
+				setTimeout(function() { res("[]"); }, 10);
+			})`,
+		},
+		"queueMicrotask": {
+			script: `new Promise(function(res) {
+				queueMicrotask(function() { throw new TypeError("boom"); });
+				setTimeout(function() { res("[]"); }, 10);
+			})`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript([]byte(tt.script)))
+
+			// Assert
+			require.ErrorIs(t, err, ErrUncaughtException)
+			assert.Contains(t, err.Error(), "TypeError: boom")
+			assert.Nil(t, output)
+		})
+	}
+}
+
+func TestLint_ReturnsErrorWhenPromiseRejects(t *testing.T) {
+	t.Parallel()
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript([]byte("Promise.reject('boom')")))
+
+	// Assert
+	require.ErrorIs(t, err, ErrPromiseRejected)
+	assert.Nil(t, output)
+}
+
+func TestLint_ReturnsLintFailedErrorAtFailSeverity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`JSON.stringify([{ code: "a", severity: 2 }, { code: "b", severity: 1 }, { code: "c", severity: 3 }])`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript(script), WithFailSeverity(SeverityInfo))
+
+	// Assert
+	var lintFailedErr *LintFailedError
+	require.ErrorAs(t, err, &lintFailedErr)
+	assert.Equal(t, SeverityInfo, lintFailedErr.FailSeverity)
+	assert.Equal(t, 2, lintFailedErr.Failures)
+	assert.Len(t, lintFailedErr.Output, 3)
+	assert.Equal(t, lintFailedErr.Output, output)
+	assert.EqualError(t, err, "lint failed: 2 result(s) with a severity of 'info' or higher")
+}
+
+func TestLint_NoErrorBelowFailSeverity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`JSON.stringify([{ code: "a", severity: 2 }, { code: "b", severity: 3 }])`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript(script), WithFailSeverity(SeverityWarn))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, output, 2)
+}
+
+func TestWithFailSeverity_RejectsUnknownSeverity(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithFailSeverity(Severity(-1)))
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownSeverity)
+}
+
+func TestLint_DescribesResultsWithTheirRule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dist := []byte(`
+exports.formatOutput = function(results) { return JSON.stringify(results); };
+exports.lint = function() { return Promise.resolve({
+    results: [
+        { code: "info-contact", path: ["info"], message: "m", severity: 1, source: "/wd/openapi.yaml", range: { start: { line: 1, character: 2 }, end: { line: 3, character: 4 } } },
+        { code: "param-case", path: ["paths", "/users", "get", "parameters", 0], message: "m", severity: 0, source: "/wd/parameters.yaml", range: { start: { line: 0, character: 0 }, end: { line: 0, character: 1 } } }
+    ],
+    resolvedRuleset: { rules: {
+        "info-contact": { documentationUrl: "https://example.com/oas#info-contact", description: "Info object must have contact", owner: { source: null } },
+        "param-case": { description: "Parameters must be camelCase", owner: { source: "/wd/.spectral.yaml" } }
+    } }
+}); };`)
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist(dist), WithWorkingDirectory("/wd"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Output{
+		{
+			Source:           "/wd/openapi.yaml",
+			Document:         "/wd/openapi.yaml",
+			Code:             "info-contact",
+			Path:             Path{"info"},
+			Message:          "m",
+			Severity:         SeverityWarn,
+			Range:            Range{Start: Position{Line: 1, Character: 2}, End: Position{Line: 3, Character: 4}},
+			DocumentationURL: "https://example.com/oas#info-contact",
+			Description:      "Info object must have contact",
+		},
+		{
+			Source:      "/wd/parameters.yaml",
+			Document:    "/wd/openapi.yaml",
+			Code:        "param-case",
+			Path:        Path{"paths", "/users", "get", "parameters", "0"},
+			Message:     "m",
+			Severity:    SeverityError,
+			Range:       Range{End: Position{Character: 1}},
+			Description: "Parameters must be camelCase",
+			Ruleset:     "/wd/.spectral.yaml",
+		},
+	}, output)
+}
+
+func TestPath_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var path Path
+
+	// Act
+	err := json.Unmarshal([]byte(`["paths", "/users", "get", "parameters", 0]`), &path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Path{"paths", "/users", "get", "parameters", "0"}, path)
+}
+
+func TestPath_UnmarshalJSON_InvalidSegment(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var path Path
+
+	// Act
+	err := json.Unmarshal([]byte(`["paths", true]`), &path)
+
+	// Assert
+	require.EqualError(t, err, "invalid path segment true: json: cannot unmarshal bool into Go value of type json.Number")
+}
+
+func TestRule_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	rule := Rule{Source: "/wd/openapi.yaml", Code: "info-contact", Path: Path{"info"}, Message: "m", Severity: SeverityWarn}
+
+	// Act
+	data, err := json.Marshal(rule)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"source":"/wd/openapi.yaml","code":"info-contact","path":["info"],"message":"m","severity":1,"range":{"start":{"line":0,"character":0},"end":{"line":0,"character":0}}}`, string(data))
+}
@@ -0,0 +1,62 @@
+package gospectral
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	nodehttp "github.com/Emptyless/go-spectral/node/http"
+	"github.com/Emptyless/go-spectral/node/https"
+	"github.com/dop251/goja"
+	noderequire "github.com/dop251/goja_nodejs/require"
+)
+
+// ErrNilHTTPClient when WithHTTPClient is supplied a nil *http.Client
+var ErrNilHTTPClient = errors.New("http client must not be nil")
+
+// WithHTTPClient sets the Config.HTTPClient performing the requests of the http and https packages and the fetch
+// global, e.g. to resolve a ruleset that extends a URL or a remote $ref. Use the Transport of the client to configure
+// proxies, authentication headers or an allow-list of hosts.
+func WithHTTPClient(client *http.Client) Option {
+	return func(config *Config) error {
+		if client == nil {
+			return ErrNilHTTPClient
+		}
+
+		config.HTTPClient = client
+
+		return nil
+	}
+}
+
+// withHTTPClient wraps the BeforeModule such that the Enable.Fn of the http and https packages performs requests with
+// the client. The client is applied before the BeforeModule is evaluated, i.e. a BeforeModule returning the Enable.Fn
+// of these packages enables them with the client.
+func withHTTPClient(before BeforeModule, client *nodehttp.Client) BeforeModule {
+	return func(enable Enable, runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) (func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule), error) {
+		switch enable.Name {
+		case nodehttp.ModuleName:
+			enable.Fn = func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) {
+				nodehttp.EnableClient(runtime, registry, requireModule, client)
+			}
+		case https.ModuleName:
+			enable.Fn = func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) {
+				https.EnableClient(runtime, registry, requireModule, client)
+			}
+		}
+
+		return before(enable, runtime, registry, requireModule)
+	}
+}
+
+// httpClient of the worker, performing requests with the Config.HTTPClient on the event loop of the worker. The
+// requests are cancelled once the context of the current lint is done.
+func (w *worker) httpClient() *nodehttp.Client {
+	return &nodehttp.Client{
+		HTTPClient: w.cfg.HTTPClient,
+		Loop:       w.loop,
+		Context: func() context.Context {
+			return w.ctx
+		},
+	}
+}
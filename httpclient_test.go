@@ -0,0 +1,95 @@
+package gospectral
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fetchDist fetches the ruleset URL and returns the response body as the message of a single Rule
+var fetchDist = []byte(`exports.formatOutput = function(results) { return JSON.stringify(results); };
+exports.lint = function(documents, options) {
+    return fetch(options.ruleset)
+        .then(function(res) { return res.text(); })
+        .then(function(body) { return { results: [{ code: "fetched", message: body }] }; });
+};`)
+
+// headerTransport sets a header on every request
+type headerTransport struct {
+	name, value string
+}
+
+// RoundTrip implementation of http.RoundTripper
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.name, t.value)
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestWithHTTPClient_RejectsNilClient(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithHTTPClient(nil))
+
+	// Assert
+	require.ErrorIs(t, err, ErrNilHTTPClient)
+	assert.Nil(t, linter)
+}
+
+func TestLint_FetchesWithHTTPClient(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("extends: spectral:oas"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: headerTransport{name: "Authorization", value: "Bearer token"}}
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, server.URL+"/ruleset.yaml", WithDist(fetchDist), WithHTTPClient(client))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "extends: spectral:oas", output[0].Message)
+}
+
+func TestLint_CancelsInFlightRequest(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	output, err := LintContext(ctx, []string{"./openapi.yaml"}, server.URL, WithDist(fetchDist))
+
+	// Assert
+	var contextErr *ContextError
+	require.ErrorAs(t, err, &contextErr)
+	assert.Nil(t, output)
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
@@ -0,0 +1,165 @@
+// Package task queues the callbacks of process.nextTick and queueMicrotask of a runtime
+package task
+
+import (
+	"github.com/dop251/goja"
+)
+
+// queueSymbol of the global property holding the Queue of a runtime
+var queueSymbol = goja.NewSymbol("task.queue")
+
+// Queue of a runtime holding the callbacks of process.nextTick and the microtasks. Like Node, the nextTick queue is
+// drained before the microtasks. Both are drained by a single job on the goja job queue, such that promise reactions
+// queued by the current job before its first callback still run before the callbacks.
+type Queue struct {
+	r          *goja.Runtime
+	ticks      []func()
+	microtasks []func()
+	scheduled  bool
+	// microtasking while the microtasks queued by microtasks are drained
+	microtasking bool
+	// uncaught exception thrown by a callback
+	uncaught   goja.Value
+	onUncaught func(exception goja.Value)
+}
+
+// Of the runtime, which is created once it is first used
+func Of(runtime *goja.Runtime) *Queue {
+	global := runtime.GlobalObject()
+	if v := global.GetSymbol(queueSymbol); v != nil {
+		if q, ok := v.Export().(*Queue); ok {
+			return q
+		}
+	}
+
+	q := &Queue{r: runtime}
+	_ = global.DefineDataPropertySymbol(queueSymbol, runtime.ToValue(q), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
+
+	return q
+}
+
+// NextTick queues fn on the nextTick queue of the runtime, see Queue.NextTick
+func NextTick(runtime *goja.Runtime, fn func()) {
+	Of(runtime).NextTick(fn)
+}
+
+// Microtask queues fn as a microtask of the runtime, see Queue.Microtask
+func Microtask(runtime *goja.Runtime, fn func()) {
+	Of(runtime).Microtask(fn)
+}
+
+// NextTick queues fn on the nextTick queue, i.e. fn is called once the current job (e.g. the script or a timer) is
+// done and before the microtasks
+func (q *Queue) NextTick(fn func()) {
+	if q.uncaught != nil {
+		return
+	}
+	q.ticks = append(q.ticks, fn)
+	q.schedule()
+}
+
+// Microtask queues fn as a microtask, i.e. fn is called once the current job is done and the nextTick queue is empty
+func (q *Queue) Microtask(fn func()) {
+	if q.uncaught != nil {
+		return
+	}
+	q.microtasks = append(q.microtasks, fn)
+	q.schedule()
+}
+
+// Uncaught exception thrown by a callback or nil if no callback threw
+func (q *Queue) Uncaught() goja.Value {
+	return q.uncaught
+}
+
+// OnUncaught sets the handler called with the exception thrown by a callback, e.g. to stop the event loop. Like an
+// uncaught exception in Node, the remaining callbacks are dropped and no callbacks are queued anymore.
+func (q *Queue) OnUncaught(handler func(exception goja.Value)) {
+	q.onUncaught = handler
+}
+
+// schedule the drain of the queue on the goja job queue through an already resolved promise, unless it is scheduled
+func (q *Queue) schedule() {
+	if q.scheduled {
+		return
+	}
+	q.scheduled = true
+
+	promise, resolve, _ := q.r.NewPromise()
+	then, _ := goja.AssertFunction(q.r.ToValue(promise).ToObject(q.r).Get("then"))
+	if _, err := then(q.r.ToValue(promise), q.r.ToValue(func(goja.FunctionCall) goja.Value {
+		q.drain()
+
+		return goja.Undefined()
+	})); err != nil {
+		panic(err)
+	}
+	_ = resolve(goja.Undefined())
+}
+
+// drain the nextTick queue (including the callbacks queued while draining it) and then the microtasks queued so far.
+// Like Node, a microtask queued by a microtask runs after the promise reactions queued meanwhile, so it is drained by
+// the next job, and the nextTick queue is only drained again once no microtasks are left.
+func (q *Queue) drain() {
+	// if a callback panics, the remaining callbacks are drained by the next job
+	ticking := true
+	defer func() {
+		if ticking {
+			q.scheduled = false
+		}
+		if len(q.microtasks) == 0 {
+			q.microtasking = false
+		}
+		if len(q.ticks) > 0 || len(q.microtasks) > 0 {
+			q.schedule()
+		}
+	}()
+
+	if !q.microtasking {
+		for len(q.ticks) > 0 {
+			fn := q.ticks[0]
+			q.ticks = q.ticks[1:]
+			q.call(fn)
+		}
+	}
+
+	ticking = false
+	q.scheduled = false
+	q.microtasking = true
+	for n := len(q.microtasks); n > 0; n-- {
+		fn := q.microtasks[0]
+		q.microtasks = q.microtasks[1:]
+		q.call(fn)
+	}
+}
+
+// call fn, dropping the remaining callbacks if it throws a JavaScript exception
+func (q *Queue) call(fn func()) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		var exception goja.Value
+		switch r := r.(type) {
+		case *goja.Exception:
+			exception = r.Value()
+		case goja.Value:
+			exception = r
+		default:
+			panic(r)
+		}
+
+		if q.uncaught != nil {
+			return
+		}
+		q.uncaught = exception
+		q.ticks, q.microtasks = nil, nil
+		if q.onUncaught != nil {
+			q.onUncaught(exception)
+		}
+	}()
+
+	fn()
+}
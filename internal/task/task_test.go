@@ -0,0 +1,98 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMicrotask(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	var calls []string
+	_ = runtime.Set("push", func(name string) { calls = append(calls, name) })
+	_ = runtime.Set("microtask", func(name string) {
+		Microtask(runtime, func() { calls = append(calls, name) })
+	})
+
+	// Act
+	_, err := runtime.RunString(`
+		microtask('microtask');
+		Promise.resolve().then(function() { push('promise'); });
+		push('script');
+	`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"script", "microtask", "promise"}, calls)
+}
+
+func TestNextTick(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	var calls []string
+	_ = runtime.Set("push", func(name string) { calls = append(calls, name) })
+	var microtask, nextTick func(name string, fn goja.Callable)
+	microtask = func(name string, fn goja.Callable) {
+		Microtask(runtime, func() {
+			calls = append(calls, name)
+			if fn != nil {
+				_, _ = fn(goja.Undefined())
+			}
+		})
+	}
+	nextTick = func(name string, fn goja.Callable) {
+		NextTick(runtime, func() {
+			calls = append(calls, name)
+			if fn != nil {
+				_, _ = fn(goja.Undefined())
+			}
+		})
+	}
+	_ = runtime.Set("microtask", microtask)
+	_ = runtime.Set("nextTick", nextTick)
+
+	// Act
+	_, err := runtime.RunString(`
+		microtask('m1', function() { nextTick('t3'); microtask('m2'); });
+		nextTick('t1', function() { nextTick('t2'); });
+		push('script');
+	`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"script", "t1", "t2", "m1", "m2", "t3"}, calls)
+}
+
+func TestQueue_OnUncaught(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	var calls []string
+	var exceptions []string
+	Of(runtime).OnUncaught(func(exception goja.Value) { exceptions = append(exceptions, exception.String()) })
+	_ = runtime.Set("nextTick", func(name string, fn goja.Callable) {
+		NextTick(runtime, func() {
+			calls = append(calls, name)
+			if _, err := fn(goja.Undefined()); err != nil {
+				panic(err)
+			}
+		})
+	})
+
+	// Act
+	_, err := runtime.RunString(`
+		nextTick('t1', function() { throw new TypeError('boom'); });
+		nextTick('t2', function() {});
+	`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1"}, calls)
+	assert.Equal(t, []string{"TypeError: boom"}, exceptions)
+	assert.Equal(t, "TypeError: boom", Of(runtime).Uncaught().String())
+}
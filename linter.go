@@ -0,0 +1,527 @@
+package gospectral
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	goruntime "runtime"
+
+	"github.com/Emptyless/go-spectral/internal/task"
+	"github.com/Emptyless/go-spectral/node/process"
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/eventloop"
+	noderequire "github.com/dop251/goja_nodejs/require"
+)
+
+// distModuleName of the native module evaluating the pre-compiled Config.Dist. The DistName source resolves to this
+// module such that the Dist is only compiled once per Linter instead of once per runtime.
+const distModuleName = "go-spectral:dist"
+
+// Linter lints documents with a pool of initialized runtimes. The Config.Dist is compiled once when the Linter is
+// created and every runtime in the pool has its modules loaded, which makes subsequent calls to Lint considerably
+// cheaper than calling the package level Lint function repeatedly.
+type Linter struct {
+	cfg          *Config
+	dist         *goja.Program
+	pool         chan *worker
+	functionsDir string
+}
+
+// New Linter using the supplied Option's
+func New(options ...Option) (*Linter, error) {
+	// instantiate default Config
+	cfg := &Config{
+		Dist:         DefaultDist(),
+		Script:       DefaultScript(),
+		LintOptions:  DefaultLintOptions(),
+		HTTPClient:   http.DefaultClient,
+		InheritEnv:   true,
+		BeforeModule: nil,
+		AfterModule:  nil,
+		PoolSize:     goruntime.GOMAXPROCS(0),
+	}
+
+	// apply Option's
+	for _, option := range options {
+		if err := option(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// restrict the requests of the HTTPClient to the NetworkPolicy
+	if cfg.NetworkPolicy != nil {
+		cfg.HTTPClient = cfg.NetworkPolicy.client(cfg.HTTPClient)
+	}
+
+	// Set working directory if ""
+	if cfg.WorkingDirectory == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.WorkingDirectory = wd
+	}
+
+	// serve the Ruleset from memory
+	functionsDir := FunctionsDir
+	if cfg.Ruleset != nil {
+		if cfg.RulesetContent != nil {
+			return nil, ErrConflictingRuleset
+		}
+
+		content, err := rulesetContent(*cfg.Ruleset, cfg.Functions)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.RulesetContent = content
+		if cfg.Ruleset.FunctionsDir != "" {
+			functionsDir = cfg.Ruleset.FunctionsDir
+		}
+	}
+
+	// compile the Dist the same way as the require module would wrap a CommonJS module
+	dist, err := goja.Compile(DistName, "(function(exports, require, module) {"+string(cfg.Dist)+"\n})", false)
+	if err != nil {
+		return nil, &EvaluateError{Err: err}
+	}
+
+	return &Linter{
+		cfg:          cfg,
+		dist:         dist,
+		pool:         make(chan *worker, cfg.PoolSize),
+		functionsDir: functionsDir,
+	}, nil
+}
+
+// Lint OpenAPI documents (e.g. openapi.yaml) with a Spectral ruleset using a runtime from the pool. If the context
+// is cancelled or its deadline passes, the runtime is interrupted and a *ContextError wrapping ctx.Err() is returned.
+// Lint is safe for concurrent use: every call runs on its own runtime, of which the node modules keep all state. Before
+// a runtime is reused, the own properties of its global object, process.env and process.argv are reset to their state
+// once the modules were loaded. The exports of the required modules and the built-in objects (e.g. Object.prototype)
+// are not reset, so their changes are seen by the next lint of the runtime.
+func (l *Linter) Lint(ctx context.Context, documents []string, ruleset string) (Output, error) {
+	return l.lint(ctx, documents, ruleset, nil)
+}
+
+// LintBytes lints in-memory documents with a Spectral ruleset using a runtime from the pool. Relative $ref's are
+// resolved against the Document.Name, first in memory and then using the Config.FS or system file system.
+func (l *Linter) LintBytes(ctx context.Context, documents []Document, ruleset string) (Output, error) {
+	names := make([]string, len(documents))
+	files := make(map[string][]byte, len(documents))
+	for i, document := range documents {
+		names[i] = document.Name
+		files[document.Name] = document.Content
+	}
+
+	return l.lint(ctx, names, ruleset, files)
+}
+
+// lint the documents with the ruleset, serving the files from memory
+func (l *Linter) lint(ctx context.Context, documents []string, ruleset string, files map[string][]byte) (Output, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &ContextError{Err: err}
+	}
+
+	if l.cfg.RulesetContent != nil {
+		if ruleset == "" {
+			ruleset = RulesetContentName
+		}
+
+		files = maps.Clone(files)
+		if files == nil {
+			files = make(map[string][]byte, 1)
+		}
+		files[ruleset] = l.cfg.RulesetContent
+	}
+
+	// serve the function modules of the Go functions next to the ruleset
+	if len(l.cfg.Functions) > 0 {
+		files = maps.Clone(files)
+		if files == nil {
+			files = make(map[string][]byte, len(l.cfg.Functions))
+		}
+		maps.Copy(files, functionFiles(l.cfg.Functions, ruleset, l.functionsDir))
+	}
+
+	w, err := l.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// record the accesses denied during the lint, e.g. by the NetworkPolicy
+	ctx, denials := withDenials(ctx)
+
+	w.overlay.set(files)
+	output, err := w.lint(ctx, documents, ruleset)
+	w.overlay.set(nil)
+	if err != nil {
+		// the runtime state is unknown after a failure (e.g. an interrupt), so it is not returned to the pool
+		w.close()
+
+		return nil, denials.join(nil, err)
+	}
+
+	l.release(w)
+
+	// gate the output on the fail severity, similar to spectral --fail-severity
+	if l.cfg.FailSeverity != nil {
+		if failures := output.Filter(*l.cfg.FailSeverity); len(failures) > 0 {
+			err = &LintFailedError{Output: output, FailSeverity: *l.cfg.FailSeverity, Failures: len(failures)}
+		}
+	}
+
+	return output, denials.join(output, err)
+}
+
+// acquire an idle worker from the pool or initialize a new one if the pool is empty. The worker is reset (see
+// worker.reset), a worker that cannot be reset is closed. Like a lint, initializing a new worker is interrupted once the context is done.
+func (l *Linter) acquire(ctx context.Context) (*worker, error) {
+	for {
+		select {
+		case w := <-l.pool:
+			if err := w.reset(); err != nil {
+				w.close()
+
+				continue
+			}
+
+			return w, nil
+		default:
+			return l.newWorker(ctx)
+		}
+	}
+}
+
+// release the worker back to the pool or close it if the pool is full
+func (l *Linter) release(w *worker) {
+	select {
+	case l.pool <- w:
+	default:
+		w.close()
+	}
+}
+
+// worker is a runtime on an event loop with the modules loaded
+type worker struct {
+	cfg     *Config
+	loop    *eventloop.EventLoop
+	overlay *overlay
+
+	// process of the runtime, nil if a custom BeforeModule enables the process module
+	process *process.Process
+
+	// globals of the runtime once the modules are loaded
+	globals globals
+
+	// ctx of the current lint, in-flight requests are cancelled once it is done
+	ctx context.Context
+}
+
+// newWorker initializes a runtime on an event loop and loads the modules, interrupting the runtime when the context is
+// done (e.g. while the dist is evaluated)
+func (l *Linter) newWorker(ctx context.Context) (*worker, error) {
+	// the DistName resolves to the native module running the pre-compiled dist. Note that require cleans the path
+	// before calling the loader, i.e. './dist/built.js' is loaded as 'dist/built.js'
+	registry := noderequire.NewRegistry(noderequire.WithLoader(func(p string) ([]byte, error) {
+		if path.Clean(p) == path.Clean(DistName) {
+			return []byte("module.exports = require('" + distModuleName + "');"), nil
+		}
+
+		// modules outside the Sandbox do not exist, such that require continues resolving e.g. node_modules
+		if !l.cfg.Sandbox.allowed(l.cfg.WorkingDirectory, p) {
+			return nil, noderequire.ModuleFileDoesNotExistError
+		}
+
+		return noderequire.DefaultSourceLoader(p)
+	}))
+	registry.RegisterNativeModule(distModuleName, l.loadDist)
+
+	w := &worker{
+		cfg:     l.cfg,
+		loop:    eventloop.NewEventLoop(eventloop.WithRegistry(registry), eventloop.EnableConsole(false)),
+		overlay: &overlay{workingDirectory: l.cfg.WorkingDirectory, base: l.cfg.FS},
+		ctx:     context.Background(),
+	}
+
+	// Set default BeforeModule if nil such that node:fs and node:process can use the working directory and/or virtual file system
+	beforeModule := l.cfg.BeforeModule
+	if beforeModule == nil {
+		beforeModule = DefaultBeforeModule(l.cfg.WorkingDirectory, l.cfg.FS)
+	}
+
+	// serve the in-memory files (e.g. of LintBytes) before the fs.FS the BeforeModule enables the fs module with
+	beforeModule = withOverlay(beforeModule, w.overlay)
+
+	// confine the file access of the fs module to the Sandbox
+	if guard := w.guard(); guard != nil {
+		beforeModule = withSandbox(beforeModule, l.cfg.WorkingDirectory, w.overlay, guard)
+	}
+
+	// back process.stdout and process.stderr with the writers of the Config and set process.env and process.argv
+	if options := l.cfg.processOptions(); options != nil {
+		beforeModule = withProcess(beforeModule, l.cfg.WorkingDirectory, *options, func(p *process.Process) { w.process = p })
+	}
+
+	// perform the requests of the http and https packages with the Config.HTTPClient on the event loop
+	beforeModule = withHTTPClient(beforeModule, w.httpClient())
+
+	var initErr error
+	ctxErr := w.run(ctx, func(runtime *goja.Runtime) {
+		require, err := LoadModules(runtime, registry, beforeModule, l.cfg.AfterModule)
+		if err != nil {
+			initErr = err
+			return
+		}
+
+		if err := EnableQueueMicrotask(runtime); err != nil {
+			initErr = err
+			return
+		}
+
+		if err := EnableFunctions(runtime, l.cfg.Functions); err != nil {
+			initErr = err
+			return
+		}
+
+		// set the __dirname global to the working directory
+		if err := runtime.GlobalObject().Set("__dirname", runtime.ToValue(l.cfg.WorkingDirectory)); err != nil {
+			initErr = err
+			return
+		}
+
+		// load the dist once, the script calls its lint function for every lint
+		if err := EnableDist(require); err != nil {
+			initErr = &EvaluateError{Err: err}
+			return
+		}
+
+		w.globals = snapshotGlobals(runtime)
+	})
+
+	if ctxErr != nil {
+		initErr = ctxErr
+	}
+
+	if initErr != nil {
+		w.close()
+
+		return nil, initErr
+	}
+
+	return w, nil
+}
+
+// loadDist is the ModuleLoader of the distModuleName running the pre-compiled dist
+func (l *Linter) loadDist(runtime *goja.Runtime, module *goja.Object) {
+	v, err := runtime.RunProgram(l.dist)
+	if err != nil {
+		panic(err)
+	}
+
+	call, ok := goja.AssertFunction(v)
+	if !ok {
+		panic(runtime.NewTypeError("dist did not compile to a module function"))
+	}
+
+	exports := module.Get("exports")
+	if _, err := call(exports, exports, runtime.Get("require"), module); err != nil {
+		panic(err)
+	}
+}
+
+// lint the documents with the ruleset on the worker runtime
+func (w *worker) lint(ctx context.Context, documents []string, ruleset string) (Output, error) {
+	var value any
+	var evaluateErr error
+	var queue *task.Queue
+	w.ctx = ctx
+	defer func() { w.ctx = context.Background() }()
+	// run the script until the event loop has no more work, interrupting the runtime when the context is done
+	ctxErr := w.run(ctx, func(runtime *goja.Runtime) {
+		// like Node, an uncaught exception of a callback ends the lint
+		queue = task.Of(runtime)
+		queue.OnUncaught(func(goja.Value) { w.loop.StopNoWait() })
+
+		value, evaluateErr = w.evaluate(runtime, documents, ruleset)
+	})
+
+	if ctxErr != nil {
+		return nil, ctxErr
+	}
+
+	if exception := queue.Uncaught(); exception != nil {
+		return nil, fmt.Errorf("%s: %w", exception.String(), ErrUncaughtException)
+	}
+
+	if evaluateErr != nil {
+		return nil, evaluateErr
+	}
+
+	// if the result is a goja.Promise, it must be settled once the event loop is done
+	if promise, ok := value.(*goja.Promise); ok {
+		switch promise.State() {
+		case goja.PromiseStatePending:
+			return nil, ErrPromisePending
+		case goja.PromiseStateRejected:
+			return nil, fmt.Errorf("%s: %w", promise.Result().String(), ErrPromiseRejected)
+		case goja.PromiseStateFulfilled:
+			value = promise.Result().String()
+		}
+	}
+
+	if _, ok := value.(string); !ok {
+		return nil, fmt.Errorf("invalid value type of Lint result '%T': %w", value, ErrUnknownReturn)
+	}
+
+	var output Output
+	if err := json.Unmarshal([]byte(value.(string)), &output); err != nil {
+		return nil, err
+	}
+
+	output.attribute(documents, w.cfg.WorkingDirectory)
+
+	return output, nil
+}
+
+// evaluate the Config.Script in the runtime, returning the exported result of the script
+func (w *worker) evaluate(runtime *goja.Runtime, documents []string, ruleset string) (any, error) {
+	// set the lintDocuments global variable
+	if err := runtime.GlobalObject().Set(lintDocuments, runtime.ToValue(documents)); err != nil {
+		return nil, err
+	}
+
+	// set the lintRuleset global variable
+	if err := runtime.GlobalObject().Set(lintRuleset, runtime.ToValue(ruleset)); err != nil {
+		return nil, err
+	}
+
+	// set the lintOptions global variable
+	if err := runtime.GlobalObject().Set(lintOptions, runtime.ToValue(w.cfg.LintOptions.values(ruleset))); err != nil {
+		return nil, err
+	}
+
+	// run the script
+	v, err := runtime.RunString(string(w.cfg.Script))
+	if err != nil {
+		return nil, &EvaluateError{Err: err}
+	}
+
+	return v.Export(), nil
+}
+
+// run fn on the event loop until it has no more work, interrupting the runtime and stopping the loop when the context
+// is done. If the context is done, a *ContextError wrapping ctx.Err() is returned.
+func (w *worker) run(ctx context.Context, fn func(runtime *goja.Runtime)) error {
+	done := make(chan struct{})
+	watched := make(chan struct{})
+	w.loop.Run(func(runtime *goja.Runtime) {
+		go func() {
+			defer close(watched)
+			select {
+			case <-ctx.Done():
+				runtime.Interrupt(ctx.Err())
+				w.loop.StopNoWait()
+			case <-done:
+			}
+		}()
+
+		fn(runtime)
+	})
+
+	// wait for the watcher such that an interrupt can no longer happen once the context error is checked
+	close(done)
+	<-watched
+
+	if err := ctx.Err(); err != nil {
+		return &ContextError{Err: err}
+	}
+
+	return nil
+}
+
+// reset the own properties of the global object and process.env and process.argv to their state once the modules
+// were loaded. The exports of the required modules and the built-in objects are not reset.
+func (w *worker) reset() error {
+	var err error
+	w.loop.Run(func(runtime *goja.Runtime) {
+		if err = w.globals.restore(runtime); err != nil {
+			return
+		}
+
+		if w.process != nil {
+			w.process.Reset()
+		}
+	})
+
+	return err
+}
+
+// globals of a runtime by their name
+type globals map[string]goja.Value
+
+// snapshotGlobals of the global object of the runtime
+func snapshotGlobals(runtime *goja.Runtime) globals {
+	global := runtime.GlobalObject()
+	names := global.GetOwnPropertyNames()
+	g := make(globals, len(names))
+	for _, name := range names {
+		g[name] = global.Get(name)
+	}
+
+	return g
+}
+
+// restore the global object of the runtime to the globals. Added globals are deleted or, if they cannot be deleted
+// (e.g. the var declarations of the Script), set to undefined.
+func (g globals) restore(runtime *goja.Runtime) error {
+	global := runtime.GlobalObject()
+	for _, name := range global.GetOwnPropertyNames() {
+		if _, ok := g[name]; ok {
+			continue
+		}
+
+		if err := global.Delete(name); err != nil {
+			if err := global.Set(name, goja.Undefined()); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, value := range g {
+		if current := global.Get(name); current == nil || !current.SameAs(value) {
+			if err := global.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// close the worker by terminating the event loop, clearing any remaining timers
+func (w *worker) close() {
+	w.loop.Terminate()
+}
+
+// ErrInvalidPoolSize when the pool size is not positive
+var ErrInvalidPoolSize = errors.New("pool size must be positive")
+
+// WithPoolSize sets the Config.PoolSize, the maximum number of idle runtimes a Linter keeps for reuse
+func WithPoolSize(size int) Option {
+	return func(config *Config) error {
+		if size < 1 {
+			return fmt.Errorf("%d: %w", size, ErrInvalidPoolSize)
+		}
+
+		config.PoolSize = size
+
+		return nil
+	}
+}
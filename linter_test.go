@@ -0,0 +1,198 @@
+package gospectral
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingDist counts how often it is evaluated in the same runtime
+var countingDist = []byte(`globalThis.loads = (globalThis.loads || 0) + 1; exports.loads = globalThis.loads;`)
+
+// countingScript returns the number of evaluations of the countingDist and of itself as the code of a single Rule. The
+// lints are counted on the exports of the dist, which unlike the globals are kept between the lints of a runtime.
+var countingScript = []byte(`var dist = require('./dist/built.js');
+dist.lints = (dist.lints || 0) + 1;
+JSON.stringify([{ code: dist.loads + ":" + dist.lints }])`)
+
+func TestNew_ReturnsErrorOnInvalidDist(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithDist([]byte("function {")))
+
+	// Assert
+	var evaluateErr *EvaluateError
+	require.ErrorAs(t, err, &evaluateErr)
+	assert.Nil(t, linter)
+}
+
+func TestLinter_Lint_ReusesRuntimeFromPool(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	linter, err := New(WithDist(countingDist), WithScript(countingScript), WithPoolSize(1))
+	require.NoError(t, err)
+
+	// Act
+	first, firstErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+	second, secondErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "1:1", first[0].Code)
+	assert.Equal(t, "1:2", second[0].Code)
+	assert.Len(t, linter.pool, 1)
+}
+
+func TestLinter_Lint_ResetsGlobalsAndProcessOfRuntimeFromPool(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`var dist = require('./dist/built.js');
+dist.lints = (dist.lints || 0) + 1;
+var code = [dist.lints, process.env.SPECTRAL_DEBUG, process.env.TOKEN, process.argv.join(" "), typeof leaked, typeof Promise, String(process.kept)].join(",");
+process.kept = true;
+process.env.SPECTRAL_DEBUG = "2";
+process.env.TOKEN = "secret";
+process.argv.push("modified");
+globalThis.leaked = true;
+globalThis.Promise = undefined;
+JSON.stringify([{ code: code }])`)
+	linter, err := New(WithDist([]byte("")), WithScript(script), WithPoolSize(1), WithInheritEnv(false),
+		WithEnv(map[string]string{"SPECTRAL_DEBUG": "1"}), WithArgv("spectral", "lint"))
+	require.NoError(t, err)
+
+	// Act
+	first, firstErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+	second, secondErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "1,1,,spectral lint,undefined,function,undefined", first[0].Code)
+	assert.Equal(t, "2,1,,spectral lint,undefined,function,true", second[0].Code)
+	assert.Len(t, linter.pool, 1)
+}
+
+func TestLinter_Lint_KeepsModulesAndBuiltinsOfRuntimeFromPool(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`var code = [String(({}).polluted), String(require('fs').hacked), typeof leaked].join(",");
+Object.prototype.polluted = true;
+require('fs').hacked = true;
+globalThis.leaked = true;
+JSON.stringify([{ code: code }])`)
+	linter, err := New(WithDist([]byte("")), WithScript(script), WithPoolSize(1))
+	require.NoError(t, err)
+
+	// Act
+	first, firstErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+	second, secondErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+	isolated, isolatedErr := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist([]byte("")), WithScript(script))
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, isolatedErr)
+	assert.Equal(t, "undefined,undefined,undefined", first[0].Code)
+	assert.Equal(t, "true,true,undefined", second[0].Code)
+	assert.Equal(t, "undefined,undefined,undefined", isolated[0].Code)
+}
+
+func TestLinter_Lint_ReplacesRuntimeThatCannotBeReset(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`var dist = require('./dist/built.js');
+dist.lints = (dist.lints || 0) + 1;
+Object.defineProperty(globalThis, "pinned", { value: true });
+JSON.stringify([{ code: String(dist.lints) }])`)
+	linter, err := New(WithDist([]byte("")), WithScript(script), WithPoolSize(1))
+	require.NoError(t, err)
+
+	// Act
+	first, firstErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+	second, secondErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	assert.Equal(t, "1", first[0].Code)
+	assert.Equal(t, "1", second[0].Code)
+}
+
+func TestLinter_Lint_InterruptsDistOnDeadline(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	linter, err := New(WithDist([]byte("for (;;) {}")))
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Act
+	output, lintErr := linter.Lint(ctx, []string{"./openapi.yaml"}, "./.spectral.yaml")
+
+	// Assert
+	var contextErr *ContextError
+	require.ErrorAs(t, lintErr, &contextErr)
+	require.ErrorIs(t, lintErr, context.DeadlineExceeded)
+	assert.Nil(t, output)
+	assert.Empty(t, linter.pool)
+}
+
+func TestLinter_Lint_DiscardsRuntimeOnError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	linter, err := New(WithDist([]byte("")), WithScript([]byte("Promise.reject('boom')")))
+	require.NoError(t, err)
+
+	// Act
+	output, lintErr := linter.Lint(context.Background(), []string{"./openapi.yaml"}, "./.spectral.yaml")
+
+	// Assert
+	require.ErrorIs(t, lintErr, ErrPromiseRejected)
+	assert.Nil(t, output)
+	assert.Empty(t, linter.pool)
+}
+
+func TestWithPoolSize_RejectsNonPositiveSize(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithPoolSize(0))
+
+	// Assert
+	require.ErrorIs(t, err, ErrInvalidPoolSize)
+	assert.Nil(t, linter)
+}
+
+func TestLinter_Lint_RunsConcurrently(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`new Promise(function(res) {
+		setTimeout(function() { res(JSON.stringify([{ code: process.cwd() + ":" + lintDocuments[0] }])); }, 10);
+	})`)
+
+	var wg sync.WaitGroup
+	outputs := make([]Output, 8)
+	errs := make([]error, len(outputs))
+
+	// Act
+	for i := range outputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outputs[i], errs[i] = LintContext(context.Background(), []string{"openapi-" + strconv.Itoa(i) + ".yaml"}, "./.spectral.yaml",
+				WithDist([]byte("")), WithScript(script), WithWorkingDirectory("/wd-"+strconv.Itoa(i)))
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	for i := range outputs {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "/wd-"+strconv.Itoa(i)+":openapi-"+strconv.Itoa(i)+".yaml", outputs[i][0].Code)
+	}
+}
@@ -0,0 +1,72 @@
+package gospectral
+
+// lintOptions global variable name of the options passed to the lint function of the Dist
+const lintOptions = "lintOptions"
+
+// LintOptions are the options of the spectral lint command passed to the lint function of the Dist
+type LintOptions struct {
+	// Encoding of the documents, e.g. utf8
+	Encoding string
+
+	// IgnoreUnknownFormat if true does not warn about documents of an unknown format, e.g. a misspelled openapi key
+	IgnoreUnknownFormat bool
+
+	// FailOnUnmatchedGlobs if true fails the lint if a document glob does not match any file
+	FailOnUnmatchedGlobs bool
+
+	// Verbose increases the verbosity of the lint
+	Verbose bool
+
+	// Quiet suppresses logging of the lint
+	Quiet bool
+
+	// StdinFilepath is the path used for a document read from stdin, unset if ""
+	StdinFilepath string
+}
+
+// DefaultLintOptions used if no LintOptions are supplied
+func DefaultLintOptions() LintOptions {
+	return LintOptions{
+		Encoding:             "utf8",
+		IgnoreUnknownFormat:  true,
+		FailOnUnmatchedGlobs: false,
+		Verbose:              true,
+		Quiet:                false,
+		StdinFilepath:        "",
+	}
+}
+
+// values of the LintOptions as the flags of the spectral lint command for the ruleset
+func (o LintOptions) values(ruleset string) map[string]any {
+	var stdinFilepath any
+	if o.StdinFilepath != "" {
+		stdinFilepath = o.StdinFilepath
+	}
+
+	return map[string]any{
+		"encoding":             o.Encoding,
+		"format":               []any{"json"},
+		"output":               map[string]any{"json": "<stdout>"},
+		"ruleset":              ruleset,
+		"stdinFilepath":        stdinFilepath,
+		"ignoreUnknownFormat":  o.IgnoreUnknownFormat,
+		"failOnUnmatchedGlobs": o.FailOnUnmatchedGlobs,
+		"verbose":              o.Verbose,
+		"quiet":                o.Quiet,
+	}
+}
+
+// WithLintOptions sets the Config.LintOptions passed to the lint function of the Dist. The options replace every field
+// of the defaults, so start from DefaultLintOptions to change a single field, e.g. to set IgnoreUnknownFormat to false
+// such that documents of an unknown format fail the lint:
+//
+//	options := DefaultLintOptions()
+//	options.IgnoreUnknownFormat = false
+//	linter, err := New(WithLintOptions(options))
+func WithLintOptions(options LintOptions) Option {
+	return func(config *Config) error {
+		config.LintOptions = options
+
+		return nil
+	}
+}
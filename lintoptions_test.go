@@ -0,0 +1,61 @@
+package gospectral
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// optionsDist returns the documents and options its lint function is called with as the message of a single Rule
+var optionsDist = []byte(`exports.formatOutput = function(results) { return JSON.stringify(results); };
+exports.lint = function(documents, options) {
+    return Promise.resolve({ results: [{ code: documents.join(","), message: JSON.stringify(options) }] });
+};`)
+
+func TestLint_PassesDefaultLintOptionsToDist(t *testing.T) {
+	t.Parallel()
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "./.spectral.yaml", WithDist(optionsDist))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "./openapi.yaml", output[0].Code)
+	assert.JSONEq(t, `{
+		"encoding": "utf8",
+		"format": ["json"],
+		"output": {"json": "<stdout>"},
+		"ruleset": "./.spectral.yaml",
+		"stdinFilepath": null,
+		"ignoreUnknownFormat": true,
+		"failOnUnmatchedGlobs": false,
+		"verbose": true,
+		"quiet": false
+	}`, output[0].Message)
+}
+
+func TestLint_PassesLintOptionsToDist(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	options := LintOptions{Encoding: "latin1", FailOnUnmatchedGlobs: true, Quiet: true, StdinFilepath: "stdin.yaml"}
+
+	// Act
+	output, err := Lint([]string{"./a.yaml", "./b.yaml"}, "./.spectral.yaml", WithDist(optionsDist), WithLintOptions(options))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "./a.yaml,./b.yaml", output[0].Code)
+	assert.JSONEq(t, `{
+		"encoding": "latin1",
+		"format": ["json"],
+		"output": {"json": "<stdout>"},
+		"ruleset": "./.spectral.yaml",
+		"stdinFilepath": "stdin.yaml",
+		"ignoreUnknownFormat": false,
+		"failOnUnmatchedGlobs": true,
+		"verbose": false,
+		"quiet": true
+	}`, output[0].Message)
+}
@@ -87,6 +87,7 @@ func WithAfterModule(after AfterModule) Option {
 func Enables() []Enable {
 	return []Enable{
 		{Name: util.ModuleName, Fn: util.Enable},
+		{Name: events.ModuleName, Fn: events.Enable},
 		{Name: stream.ModuleName, Fn: stream.Enable},
 		{Name: http.ModuleName, Fn: http.Enable},
 		{Name: https.ModuleName, Fn: https.Enable},
@@ -117,7 +118,6 @@ func Enables() []Enable {
 		{Name: tty.ModuleName, Fn: tty.Enable},
 		{Name: constants.ModuleName, Fn: constants.Enable},
 		{Name: punycode.ModuleName, Fn: punycode.Enable},
-		{Name: events.ModuleName, Fn: events.Enable},
 	}
 }
 
@@ -0,0 +1,178 @@
+package gospectral
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrNetworkDenied when a request is denied by the NetworkPolicy
+var ErrNetworkDenied = errors.New("network request denied")
+
+// NetworkPolicy restricts the requests of a lint, e.g. to resolve remote $ref's and rulesets. The zero value denies
+// every request, such that a lint never leaves the machine.
+type NetworkPolicy struct {
+	// AllowedHosts may be requested, either as a host (e.g. "rulesets.example.com"), a host with a port
+	// (e.g. "localhost:8080") or a wildcard of the subdomains (e.g. "*.example.com")
+	AllowedHosts []string
+
+	// MirrorDirectory if not "" serves GET requests from a local mirror before the AllowedHosts are considered, where
+	// e.g. https://example.com/rulesets/company.yaml is served from <MirrorDirectory>/example.com/rulesets/company.yaml
+	MirrorDirectory string
+}
+
+// WithNetworkPolicy sets the Config.NetworkPolicy restricting the requests made with the Config.HTTPClient. A denied
+// request is returned by Lint as a *NetworkDeniedError (along with the Output if the lint completed).
+func WithNetworkPolicy(policy NetworkPolicy) Option {
+	return func(config *Config) error {
+		config.NetworkPolicy = &policy
+
+		return nil
+	}
+}
+
+// NetworkDeniedError when the NetworkPolicy denied a request, e.g. to resolve a remote $ref
+type NetworkDeniedError struct {
+	// URL of the denied request
+	URL string
+
+	// Source is the document with the $ref that triggered the request, empty if unknown (e.g. an extends of a ruleset)
+	Source string
+
+	// Path to the $ref in the Source
+	Path Path
+}
+
+// Error implementation of NetworkDeniedError
+func (e NetworkDeniedError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("%s: '%s'", ErrNetworkDenied, e.URL)
+	}
+
+	return fmt.Sprintf("%s: '%s' referenced at %s#/%s", ErrNetworkDenied, e.URL, e.Source, strings.Join(e.Path, "/"))
+}
+
+// Unwrap returns ErrNetworkDenied
+func (e NetworkDeniedError) Unwrap() error {
+	return ErrNetworkDenied
+}
+
+// reference of the denied request is the URL
+func (e *NetworkDeniedError) reference() string {
+	return e.URL
+}
+
+// attribute the denied request to the $ref at the path in the source
+func (e *NetworkDeniedError) attribute(source string, path Path) {
+	e.Source, e.Path = source, path
+}
+
+// allowed if the host (with an optional port) is one of the AllowedHosts
+func (p NetworkPolicy) allowed(host string) bool {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+
+	for _, allowed := range p.AllowedHosts {
+		switch {
+		case strings.EqualFold(allowed, host), strings.EqualFold(allowed, hostname):
+			return true
+		case strings.HasPrefix(allowed, "*."):
+			if suffix := allowed[1:]; len(hostname) > len(suffix) && strings.HasSuffix(strings.ToLower(hostname), strings.ToLower(suffix)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// client with the Transport of the supplied client restricted by the policy
+func (p NetworkPolicy) client(client *http.Client) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	restricted := *client
+	restricted.Transport = &policyTransport{policy: p, next: next}
+
+	return &restricted
+}
+
+// policyTransport serves requests from the mirror, passes allowed requests to the next http.RoundTripper and denies
+// every other request
+type policyTransport struct {
+	policy NetworkPolicy
+	next   http.RoundTripper
+}
+
+// RoundTrip implementation of http.RoundTripper
+func (t *policyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.policy.MirrorDirectory != "" && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+		resp, err := t.mirror(req)
+		if err == nil {
+			return resp, nil
+		}
+
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if t.policy.allowed(req.URL.Host) {
+		return t.next.RoundTrip(req)
+	}
+
+	denied := &NetworkDeniedError{URL: req.URL.String()}
+	denialsOf(req.Context()).add(denied)
+
+	return nil, denied
+}
+
+// mirror serves the request from the MirrorDirectory. The host and path are cleaned as rooted paths, so the file can
+// not be outside the directory of the host.
+func (t *policyTransport) mirror(req *http.Request) (*http.Response, error) {
+	host := filepath.FromSlash(path.Clean("/" + req.URL.Host))
+	name := filepath.Join(t.policy.MirrorDirectory, host, filepath.FromSlash(path.Clean("/"+req.URL.Path)))
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s is a directory: %w", name, fs.ErrNotExist)
+	}
+
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	body := b
+	if req.Method == http.MethodHead {
+		body = nil
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(http.StatusOK) + " " + http.StatusText(http.StatusOK),
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Length": {strconv.Itoa(len(b))}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(b)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package gospectral
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// refDist fetches the ruleset URL like the resolution of a $ref and reports a failure as an invalid-ref Rule
+var refDist = []byte(`exports.formatOutput = function(results) { return JSON.stringify(results); };
+exports.lint = function(documents, options) {
+    return fetch(options.ruleset)
+        .then(function(res) { return res.text(); })
+        .then(function(body) { return { results: [{ code: "fetched", message: body }] }; })
+        .catch(function(err) {
+            return { results: [{ code: "invalid-ref", message: err.message, source: documents[0], path: ["components", "schemas", "User", "$ref"] }] };
+        });
+};`)
+
+func TestNetworkPolicy_Allowed(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		allowedHosts []string
+		host         string
+		allowed      bool
+	}{
+		"zero value denies":          {host: "example.com", allowed: false},
+		"host":                       {allowedHosts: []string{"example.com"}, host: "example.com", allowed: true},
+		"host is case insensitive":   {allowedHosts: []string{"Example.com"}, host: "example.COM", allowed: true},
+		"host allows any port":       {allowedHosts: []string{"example.com"}, host: "example.com:8443", allowed: true},
+		"host with port":             {allowedHosts: []string{"localhost:8080"}, host: "localhost:8080", allowed: true},
+		"host with other port":       {allowedHosts: []string{"localhost:8080"}, host: "localhost:9090", allowed: false},
+		"other host":                 {allowedHosts: []string{"example.com"}, host: "example.org", allowed: false},
+		"wildcard subdomain":         {allowedHosts: []string{"*.example.com"}, host: "rulesets.example.com", allowed: true},
+		"wildcard excludes the apex": {allowedHosts: []string{"*.example.com"}, host: "example.com", allowed: false},
+		"wildcard suffix":            {allowedHosts: []string{"*.example.com"}, host: "badexample.com", allowed: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			policy := NetworkPolicy{AllowedHosts: tt.allowedHosts}
+
+			// Act
+			allowed := policy.allowed(tt.host)
+
+			// Assert
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
+
+func TestLint_DeniesRequestsByDefaultPolicy(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	requested := false
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		requested = true
+	}))
+	defer server.Close()
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, server.URL+"/ruleset.yaml", WithDist(fetchDist), WithNetworkPolicy(NetworkPolicy{}))
+
+	// Assert
+	require.ErrorIs(t, err, ErrNetworkDenied)
+	require.ErrorIs(t, err, ErrPromiseRejected)
+	var denied *NetworkDeniedError
+	require.ErrorAs(t, err, &denied)
+	assert.Equal(t, server.URL+"/ruleset.yaml", denied.URL)
+	assert.Empty(t, denied.Source)
+	assert.Nil(t, output)
+	assert.False(t, requested)
+}
+
+func TestLint_AttributesDeniedRequestToRef(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	url := "https://schemas.example.com/user.yaml"
+
+	// Act
+	output, err := Lint([]string{"/api/openapi.yaml"}, url, WithDist(refDist), WithNetworkPolicy(NetworkPolicy{}))
+
+	// Assert
+	var denied *NetworkDeniedError
+	require.ErrorAs(t, err, &denied)
+	assert.Equal(t, &NetworkDeniedError{
+		URL:    url,
+		Source: "/api/openapi.yaml",
+		Path:   Path{"components", "schemas", "User", "$ref"},
+	}, denied)
+	assert.Equal(t, "network request denied: '"+url+"' referenced at /api/openapi.yaml#/components/schemas/User/$ref", denied.Error())
+	require.Len(t, output, 1)
+	assert.Equal(t, "invalid-ref", output[0].Code)
+}
+
+func TestLint_AllowsAllowedHosts(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("extends: spectral:oas"))
+	}))
+	defer server.Close()
+
+	policy := NetworkPolicy{AllowedHosts: []string{strings.TrimPrefix(server.URL, "http://")}}
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, server.URL+"/ruleset.yaml", WithDist(refDist), WithNetworkPolicy(policy))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "extends: spectral:oas", output[0].Message)
+}
+
+func TestLint_ResolvesFromMirrorDirectory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	mirror := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(mirror, "rulesets.example.com", "company"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(mirror, "rulesets.example.com", "company", "ruleset.yaml"), []byte("extends: spectral:oas"), 0o600))
+
+	policy := NetworkPolicy{MirrorDirectory: mirror}
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "https://rulesets.example.com/company/ruleset.yaml", WithDist(refDist), WithNetworkPolicy(policy))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "extends: spectral:oas", output[0].Message)
+}
+
+func TestLint_DeniesRequestOutsideMirroredHost(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	root := t.TempDir()
+	mirror := filepath.Join(root, "mirror")
+	require.NoError(t, os.MkdirAll(mirror, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret.yaml"), []byte("secret"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(mirror, "secret.yaml"), []byte("secret"), 0o600))
+
+	policy := NetworkPolicy{MirrorDirectory: mirror}
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "https://example.com/../secret.yaml", WithDist(refDist), WithNetworkPolicy(policy))
+
+	// Assert
+	require.ErrorIs(t, err, ErrNetworkDenied)
+	require.Len(t, output, 1)
+	assert.Equal(t, "invalid-ref", output[0].Code)
+}
@@ -0,0 +1,375 @@
+package events
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/Emptyless/go-spectral/node/internal/args"
+	"github.com/dop251/goja"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
+)
+
+// defaultMaxListeners of an emitter unless set with setMaxListeners or EventEmitter.defaultMaxListeners
+const defaultMaxListeners = 10
+
+// listener of an event, where the fn of a once listener is a wrapper that removes itself before calling the listener
+type listener struct {
+	fn       *goja.Object
+	listener *goja.Object
+	callable goja.Callable
+}
+
+// matches if the function is the registered function or the listener it wraps
+func (l *listener) matches(function *goja.Object) bool {
+	return l.fn.SameAs(function) || l.listener.SameAs(function)
+}
+
+// state of an emitter, i.e. the listeners of the events in the order the events were first listened to
+type state struct {
+	owner        *goja.Object
+	names        []goja.Value
+	listeners    map[any][]*listener
+	maxListeners float64
+}
+
+// key of the event name, which is either a string or a symbol
+func key(name goja.Value) any {
+	if symbol, ok := name.(*goja.Symbol); ok {
+		return symbol
+	}
+
+	return name.String()
+}
+
+// has listeners of the event
+func (s *state) has(name string) bool {
+	return len(s.listeners[name]) > 0
+}
+
+// add the listener of the event, before the other listeners if prepend
+func (s *state) add(name goja.Value, l *listener, prepend bool) {
+	k := key(name)
+	if _, ok := s.listeners[k]; !ok {
+		s.names = append(s.names, name)
+	}
+
+	if prepend {
+		s.listeners[k] = append([]*listener{l}, s.listeners[k]...)
+	} else {
+		s.listeners[k] = append(s.listeners[k], l)
+	}
+}
+
+// delete the listeners of the event
+func (s *state) delete(name goja.Value) {
+	k := key(name)
+	delete(s.listeners, k)
+	s.names = slices.DeleteFunc(s.names, func(n goja.Value) bool {
+		return key(n) == k
+	})
+}
+
+// state of the emitter. The state is created on first use, such that an object inheriting from the prototype without
+// calling the constructor (e.g. Object.create(EventEmitter.prototype)) is an emitter as well. The state of a prototype
+// (e.g. Child.prototype = new EventEmitter()) is never shared with the objects inheriting from it.
+func (e *EventEmitter) state(this goja.Value) *state {
+	object := this.ToObject(e.r)
+	if v := object.GetSymbol(e.symbol); v != nil {
+		if s, ok := v.Export().(*state); ok && s.owner.SameAs(object) {
+			return s
+		}
+	}
+
+	return e.init(object)
+}
+
+// init the state of the emitter
+func (e *EventEmitter) init(object *goja.Object) *state {
+	s := &state{owner: object, listeners: map[any][]*listener{}, maxListeners: -1}
+	_ = object.DefineDataPropertySymbol(e.symbol, e.r.ToValue(s), goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+
+	return s
+}
+
+// function argument, panicking with a Node ERR_INVALID_ARG_TYPE TypeError if it is not a function
+func (e *EventEmitter) function(value goja.Value, name string) (*goja.Object, goja.Callable) {
+	callable, ok := goja.AssertFunction(value)
+	if !ok {
+		panic(args.InvalidArgType(e.r, name, "of type function", value))
+	}
+
+	return value.ToObject(e.r), callable
+}
+
+// addListener of the event, which is removed after its first call if once. Like Node, the 'newListener' event is
+// emitted before the listener is added.
+func (e *EventEmitter) addListener(this goja.Value, name goja.Value, value goja.Value, prepend bool, once bool) {
+	fn, callable := e.function(value, "listener")
+	l := &listener{fn: fn, listener: fn, callable: callable}
+	if once {
+		l.fn = e.onceWrapper(this, name, l)
+		l.callable, _ = goja.AssertFunction(l.fn)
+	}
+
+	s := e.state(this)
+	if s.has("newListener") {
+		e.emit(this, e.r.ToValue("newListener"), name, fn)
+	}
+
+	s.add(name, l, prepend)
+}
+
+// onceWrapper of the listener, which removes itself from the emitter before calling the listener
+func (e *EventEmitter) onceWrapper(this goja.Value, name goja.Value, l *listener) *goja.Object {
+	callable := l.callable
+	fired := false
+	var wrapper *goja.Object
+	wrapper = e.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		if fired {
+			return goja.Undefined()
+		}
+		fired = true
+
+		e.removeListener(this, name, wrapper)
+		res, err := callable(this, call.Arguments...)
+		if err != nil {
+			panic(err)
+		}
+
+		return res
+	}).ToObject(e.r)
+	_ = wrapper.Set("listener", l.listener)
+
+	return wrapper
+}
+
+// removeListener of the event, i.e. the most recently added listener that is or wraps the function
+func (e *EventEmitter) removeListener(this goja.Value, name goja.Value, function *goja.Object) {
+	s := e.state(this)
+	k := key(name)
+	listeners := s.listeners[k]
+	for i := len(listeners) - 1; i >= 0; i-- {
+		if !listeners[i].matches(function) {
+			continue
+		}
+
+		removed := listeners[i]
+		if len(listeners) == 1 {
+			s.delete(name)
+		} else {
+			s.listeners[k] = slices.Delete(slices.Clone(listeners), i, i+1)
+		}
+
+		if s.has("removeListener") {
+			e.emit(this, e.r.ToValue("removeListener"), name, removed.listener)
+		}
+
+		return
+	}
+}
+
+// removeAllListeners of the event, or of every event if the name is undefined. The 'removeListener' event is emitted
+// for every removed listener, the listeners of 'removeListener' itself being removed last.
+func (e *EventEmitter) removeAllListeners(this goja.Value, name goja.Value) {
+	s := e.state(this)
+	if !s.has("removeListener") {
+		if goja.IsUndefined(name) {
+			s.names, s.listeners = nil, map[any][]*listener{}
+		} else {
+			s.delete(name)
+		}
+
+		return
+	}
+
+	if goja.IsUndefined(name) {
+		for _, n := range slices.Clone(s.names) {
+			if key(n) != "removeListener" {
+				e.removeAllListeners(this, n)
+			}
+		}
+		e.removeAllListeners(this, e.r.ToValue("removeListener"))
+
+		return
+	}
+
+	listeners := s.listeners[key(name)]
+	for i := len(listeners) - 1; i >= 0; i-- {
+		e.removeListener(this, name, listeners[i].fn)
+	}
+}
+
+// emit the event, calling the listeners in order with the emitter as this. A listener that throws stops the emit. An
+// 'error' event is first emitted to the errorMonitor and is thrown if the event has no listeners.
+func (e *EventEmitter) emit(this goja.Value, name goja.Value, args ...goja.Value) bool {
+	s := e.state(this)
+	if key(name) == "error" {
+		if len(s.listeners[e.errorMonitor]) > 0 {
+			e.emit(this, e.errorMonitor, args...)
+		}
+
+		if !s.has("error") {
+			panic(e.unhandled(args))
+		}
+	}
+
+	listeners := slices.Clone(s.listeners[key(name)])
+	for _, l := range listeners {
+		if _, err := l.callable(this, args...); err != nil {
+			panic(err)
+		}
+	}
+
+	return len(listeners) > 0
+}
+
+// unhandled error of an 'error' event without listeners, which is the emitted Error itself or else an
+// ERR_UNHANDLED_ERROR Error with the emitted value as context
+func (e *EventEmitter) unhandled(args []goja.Value) *goja.Object {
+	er := goja.Undefined()
+	if len(args) > 0 {
+		er = args[0]
+	}
+
+	if object, ok := er.(*goja.Object); ok && object.ClassName() == "Error" {
+		return object
+	}
+
+	message := "Unhandled error."
+	if s, ok := er.(goja.String); ok {
+		message += fmt.Sprintf(" ('%s')", s)
+	} else {
+		message += fmt.Sprintf(" (%s)", er)
+	}
+
+	err := nodeerrors.NewError(e.r, nil, "ERR_UNHANDLED_ERROR", "%s", message)
+	_ = err.Set("context", er)
+
+	return err
+}
+
+// On adds the listener of the event, i.e. emitter.on(eventName, listener)
+func (e *EventEmitter) On(call goja.FunctionCall) goja.Value {
+	e.addListener(call.This, call.Argument(0), call.Argument(1), false, false)
+
+	return call.This
+}
+
+// PrependListener adds the listener of the event before the other listeners
+func (e *EventEmitter) PrependListener(call goja.FunctionCall) goja.Value {
+	e.addListener(call.This, call.Argument(0), call.Argument(1), true, false)
+
+	return call.This
+}
+
+// Once adds the listener of the event, which is removed before it is called
+func (e *EventEmitter) Once(call goja.FunctionCall) goja.Value {
+	e.addListener(call.This, call.Argument(0), call.Argument(1), false, true)
+
+	return call.This
+}
+
+// PrependOnceListener adds the once listener of the event before the other listeners
+func (e *EventEmitter) PrependOnceListener(call goja.FunctionCall) goja.Value {
+	e.addListener(call.This, call.Argument(0), call.Argument(1), true, true)
+
+	return call.This
+}
+
+// Off removes the listener of the event, i.e. emitter.off(eventName, listener)
+func (e *EventEmitter) Off(call goja.FunctionCall) goja.Value {
+	function, _ := e.function(call.Argument(1), "listener")
+	e.removeListener(call.This, call.Argument(0), function)
+
+	return call.This
+}
+
+// RemoveAllListeners of the event or of every event if no event is supplied
+func (e *EventEmitter) RemoveAllListeners(call goja.FunctionCall) goja.Value {
+	e.removeAllListeners(call.This, call.Argument(0))
+
+	return call.This
+}
+
+// Emit the event with the arguments, returning whether the event had listeners
+func (e *EventEmitter) Emit(call goja.FunctionCall) goja.Value {
+	var args []goja.Value
+	if len(call.Arguments) > 1 {
+		args = call.Arguments[1:]
+	}
+
+	return e.r.ToValue(e.emit(call.This, call.Argument(0), args...))
+}
+
+// ListenerCount of the event, counting only the listeners that are or wrap the optional listener argument
+func (e *EventEmitter) ListenerCount(call goja.FunctionCall) goja.Value {
+	listeners := e.state(call.This).listeners[key(call.Argument(0))]
+	function, ok := call.Argument(1).(*goja.Object)
+	if !ok {
+		return e.r.ToValue(len(listeners))
+	}
+
+	count := 0
+	for _, l := range listeners {
+		if l.matches(function) {
+			count++
+		}
+	}
+
+	return e.r.ToValue(count)
+}
+
+// Listeners of the event, where the wrapper of a once listener is unwrapped
+func (e *EventEmitter) Listeners(call goja.FunctionCall) goja.Value {
+	var functions []any
+	for _, l := range e.state(call.This).listeners[key(call.Argument(0))] {
+		functions = append(functions, l.listener)
+	}
+
+	return e.r.NewArray(functions...)
+}
+
+// RawListeners of the event, including the wrappers of the once listeners
+func (e *EventEmitter) RawListeners(call goja.FunctionCall) goja.Value {
+	var functions []any
+	for _, l := range e.state(call.This).listeners[key(call.Argument(0))] {
+		functions = append(functions, l.fn)
+	}
+
+	return e.r.NewArray(functions...)
+}
+
+// EventNames that have listeners
+func (e *EventEmitter) EventNames(call goja.FunctionCall) goja.Value {
+	var names []any
+	for _, name := range e.state(call.This).names {
+		names = append(names, name)
+	}
+
+	return e.r.NewArray(names...)
+}
+
+// SetMaxListeners of the emitter. A listener exceeding the maximum is still added, i.e. there is no leak warning.
+func (e *EventEmitter) SetMaxListeners(call goja.FunctionCall) goja.Value {
+	if args.TypeOf(call.Argument(0)) != "number" {
+		panic(args.InvalidArgType(e.r, "n", "of type number", call.Argument(0)))
+	}
+
+	if n := call.Argument(0).ToFloat(); math.IsNaN(n) || n < 0 {
+		panic(nodeerrors.NewError(e.r, e.r.Get("RangeError").ToObject(e.r), "ERR_OUT_OF_RANGE", "The value of \"n\" is out of range. It must be a non-negative number. Received %s", call.Argument(0)))
+	}
+
+	e.state(call.This).maxListeners = call.Argument(0).ToFloat()
+
+	return call.This
+}
+
+// GetMaxListeners of the emitter, which is EventEmitter.defaultMaxListeners unless set with setMaxListeners
+func (e *EventEmitter) GetMaxListeners(call goja.FunctionCall) goja.Value {
+	if n := e.state(call.This).maxListeners; n >= 0 {
+		return e.r.ToValue(n)
+	}
+
+	return e.constructor.Get("defaultMaxListeners")
+}
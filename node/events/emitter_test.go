@@ -0,0 +1,218 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/dop251/goja"
+	noderequire "github.com/dop251/goja_nodejs/require"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventEmitter_On(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var received = [];
+e.on('ref', function(a, b) { received.push('first ' + a + b + (this === e)); });
+e.addListener('ref', function(a, b) { received.push('second ' + a + b); });
+e.prependListener('ref', function() { received.push('prepended'); });
+[e.emit('ref', 'a', 'b'), e.emit('other'), received]`)
+
+	// Assert
+	assert.Equal(t, []any{true, false, []any{"prepended", "first abtrue", "second ab"}}, res.Export())
+}
+
+func TestEventEmitter_Once(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var received = [];
+e.once('ref', function(a) { received.push('once ' + a); });
+e.prependOnceListener('ref', function(a) { received.push('prepended ' + a); });
+e.emit('ref', 1);
+e.emit('ref', 2);
+[received, e.listenerCount('ref')]`)
+
+	// Assert
+	assert.Equal(t, []any{[]any{"prepended 1", "once 1"}, int64(0)}, res.Export())
+}
+
+func TestEventEmitter_Off(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var received = [];
+function listener(n) { received.push(n); }
+e.on('ref', listener);
+e.on('ref', listener);
+e.once('other', listener);
+e.off('ref', listener);
+e.emit('ref', 1);
+e.removeListener('other', listener);
+e.emit('other', 2);
+[received, e.listenerCount('ref'), e.eventNames()]`)
+
+	// Assert
+	assert.Equal(t, []any{[]any{int64(1)}, int64(1), []any{"ref"}}, res.Export())
+}
+
+func TestEventEmitter_RemoveAllListeners(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var removed = [];
+e.on('a', function a() {});
+e.on('b', function b() {});
+e.on('b', function c() {});
+e.on('removeListener', function(name, listener) { removed.push(name + ':' + listener.name); });
+e.removeAllListeners('b');
+var afterB = e.eventNames();
+e.removeAllListeners();
+[removed, afterB, e.eventNames()]`)
+
+	// Assert
+	assert.Equal(t, []any{[]any{"b:c", "b:b", "a:a"}, []any{"a", "removeListener"}, []any{}}, res.Export())
+}
+
+func TestEventEmitter_NewListener(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var added = [];
+e.on('newListener', function(name, listener) { added.push(name + ':' + listener.name + ':' + e.listenerCount(name)); });
+e.once('ref', function resolve() {});
+added`)
+
+	// Assert
+	assert.Equal(t, []any{"ref:resolve:0"}, res.Export())
+}
+
+func TestEventEmitter_Listeners(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var symbol = Symbol('parsed');
+function listener() {}
+e.once('ref', listener);
+e.on(symbol, listener);
+[e.listeners('ref')[0] === listener, e.rawListeners('ref')[0] === listener, e.rawListeners('ref')[0].listener === listener, e.listenerCount('ref', listener), e.eventNames()[1] === symbol, e.emit(symbol)]`)
+
+	// Assert
+	assert.Equal(t, []any{true, false, true, int64(1), true, true}, res.Export())
+}
+
+func TestEventEmitter_Emit_ListenerThrows(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var received = [];
+e.on('ref', function() { throw new Error('boom'); });
+e.on('ref', function() { received.push('second'); });
+var message;
+try { e.emit('ref'); } catch (err) { message = err.message; }
+[message, received]`)
+
+	// Assert
+	assert.Equal(t, []any{"boom", []any{}}, res.Export())
+}
+
+func TestEventEmitter_Emit_Error(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		emitted  string
+		expected string
+	}{
+		"error": {
+			emitted:  `new Error('boom')`,
+			expected: "Error: boom",
+		},
+		"string": {
+			emitted:  `'boom'`,
+			expected: "ERR_UNHANDLED_ERROR: Unhandled error. ('boom') boom",
+		},
+		"undefined": {
+			emitted:  `undefined`,
+			expected: "ERR_UNHANDLED_ERROR: Unhandled error. (undefined) undefined",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, `
+var e = new events();
+var thrown;
+try { e.emit('error', `+tt.emitted+`); } catch (err) { thrown = err.code ? err.code + ': ' + err.message + ' ' + err.context : err.toString(); }
+thrown`)
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
+
+func TestEventEmitter_Emit_ErrorMonitor(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var received = [];
+e.on(events.errorMonitor, function(err) { received.push('monitor ' + err.message); });
+e.on('error', function(err) { received.push('error ' + err.message); });
+e.emit('error', new Error('boom'));
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"monitor boom", "error boom"}, res.Export())
+}
+
+func TestEventEmitter_On_NotAFunctionShouldThrowTypeError(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `try { new events().on('ref', 'listener'); } catch (err) { err.name + ' ' + err.code + ': ' + err.message; }`)
+
+	// Assert
+	assert.Equal(t, `TypeError ERR_INVALID_ARG_TYPE: The "listener" argument must be of type function. Received type string ('listener')`, res.Export())
+}
+
+func TestEventEmitter_SetMaxListeners(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var e = new events();
+var message;
+try { e.setMaxListeners(-1); } catch (err) { message = err.name + ' ' + err.code; }
+[e.setMaxListeners(20) === e, e.getMaxListeners(), message]`)
+
+	// Assert
+	assert.Equal(t, []any{true, int64(20), "RangeError ERR_OUT_OF_RANGE"}, res.Export())
+}
+
+func TestEventEmitter_UsableWithoutConstructor(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(runtime, registry, registry.Enable(runtime))
+
+	// Act
+	res, err := runtime.RunString(`
+var e = Object.create(events.prototype);
+var received;
+e.on('ref', function(ref) { received = ref; });
+e.emit('ref', '#/a');
+received`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "#/a", res.Export())
+}
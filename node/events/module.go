@@ -8,52 +8,145 @@ import (
 // ModuleName of the events package
 const ModuleName = "events"
 
-// EventEmitter holds the goja.Runtime to convert Go>JS
+// EventEmitter holds the goja.Runtime to convert Go>JS and the symbols of the events package
 type EventEmitter struct {
 	r *goja.Runtime
+
+	// constructor of the EventEmitter class
+	constructor *goja.Object
+
+	// symbol of the state of an emitter
+	symbol *goja.Symbol
+
+	// errorMonitor symbol, i.e. the event emitted before an 'error' event
+	errorMonitor *goja.Symbol
+}
+
+// newEventEmitter creates the EventEmitter class in the runtime, where the methods are set on its prototype such that
+// it can be extended with a class, util.inherits or an ES5 prototype chain calling EventEmitter.call(this)
+func newEventEmitter(runtime *goja.Runtime) *EventEmitter {
+	e := &EventEmitter{r: runtime, symbol: goja.NewSymbol("events"), errorMonitor: goja.NewSymbol("events.errorMonitor")}
+	e.constructor = runtime.ToValue(e.Constructor).ToObject(runtime)
+	_ = e.constructor.Set("EventEmitter", e.constructor)
+	_ = e.constructor.Set("defaultMaxListeners", defaultMaxListeners)
+	_ = e.constructor.Set("errorMonitor", e.errorMonitor)
+	_ = e.constructor.Set("once", e.OnceEvent)
+	_ = e.constructor.Set("listenerCount", e.StaticListenerCount)
+	_ = e.constructor.Set("getEventListeners", e.GetEventListeners)
+
+	prototype := e.constructor.Get("prototype").ToObject(runtime)
+	methods := []struct {
+		name string
+		fn   func(goja.FunctionCall) goja.Value
+	}{
+		{name: "setMaxListeners", fn: e.SetMaxListeners},
+		{name: "getMaxListeners", fn: e.GetMaxListeners},
+		{name: "emit", fn: e.Emit},
+		{name: "addListener", fn: e.On},
+		{name: "on", fn: e.On},
+		{name: "prependListener", fn: e.PrependListener},
+		{name: "once", fn: e.Once},
+		{name: "prependOnceListener", fn: e.PrependOnceListener},
+		{name: "removeListener", fn: e.Off},
+		{name: "off", fn: e.Off},
+		{name: "removeAllListeners", fn: e.RemoveAllListeners},
+		{name: "listeners", fn: e.Listeners},
+		{name: "rawListeners", fn: e.RawListeners},
+		{name: "listenerCount", fn: e.ListenerCount},
+		{name: "eventNames", fn: e.EventNames},
+	}
+	for _, method := range methods {
+		_ = prototype.Set(method.name, method.fn)
+	}
+
+	return e
 }
 
-// Constructor of EventEmitter
-func (e *EventEmitter) Constructor(_ goja.ConstructorCall) *goja.Object {
-	obj := e.r.NewObject()
-	_ = obj.Set("setMaxListeners", e.SetMaxListeners)
-	_ = obj.Set("once", e.Once)
-	_ = obj.Set("emit", e.Emit)
-	_ = obj.Set("off", e.Emit)
+// Constructor of EventEmitter, which initializes this when called as a function by the constructor of a subclass
+func (e *EventEmitter) Constructor(call goja.ConstructorCall) *goja.Object {
+	e.init(call.This)
 
-	return obj
+	return nil
 }
 
-// SetMaxListeners no-op
-func (e *EventEmitter) SetMaxListeners(_ goja.FunctionCall) goja.Value {
-	return goja.Undefined()
+// OnceEvent is events.once(emitter, name), i.e. a promise of the arguments of the first emit of the event. The promise
+// is rejected if the emitter emits an 'error' event first.
+func (e *EventEmitter) OnceEvent(call goja.FunctionCall) goja.Value {
+	promise, resolve, reject := e.r.NewPromise()
+	emitter := call.Argument(0).ToObject(e.r)
+	name := call.Argument(1)
+	once, ok := goja.AssertFunction(emitter.Get("once"))
+	if !ok {
+		panic(e.r.NewTypeError("The \"emitter\" argument must be an instance of EventEmitter"))
+	}
+
+	removeListener := func(event goja.Value, listener goja.Value) {
+		if remove, ok := goja.AssertFunction(emitter.Get("removeListener")); ok {
+			if _, err := remove(emitter, event, listener); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	var resolver, rejecter goja.Value
+	resolver = e.r.ToValue(func(c goja.FunctionCall) goja.Value {
+		if rejecter != nil {
+			removeListener(e.r.ToValue("error"), rejecter)
+		}
+		_ = resolve(e.r.NewArray(valuesOf(c.Arguments)...))
+
+		return goja.Undefined()
+	})
+	if _, err := once(emitter, name, resolver); err != nil {
+		panic(err)
+	}
+
+	if key(name) != "error" {
+		rejecter = e.r.ToValue(func(c goja.FunctionCall) goja.Value {
+			removeListener(name, resolver)
+			_ = reject(c.Argument(0))
+
+			return goja.Undefined()
+		})
+		if _, err := once(emitter, e.r.ToValue("error"), rejecter); err != nil {
+			panic(err)
+		}
+	}
+
+	return e.r.ToValue(promise)
 }
 
-// Once no-op. Normally this contacts the pluginDriver to get all unfulfilled hooks and emits them
-func (e *EventEmitter) Once(_ goja.FunctionCall) goja.Value {
-	return goja.Undefined()
+// StaticListenerCount is the deprecated EventEmitter.listenerCount(emitter, name)
+func (e *EventEmitter) StaticListenerCount(call goja.FunctionCall) goja.Value {
+	return e.ListenerCount(goja.FunctionCall{This: call.Argument(0), Arguments: call.Arguments[min(1, len(call.Arguments)):]})
 }
 
-// Emit no-op
-func (e *EventEmitter) Emit(_ goja.FunctionCall) goja.Value {
-	return goja.Undefined()
+// GetEventListeners is events.getEventListeners(emitter, name), i.e. a copy of the listeners of the event
+func (e *EventEmitter) GetEventListeners(call goja.FunctionCall) goja.Value {
+	return e.Listeners(goja.FunctionCall{This: call.Argument(0), Arguments: call.Arguments[min(1, len(call.Arguments)):]})
 }
 
-// Off no-op
-func (e *EventEmitter) Off(_ goja.FunctionCall) goja.Value {
-	return goja.Undefined()
+// valuesOf the arguments as the items of an array
+func valuesOf(args []goja.Value) []any {
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+
+	return values
 }
 
-// Require the events package
+// Require the events package, exporting the EventEmitter class itself like Node does
 func Require(runtime *goja.Runtime, module *goja.Object) {
-	eventEmitter := &EventEmitter{r: runtime}
-	exports := module.Get("exports").(*goja.Object) //nolint:forcetypeassert // based on reference implementation in library
-	_ = exports.Set("EventEmitter", eventEmitter.Constructor)
+	_ = module.Set("exports", newEventEmitter(runtime).constructor)
 }
 
-// Enable events package with a stub implementation
+// Enable events package. The node:events package exports the same EventEmitter class, such that an emitter of either
+// is an instance of both.
 func Enable(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule) {
-	registry.RegisterNativeModule("node:"+ModuleName, Require)
 	registry.RegisterNativeModule(ModuleName, Require)
+	registry.RegisterNativeModule("node:"+ModuleName, func(runtime *goja.Runtime, module *goja.Object) {
+		_ = module.Set("exports", require.Require(runtime, ModuleName))
+	})
 	_ = runtime.Set(ModuleName, require.Require(runtime, ModuleName))
 }
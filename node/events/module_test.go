@@ -9,57 +9,121 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// run the script in a runtime with the events package enabled
+func run(t *testing.T, script string) goja.Value {
+	t.Helper()
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(runtime, registry, registry.Enable(runtime))
+
+	res, err := runtime.RunString(script)
+	require.NoError(t, err)
+
+	return res
+}
+
 func TestEventEmitter_Constructor(t *testing.T) {
 	t.Parallel()
-	// Arrange
-	emitter := &EventEmitter{r: goja.New()}
+	// Act
+	res := run(t, `var e = new events.EventEmitter(); [e instanceof events, typeof e.on, typeof e.emit, e.getMaxListeners()]`)
 
+	// Assert
+	assert.Equal(t, []any{true, "function", "function", int64(10)}, res.Export())
+}
+
+func TestEventEmitter_Class(t *testing.T) {
+	t.Parallel()
 	// Act
-	obj := emitter.Constructor(goja.ConstructorCall{})
+	res := run(t, `
+class Document extends events {
+	constructor(name) {
+		super();
+		this.name = name;
+	}
+}
+var d = new Document('openapi.yaml');
+var received = [];
+d.on('parsed', function(result) { received.push(this.name + ':' + result); });
+[d.emit('parsed', 'ok'), received.join(), d instanceof events]`)
 
 	// Assert
-	actual := obj.Export()
-	assert.Len(t, actual, 4)
-	assert.Contains(t, actual, "setMaxListeners")
-	assert.Contains(t, actual, "once")
-	assert.Contains(t, actual, "emit")
-	assert.Contains(t, actual, "off")
+	assert.Equal(t, []any{true, "openapi.yaml:ok", true}, res.Export())
 }
 
-func TestEventEmitter_SetMaxListeners(t *testing.T) {
+func TestEventEmitter_PrototypeChain(t *testing.T) {
 	t.Parallel()
-	// Arrange
-	emitter := &EventEmitter{r: goja.New()}
+	// Act
+	res := run(t, `
+function Resolver() {
+	events.call(this);
+}
+Resolver.prototype = Object.create(events.prototype);
+Resolver.prototype.constructor = Resolver;
+var a = new Resolver();
+var b = new Resolver();
+var received = [];
+a.on('resolved', function(ref) { received.push(ref); });
+[a.emit('resolved', '#/a'), b.emit('resolved', '#/b'), received.join(), b.listenerCount('resolved')]`)
 
+	// Assert
+	assert.Equal(t, []any{true, false, "#/a", int64(0)}, res.Export())
+}
+
+func TestEventEmitter_SharedPrototypeInstance(t *testing.T) {
+	t.Parallel()
 	// Act
-	obj := emitter.SetMaxListeners(goja.FunctionCall{})
+	res := run(t, `
+function Legacy() {}
+Legacy.prototype = new events();
+var a = new Legacy();
+var b = new Legacy();
+a.on('x', function() {});
+[a.listenerCount('x'), b.listenerCount('x')]`)
 
 	// Assert
-	assert.Equal(t, goja.Undefined(), obj)
+	assert.Equal(t, []any{int64(1), int64(0)}, res.Export())
 }
 
-func TestEventEmitter_SetOnce(t *testing.T) {
+func TestEventEmitter_StaticOnce(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	emitter := &EventEmitter{r: goja.New()}
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(runtime, registry, registry.Enable(runtime))
 
 	// Act
-	obj := emitter.Once(goja.FunctionCall{})
+	_, err := runtime.RunString(`
+var e = new events();
+var result;
+events.once(e, 'done').then(function(args) { result = args; });
+e.emit('done', 1, 2);
+var counts = [e.listenerCount('done'), e.listenerCount('error')];`)
+	require.NoError(t, err)
 
 	// Assert
-	assert.Equal(t, goja.Undefined(), obj)
+	assert.Equal(t, []any{int64(1), int64(2)}, runtime.Get("result").Export())
+	assert.Equal(t, []any{int64(0), int64(0)}, runtime.Get("counts").Export())
 }
 
-func TestEventEmitter_SetOff(t *testing.T) {
+func TestEventEmitter_StaticOnce_RejectsOnError(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	emitter := &EventEmitter{r: goja.New()}
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(runtime, registry, registry.Enable(runtime))
 
 	// Act
-	obj := emitter.Off(goja.FunctionCall{})
+	_, err := runtime.RunString(`
+var e = new events();
+var outcome = [];
+events.once(e, 'done').then(function(args) { outcome.push('resolved ' + args.join()); }, function(err) { outcome.push('rejected ' + err.message); });
+e.emit('error', new Error('boom'));
+var counts = [e.listenerCount('done'), e.listenerCount('error')];`)
+	require.NoError(t, err)
 
 	// Assert
-	assert.Equal(t, goja.Undefined(), obj)
+	assert.Equal(t, []any{"rejected boom"}, runtime.Get("outcome").Export())
+	assert.Equal(t, []any{int64(0), int64(0)}, runtime.Get("counts").Export())
 }
 
 func TestRequire(t *testing.T) {
@@ -67,14 +131,17 @@ func TestRequire(t *testing.T) {
 	// Arrange
 	runtime := goja.New()
 	module := runtime.NewObject()
-	exports := runtime.NewObject()
-	_ = module.Set("exports", exports)
+	_ = module.Set("exports", runtime.NewObject())
 
 	// Act
 	Require(runtime, module)
 
 	// Assert
-	assert.NotNil(t, exports.Get("EventEmitter"))
+	exports := module.Get("exports").ToObject(runtime)
+	assert.Equal(t, exports, exports.Get("EventEmitter"))
+	assert.NotNil(t, exports.Get("once"))
+	assert.NotNil(t, exports.Get("errorMonitor"))
+	assert.Equal(t, int64(10), exports.Get("defaultMaxListeners").Export())
 }
 
 func TestEnable(t *testing.T) {
@@ -94,3 +161,13 @@ func TestEnable(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, res)
 }
+
+func TestEnable_SharesClassWithNodePrefix(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `var EventEmitter = require('node:events');
+[EventEmitter === require('events'), EventEmitter === events, new events() instanceof EventEmitter]`)
+
+	// Assert
+	assert.Equal(t, []any{true, true, true}, res.Export())
+}
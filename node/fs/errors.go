@@ -0,0 +1,94 @@
+package fs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"syscall"
+
+	"github.com/dop251/goja"
+)
+
+// errorCode of a Node system error, see https://nodejs.org/api/errors.html#common-system-errors
+type errorCode struct {
+	code        string
+	errno       int
+	description string
+}
+
+// errorCodes by syscall.Errno, where errno is the negated Linux error number like libuv reports it
+var errorCodes = map[syscall.Errno]errorCode{
+	syscall.EPERM:        {code: "EPERM", errno: -1, description: "operation not permitted"},
+	syscall.ENOENT:       {code: "ENOENT", errno: -2, description: "no such file or directory"},
+	syscall.EACCES:       {code: "EACCES", errno: -13, description: "permission denied"},
+	syscall.EEXIST:       {code: "EEXIST", errno: -17, description: "file already exists"},
+	syscall.ENOTDIR:      {code: "ENOTDIR", errno: -20, description: "not a directory"},
+	syscall.EISDIR:       {code: "EISDIR", errno: -21, description: "illegal operation on a directory"},
+	syscall.EINVAL:       {code: "EINVAL", errno: -22, description: "invalid argument"},
+	syscall.EMFILE:       {code: "EMFILE", errno: -24, description: "too many open files"},
+	syscall.ENAMETOOLONG: {code: "ENAMETOOLONG", errno: -36, description: "name too long"},
+	syscall.ELOOP:        {code: "ELOOP", errno: -40, description: "too many symbolic links encountered"},
+}
+
+// errorCodeOf the error, falling back to the fs errors for e.g. a fs.FS that does not report a syscall.Errno
+func errorCodeOf(err error) (errorCode, bool) {
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		if code, ok := errorCodes[errno]; ok {
+			return code, true
+		}
+	}
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return errorCodes[syscall.ENOENT], true
+	case errors.Is(err, fs.ErrPermission):
+		return errorCodes[syscall.EACCES], true
+	case errors.Is(err, fs.ErrExist):
+		return errorCodes[syscall.EEXIST], true
+	case errors.Is(err, fs.ErrInvalid):
+		return errorCodes[syscall.EINVAL], true
+	default:
+		return errorCode{}, false
+	}
+}
+
+// nodeError converts the error of the syscall op (e.g. open) on the path into a Node system error, i.e. an Error with
+// the code, errno, syscall and (if not "") path properties. An error without a known code, e.g. of a custom fs.FS, is
+// converted into a plain Error. Every fs entry point reports its errors through nodeError, such that Node code can
+// check e.g. err.code === 'ENOENT'.
+func nodeError(runtime *goja.Runtime, err error, op string, path string) *goja.Object {
+	code, ok := errorCodeOf(err)
+	if !ok {
+		return runtime.NewGoError(err)
+	}
+
+	message := fmt.Sprintf("%s: %s, %s", code.code, code.description, op)
+	if path != "" {
+		message += fmt.Sprintf(" '%s'", path)
+	}
+
+	object, newErr := runtime.New(runtime.Get("Error"), runtime.ToValue(message))
+	if newErr != nil {
+		return runtime.NewGoError(err)
+	}
+
+	_ = object.Set("code", code.code)
+	_ = object.Set("errno", code.errno)
+	_ = object.Set("syscall", op)
+	if path != "" {
+		_ = object.Set("path", path)
+	}
+
+	return object
+}
+
+// readFileError converts the error of FS.readFile, which fails reading rather than opening a directory. Like Node, the
+// EISDIR error of reading a directory does not name the path.
+func (f *FS) readFileError(err error, path string) *goja.Object {
+	if errors.Is(err, syscall.EISDIR) {
+		return nodeError(f.r, err, "read", "")
+	}
+
+	return nodeError(f.r, err, "open", path)
+}
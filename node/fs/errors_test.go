@@ -0,0 +1,82 @@
+package fs
+
+import (
+	"errors"
+	"io/fs"
+	"syscall"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeError(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		err     error
+		path    string
+		code    string
+		errno   int64
+		message string
+	}{
+		"errno": {
+			err:     &fs.PathError{Op: "open", Path: "/etc/shadow", Err: syscall.EACCES},
+			path:    "/etc/shadow",
+			code:    "EACCES",
+			errno:   -13,
+			message: "EACCES: permission denied, open '/etc/shadow'",
+		},
+		"fs error without errno": {
+			err:     &fs.PathError{Op: "open", Path: "openapi.yaml", Err: fs.ErrNotExist},
+			path:    "/wd/openapi.yaml",
+			code:    "ENOENT",
+			errno:   -2,
+			message: "ENOENT: no such file or directory, open '/wd/openapi.yaml'",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			runtime := goja.New()
+
+			// Act
+			object := nodeError(runtime, tt.err, "open", tt.path)
+
+			// Assert
+			assert.Equal(t, tt.code, object.Get("code").String())
+			assert.Equal(t, tt.errno, object.Get("errno").ToInteger())
+			assert.Equal(t, "open", object.Get("syscall").String())
+			assert.Equal(t, tt.path, object.Get("path").String())
+			assert.Equal(t, tt.message, object.Get("message").String())
+		})
+	}
+}
+
+func TestNodeError_WithoutPath(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+
+	// Act
+	object := nodeError(runtime, syscall.EISDIR, "read", "")
+
+	// Assert
+	assert.Equal(t, "EISDIR: illegal operation on a directory, read", object.Get("message").String())
+	assert.Equal(t, "EISDIR", object.Get("code").String())
+	assert.Nil(t, object.Get("path"))
+}
+
+func TestNodeError_UnknownError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+
+	// Act
+	object := nodeError(runtime, errors.New("boom"), "open", "openapi.yaml")
+
+	// Assert
+	assert.Equal(t, "boom", object.Get("message").String())
+	assert.Nil(t, object.Get("code"))
+}
@@ -1,12 +1,17 @@
 package fs
 
 import (
+	"errors"
 	"io"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"syscall"
 
 	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/sirupsen/logrus"
 )
@@ -37,79 +42,248 @@ type FS struct {
 	// from paths and if there is a match that file is used. In case of no match, the search continues on the
 	// system file system using os.ReadFile.
 	FileSystem fs.FS
+
+	// Guard if not nil is called before the path is accessed on the system file system, where op is the syscall
+	// (i.e. open, stat, scandir or realpath). If it returns an error, the path is not accessed and the error is
+	// reported instead, e.g. to confine the access to a directory.
+	Guard func(op string, path string) error
 }
 
-// Native asynchronous realpath. Not implemented
-func (f *FS) Native(_ goja.FunctionCall) goja.Value {
-	panic("not implemented")
+// RealPath resolves the path to an absolute path without symbolic links, i.e. realpath(path[, options], callback)
+func (f *FS) RealPath(call goja.FunctionCall) goja.Value {
+	return f.realPathCall(call, "lstat")
 }
 
-// LStat translated to os.Lstat but returning false for
-// isDirectory
-// isSymbolicLink
-// isBlockDevice
-// isCharacterDevice
-// isFIFO
-// isSocket
-// and always true for isFile. Note that this most likely breaks glob patterns with directories and
-// this method should be properly implemented
-// TODO fix LStat such that the returned values are correct for also directories / symlinks
-func (f *FS) LStat(call goja.FunctionCall) goja.Value {
+// Native resolves the path like RealPath, i.e. realpath.native(path[, options], callback)
+func (f *FS) Native(call goja.FunctionCall) goja.Value {
+	return f.realPathCall(call, "realpath")
+}
+
+// realPathCall calls back with the resolved path or the error of the syscall op
+func (f *FS) realPathCall(call goja.FunctionCall, op string) goja.Value {
 	filePath := call.Argument(0).String()
-	cb := call.Argument(1).Export().(func(goja.FunctionCall) goja.Value)
-	stats := f.r.NewObject()
+	cb := callback(call)
 
-	file, openFileErr := f.openFile(filePath)
-	if openFileErr != nil {
+	resolved, realPathErr := f.realPath(filePath)
+	if realPathErr != nil {
 		cb(goja.FunctionCall{
 			This:      call.This,
-			Arguments: []goja.Value{f.r.ToValue(openFileErr.Error()), goja.Null()},
+			Arguments: []goja.Value{nodeError(f.r, realPathErr, op, filePath), goja.Null()},
 		})
 
 		return goja.Undefined()
 	}
 
-	lstat, lstatErr := file.Stat()
-	if lstatErr != nil {
+	cb(goja.FunctionCall{
+		This:      call.This,
+		Arguments: []goja.Value{goja.Null(), f.encodePath(resolved, call.Argument(1))},
+	})
+
+	return goja.Undefined()
+}
+
+// RealPathSync returns the resolved path, i.e. realpathSync(path[, options]) and realpathSync.native(path[, options])
+func (f *FS) RealPathSync(call goja.FunctionCall) goja.Value {
+	filePath := call.Argument(0).String()
+	resolved, realPathErr := f.realPath(filePath)
+	if realPathErr != nil {
+		panic(nodeError(f.r, realPathErr, "realpath", filePath))
+	}
+
+	return f.encodePath(resolved, call.Argument(1))
+}
+
+// PromiseRealPath resolves the resolved path, i.e. promises.realpath(path[, options])
+func (f *FS) PromiseRealPath(call goja.FunctionCall) goja.Value {
+	promise, resolve, reject := f.r.NewPromise()
+	filePath := call.Argument(0).String()
+	resolved, realPathErr := f.realPath(filePath)
+	if realPathErr != nil {
+		_ = reject(nodeError(f.r, realPathErr, "realpath", filePath))
+
+		return f.r.ToValue(promise)
+	}
+
+	_ = resolve(f.encodePath(resolved, call.Argument(1)))
+
+	return f.r.ToValue(promise)
+}
+
+// encodePath as a string or as a Buffer if the encoding of the options is 'buffer'
+func (f *FS) encodePath(resolved string, options goja.Value) goja.Value {
+	if enc := encoding(options); isSet(enc) && enc.String() == "buffer" {
+		return buffer.WrapBytes(f.r, []byte(resolved))
+	}
+
+	return f.r.ToValue(resolved)
+}
+
+// LStat of the path without following a symbolic link, i.e. lstat(path[, options], callback)
+func (f *FS) LStat(call goja.FunctionCall) goja.Value {
+	return f.statCall(call, "lstat", os.Lstat)
+}
+
+// Stat of the path following symbolic links, i.e. stat(path[, options], callback)
+func (f *FS) Stat(call goja.FunctionCall) goja.Value {
+	return f.statCall(call, "stat", os.Stat)
+}
+
+// statCall calls back with the stats of the path or the error of the syscall op, using osStat for paths that are not
+// in the FileSystem
+func (f *FS) statCall(call goja.FunctionCall, op string, osStat func(name string) (fs.FileInfo, error)) goja.Value {
+	filePath := call.Argument(0).String()
+	cb := callback(call)
+
+	info, statErr := f.stat(filePath, osStat)
+	if statErr != nil {
 		cb(goja.FunctionCall{
 			This:      call.This,
-			Arguments: []goja.Value{f.r.ToValue(lstatErr.Error()), goja.Null()},
+			Arguments: []goja.Value{nodeError(f.r, statErr, op, filePath), goja.Null()},
 		})
 
 		return goja.Undefined()
 	}
 
-	_ = stats.Set("dev", lstat.Size())
-	_ = stats.Set("isDirectory", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(lstat.IsDir())
-	})
-	_ = stats.Set("isSymbolicLink", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(false)
-	})
-	_ = stats.Set("isBlockDevice", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(false)
-	})
-	_ = stats.Set("isCharacterDevice", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(false)
-	})
-	_ = stats.Set("isFIFO", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(false)
-	})
-	_ = stats.Set("isFile", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(true)
-	})
-	_ = stats.Set("isSocket", func(_ goja.FunctionCall) goja.Value {
-		return f.r.ToValue(false)
+	cb(goja.FunctionCall{
+		This:      call.This,
+		Arguments: []goja.Value{goja.Null(), stats(f.r, info)},
 	})
 
+	return goja.Undefined()
+}
+
+// ReadDir lists the names of the entries in the directory, i.e. readdir(path[, options], callback). If
+// options.withFileTypes is true, the entries are listed as Dirent objects instead.
+func (f *FS) ReadDir(call goja.FunctionCall) goja.Value {
+	directory := call.Argument(0).String()
+	cb := callback(call)
+
+	entries, readDirErr := f.readDir(directory)
+	if readDirErr != nil {
+		cb(goja.FunctionCall{
+			This:      call.This,
+			Arguments: []goja.Value{nodeError(f.r, readDirErr, "scandir", directory), goja.Null()},
+		})
+
+		return goja.Undefined()
+	}
+
 	cb(goja.FunctionCall{
 		This:      call.This,
-		Arguments: []goja.Value{goja.Null(), stats},
+		Arguments: []goja.Value{goja.Null(), f.list(directory, entries, call.Argument(1))},
 	})
 
 	return goja.Undefined()
 }
 
+// list of the names of the entries in the directory or, if options.withFileTypes is true, of Dirent objects
+func (f *FS) list(directory string, entries []fs.DirEntry, options goja.Value) goja.Value {
+	withFileTypes := false
+	if object, ok := options.(*goja.Object); ok {
+		withFileTypes = object.Get("withFileTypes") != nil && object.Get("withFileTypes").ToBoolean()
+	}
+
+	list := make([]any, len(entries))
+	for i, entry := range entries {
+		if withFileTypes {
+			list[i] = dirent(f.r, directory, entry)
+		} else {
+			list[i] = entry.Name()
+		}
+	}
+
+	return f.r.ToValue(list)
+}
+
+// ReadFileSync returns the content of the file, i.e. readFileSync(path[, options]), as a Buffer or as a string if an
+// encoding is supplied. Throws ENOENT if the file does not exist and EISDIR if it is a directory.
+func (f *FS) ReadFileSync(call goja.FunctionCall) goja.Value {
+	filePath := call.Argument(0).String()
+	b, readFileErr := f.readFile(filePath)
+	if readFileErr != nil {
+		panic(f.readFileError(readFileErr, filePath))
+	}
+
+	return buffer.EncodeBytes(f.r, b, encoding(call.Argument(1)))
+}
+
+// ExistsSync returns whether the path exists, i.e. existsSync(path)
+func (f *FS) ExistsSync(call goja.FunctionCall) goja.Value {
+	if !isSet(call.Argument(0)) {
+		return f.r.ToValue(false)
+	}
+
+	_, statErr := f.stat(call.Argument(0).String(), os.Stat)
+
+	return f.r.ToValue(statErr == nil)
+}
+
+// StatSync returns the stats of the path following symbolic links, i.e. statSync(path[, options])
+func (f *FS) StatSync(call goja.FunctionCall) goja.Value {
+	return f.statSync(call, "stat", os.Stat)
+}
+
+// LStatSync returns the stats of the path without following a symbolic link, i.e. lstatSync(path[, options])
+func (f *FS) LStatSync(call goja.FunctionCall) goja.Value {
+	return f.statSync(call, "lstat", os.Lstat)
+}
+
+// statSync returns the stats of the path, using osStat for paths that are not in the FileSystem. Throws ENOENT if the
+// path does not exist, unless options.throwIfNoEntry is false in which case undefined is returned.
+func (f *FS) statSync(call goja.FunctionCall, op string, osStat func(name string) (fs.FileInfo, error)) goja.Value {
+	filePath := call.Argument(0).String()
+	info, statErr := f.stat(filePath, osStat)
+	if statErr != nil {
+		if options, ok := call.Argument(1).(*goja.Object); ok && errors.Is(statErr, fs.ErrNotExist) {
+			if throwIfNoEntry := options.Get("throwIfNoEntry"); isSet(throwIfNoEntry) && !throwIfNoEntry.ToBoolean() {
+				return goja.Undefined()
+			}
+		}
+
+		panic(nodeError(f.r, statErr, op, filePath))
+	}
+
+	return stats(f.r, info)
+}
+
+// ReadDirSync returns the entries of the directory, i.e. readdirSync(path[, options]). Throws ENOENT if the directory
+// does not exist.
+func (f *FS) ReadDirSync(call goja.FunctionCall) goja.Value {
+	directory := call.Argument(0).String()
+	entries, readDirErr := f.readDir(directory)
+	if readDirErr != nil {
+		panic(nodeError(f.r, readDirErr, "scandir", directory))
+	}
+
+	return f.list(directory, entries, call.Argument(1))
+}
+
+// PromiseStat resolves the stats of the path following symbolic links, i.e. promises.stat(path[, options])
+func (f *FS) PromiseStat(call goja.FunctionCall) goja.Value {
+	return f.promiseStat(call, "stat", os.Stat)
+}
+
+// PromiseLStat resolves the stats of the path without following a symbolic link, i.e. promises.lstat(path[, options])
+func (f *FS) PromiseLStat(call goja.FunctionCall) goja.Value {
+	return f.promiseStat(call, "lstat", os.Lstat)
+}
+
+// promiseStat resolves the stats of the path, using osStat for paths that are not in the FileSystem
+func (f *FS) promiseStat(call goja.FunctionCall, op string, osStat func(name string) (fs.FileInfo, error)) goja.Value {
+	promise, resolve, reject := f.r.NewPromise()
+	filePath := call.Argument(0).String()
+	info, statErr := f.stat(filePath, osStat)
+	if statErr != nil {
+		_ = reject(nodeError(f.r, statErr, op, filePath))
+
+		return f.r.ToValue(promise)
+	}
+
+	_ = resolve(stats(f.r, info))
+
+	return f.r.ToValue(promise)
+}
+
 // ReadFile with callback
 func (f *FS) ReadFile(call goja.FunctionCall) goja.Value {
 	filePath := call.Argument(0).String()
@@ -120,21 +294,11 @@ func (f *FS) ReadFile(call goja.FunctionCall) goja.Value {
 		cb = call.Argument(2).Export().(func(goja.FunctionCall) goja.Value) //nolint:mnd // select second argument
 	}
 
-	file, openFileErr := f.openFile(filePath)
-	if openFileErr != nil {
-		cb(goja.FunctionCall{
-			This:      call.This,
-			Arguments: []goja.Value{f.r.ToValue(openFileErr.Error()), goja.Null()},
-		})
-
-		return goja.Undefined()
-	}
-
-	b, readAllErr := io.ReadAll(file)
-	if readAllErr != nil {
+	b, readFileErr := f.readFile(filePath)
+	if readFileErr != nil {
 		cb(goja.FunctionCall{
 			This:      call.This,
-			Arguments: []goja.Value{f.r.ToValue(readAllErr.Error()), goja.Null()},
+			Arguments: []goja.Value{f.readFileError(readFileErr, filePath), goja.Null()},
 		})
 
 		return goja.Undefined()
@@ -152,15 +316,9 @@ func (f *FS) ReadFile(call goja.FunctionCall) goja.Value {
 func (f *FS) PromiseReadFile(call goja.FunctionCall) goja.Value {
 	promise, resolve, reject := f.r.NewPromise()
 	filePath := call.Argument(0).String()
-	file, openFileErr := f.openFile(filePath)
-	if openFileErr != nil {
-		_ = reject(openFileErr.Error())
-		return f.r.ToValue(promise)
-	}
-
-	b, readAllErr := io.ReadAll(file)
-	if readAllErr != nil {
-		_ = reject(readAllErr.Error())
+	b, readFileErr := f.readFile(filePath)
+	if readFileErr != nil {
+		_ = reject(f.readFileError(readFileErr, filePath))
 		return f.r.ToValue(promise)
 	}
 
@@ -171,51 +329,250 @@ func (f *FS) PromiseReadFile(call goja.FunctionCall) goja.Value {
 
 // openFile either through embedded FileSystem or system fs
 func (f *FS) openFile(filePath string) (fs.File, error) {
-	if f.FileSystem != nil {
-		// prepend that the f.FileSystem is stored at the root of the f.CurrentWorkingDirectory
-		rel, relErr := filepath.Rel(f.CurrentWorkingDirectory, filePath)
-		if relErr != nil {
-			return nil, relErr
-		}
-
-		file, openErr := f.FileSystem.Open(rel)
-		if openErr != nil {
-			logrus.Warnf("fs.Open: failed to open file from embedded FileSystem: %v\n", openErr)
-		} else {
+	if name, ok := f.fileSystemPath(filePath); ok {
+		file, openErr := f.FileSystem.Open(name)
+		switch {
+		case openErr == nil:
 			return file, nil
+		case errors.Is(openErr, fs.ErrNotExist):
+			logrus.Debugf("fs.Open: file not found in embedded FileSystem: %v\n", openErr)
+		default:
+			logrus.Warnf("fs.Open: failed to open file from embedded FileSystem: %v\n", openErr)
 		}
 	}
 
+	if guardErr := f.guard("open", filePath); guardErr != nil {
+		return nil, guardErr
+	}
+
 	return os.Open(filePath)
 }
 
+// readFile reads the content of the file either through embedded FileSystem or system fs, failing with EISDIR if the
+// path is a directory
+func (f *FS) readFile(filePath string) ([]byte, error) {
+	file, openFileErr := f.openFile(filePath)
+	if openFileErr != nil {
+		return nil, openFileErr
+	}
+	defer file.Close()
+
+	info, statErr := file.Stat()
+	if statErr != nil {
+		return nil, statErr
+	}
+
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: filePath, Err: syscall.EISDIR}
+	}
+
+	return io.ReadAll(file)
+}
+
+// realPath of the path. A path in the embedded FileSystem, which has no notion of symbolic links, resolves to itself
+// in the CurrentWorkingDirectory. Any other path is resolved on the system fs by following its symbolic links.
+func (f *FS) realPath(filePath string) (string, error) {
+	if name, ok := f.fileSystemPath(filePath); ok {
+		if _, statErr := fs.Stat(f.FileSystem, name); statErr == nil {
+			return filepath.Join(f.CurrentWorkingDirectory, filepath.FromSlash(name)), nil
+		}
+	}
+
+	if guardErr := f.guard("realpath", filePath); guardErr != nil {
+		return "", guardErr
+	}
+
+	resolved, evalErr := filepath.EvalSymlinks(filePath)
+	if evalErr != nil {
+		return "", evalErr
+	}
+
+	return filepath.Abs(resolved)
+}
+
+// stat the path either through embedded FileSystem or using osStat on the system fs. The FileSystem has no notion of
+// symbolic links, so its files are always stat'ed as is.
+func (f *FS) stat(filePath string, osStat func(name string) (fs.FileInfo, error)) (fs.FileInfo, error) {
+	if name, ok := f.fileSystemPath(filePath); ok {
+		info, statErr := fs.Stat(f.FileSystem, name)
+		switch {
+		case statErr == nil:
+			return info, nil
+		case errors.Is(statErr, fs.ErrNotExist):
+			logrus.Debugf("fs.Stat: file not found in embedded FileSystem: %v\n", statErr)
+		default:
+			logrus.Warnf("fs.Stat: failed to stat file from embedded FileSystem: %v\n", statErr)
+		}
+	}
+
+	if guardErr := f.guard("stat", filePath); guardErr != nil {
+		return nil, guardErr
+	}
+
+	return osStat(filePath)
+}
+
+// readDir lists the entries of the directory in both the embedded FileSystem and the system fs, sorted by name. If
+// an entry is in both, the entry of the FileSystem is listed. An error is only returned if neither has the directory.
+func (f *FS) readDir(directory string) ([]fs.DirEntry, error) {
+	entries := map[string]fs.DirEntry{}
+	found := false
+	if name, ok := f.fileSystemPath(directory); ok {
+		fsEntries, readDirErr := fs.ReadDir(f.FileSystem, name)
+		switch {
+		case readDirErr == nil:
+			found = true
+			for _, entry := range fsEntries {
+				entries[entry.Name()] = entry
+			}
+		case errors.Is(readDirErr, fs.ErrNotExist):
+			logrus.Debugf("fs.ReadDir: directory not found in embedded FileSystem: %v\n", readDirErr)
+		default:
+			logrus.Warnf("fs.ReadDir: failed to read directory from embedded FileSystem: %v\n", readDirErr)
+		}
+	}
+
+	var osEntries []fs.DirEntry
+	readDirErr := f.guard("scandir", directory)
+	if readDirErr == nil {
+		osEntries, readDirErr = os.ReadDir(directory)
+	}
+
+	if readDirErr != nil && !found {
+		return nil, readDirErr
+	}
+
+	for _, entry := range osEntries {
+		if _, ok := entries[entry.Name()]; !ok {
+			entries[entry.Name()] = entry
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(entries))
+	list := make([]fs.DirEntry, len(names))
+	for i, name := range names {
+		list[i] = entries[name]
+	}
+
+	return list, nil
+}
+
+// guard the access of the path on the system file system
+func (f *FS) guard(op string, path string) error {
+	if f.Guard == nil {
+		return nil
+	}
+
+	return f.Guard(op, path)
+}
+
+// fileSystemPath of the path in the embedded FileSystem, which is stored at the root of the CurrentWorkingDirectory.
+// Returns false if there is no FileSystem or the path is not inside the CurrentWorkingDirectory.
+func (f *FS) fileSystemPath(filePath string) (string, bool) {
+	if f.FileSystem == nil {
+		return "", false
+	}
+
+	rel, relErr := filepath.Rel(f.CurrentWorkingDirectory, filePath)
+	if relErr != nil {
+		return "", false
+	}
+
+	rel = filepath.ToSlash(rel)
+
+	return rel, fs.ValidPath(rel)
+}
+
+// encoding of the options, which is either the encoding or an object with an encoding property
+func encoding(options goja.Value) goja.Value {
+	if object, ok := options.(*goja.Object); ok {
+		if v := object.Get("encoding"); isSet(v) {
+			return v
+		}
+
+		return goja.Undefined()
+	}
+
+	if isSet(options) {
+		return options
+	}
+
+	return goja.Undefined()
+}
+
+// isSet if the value is neither undefined nor null
+func isSet(value goja.Value) bool {
+	return value != nil && !goja.IsUndefined(value) && !goja.IsNull(value)
+}
+
+// callback of a function call, which is the last argument, e.g. of readdir(path[, options], callback)
+func callback(call goja.FunctionCall) func(goja.FunctionCall) goja.Value {
+	return call.Argument(len(call.Arguments) - 1).Export().(func(goja.FunctionCall) goja.Value)
+}
+
 // Require fs package
 func Require(s *FS) func(runtime *goja.Runtime, module *goja.Object) {
 	return func(runtime *goja.Runtime, module *goja.Object) {
 		runtime.ToValue(s)
-		realpath := runtime.NewObject()
+		realpath := runtime.ToValue(s.RealPath).ToObject(runtime)
 		_ = realpath.Set("native", s.Native)
 
+		realpathSync := runtime.ToValue(s.RealPathSync).ToObject(runtime)
+		_ = realpathSync.Set("native", s.RealPathSync)
+
 		promises := runtime.NewObject()
 		_ = promises.Set("readFile", s.PromiseReadFile)
+		_ = promises.Set("stat", s.PromiseStat)
+		_ = promises.Set("lstat", s.PromiseLStat)
+		_ = promises.Set("realpath", s.PromiseRealPath)
 
 		exports := module.Get("exports").(*goja.Object)
 		_ = exports.Set("realpath", realpath)
+		_ = exports.Set("realpathSync", realpathSync)
 		_ = exports.Set("promises", promises)
 		_ = exports.Set("lstat", s.LStat)
+		_ = exports.Set("stat", s.Stat)
+		_ = exports.Set("readdir", s.ReadDir)
+		_ = exports.Set("readFileSync", s.ReadFileSync)
+		_ = exports.Set("existsSync", s.ExistsSync)
+		_ = exports.Set("statSync", s.StatSync)
+		_ = exports.Set("lstatSync", s.LStatSync)
+		_ = exports.Set("readdirSync", s.ReadDirSync)
 		_ = exports.Set("readFile", s.ReadFile)
 	}
 }
 
 // Enable fs package
-func Enable(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule, currentWorkingDirectory string, fileSystem fs.FS) {
+func Enable(runtime *goja.Runtime, registry *require.Registry, requireModule *require.RequireModule, currentWorkingDirectory string, fileSystem fs.FS) {
+	EnableGuard(runtime, registry, requireModule, currentWorkingDirectory, fileSystem, nil)
+}
+
+// EnableGuard enables the fs package calling the guard before a path is accessed on the system file system, see
+// FS.Guard
+func EnableGuard(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule, currentWorkingDirectory string, fileSystem fs.FS, guard func(op string, path string) error) {
 	s := &FS{
 		r:                       runtime,
 		CurrentWorkingDirectory: currentWorkingDirectory,
 		FileSystem:              fileSystem,
+		Guard:                   guard,
 	}
 
 	registry.RegisterNativeModule("node:"+ModuleName, Require(s))
 	registry.RegisterNativeModule(ModuleName, Require(s))
+	_ = runtime.GlobalObject().DefineDataPropertySymbol(fsSymbol, runtime.ToValue(s), goja.FLAG_FALSE, goja.FLAG_FALSE, goja.FLAG_FALSE)
 	_ = runtime.Set("fs", require.Require(runtime, ModuleName))
 }
+
+// fsSymbol of the global property holding the FS of a runtime
+var fsSymbol = goja.NewSymbol("fs.FS")
+
+// Of the runtime, i.e. the FS the fs package was enabled with or nil if it is not enabled
+func Of(runtime *goja.Runtime) *FS {
+	if v := runtime.GlobalObject().GetSymbol(fsSymbol); v != nil {
+		if s, ok := v.Export().(*FS); ok {
+			return s
+		}
+	}
+
+	return nil
+}
@@ -2,8 +2,11 @@ package fs
 
 import (
 	"embed"
+	iofs "io/fs"
 	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/dop251/goja"
 	noderequire "github.com/dop251/goja_nodejs/require"
@@ -14,20 +17,60 @@ import (
 //go:embed testdata
 var testdata embed.FS
 
-func TestFS_Native_NotImplemented(t *testing.T) {
+func TestFS_RealPath_FollowsSymbolicLinks(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	fs := &FS{
-		r: goja.New(),
-	}
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "rulesets"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rulesets", "company.yaml"), []byte("rules: {}"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "service"), 0o755))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "rulesets"), filepath.Join(dir, "service", "rulesets")))
+
+	expected, err := filepath.EvalSymlinks(filepath.Join(dir, "rulesets", "company.yaml"))
+	require.NoError(t, err)
+
+	fs := &FS{}
+	script := `const link = '` + filepath.ToSlash(filepath.Join(dir, "service", "rulesets", "company.yaml")) + `';
+	const paths = [fs.realpathSync(link), fs.realpathSync.native(link)];
+	fs.realpath(link, (err, resolved) => paths.push(resolved));
+	fs.realpath.native(link, (err, resolved) => paths.push(resolved));
+	fs.promises.realpath(link).then((resolved) => paths.push(resolved));
+	paths;`
 
 	// Act
-	f := func() {
-		fs.Native(goja.FunctionCall{})
-	}
+	res := runFS(t, fs, script)
+
+	// Assert
+	assert.Equal(t, []any{expected, expected, expected, expected, expected}, res)
+}
+
+func TestFS_RealPath_FileSystemPassThrough(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{CurrentWorkingDirectory: "/wd", FileSystem: fstest.MapFS{"rulesets/company.yaml": {}}}
+
+	// Act
+	res := runFS(t, fs, `fs.realpathSync('/wd/rulesets/../rulesets/company.yaml')`)
+
+	// Assert
+	assert.Equal(t, filepath.FromSlash("/wd/rulesets/company.yaml"), res)
+}
+
+func TestFS_RealPath_NotExists(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{}
+	script := `const errors = [];
+	fs.realpath('./doesnotexist', (err) => errors.push(err.code + ':' + err.syscall));
+	fs.realpath.native('./doesnotexist', (err) => errors.push(err.code + ':' + err.syscall));
+	try { fs.realpathSync('./doesnotexist'); } catch (err) { errors.push(err.code + ':' + err.syscall); }
+	errors;`
+
+	// Act
+	res := runFS(t, fs, script)
 
 	// Assert
-	assert.Panics(t, f)
+	assert.Equal(t, []any{"ENOENT:lstat", "ENOENT:realpath", "ENOENT:realpath"}, res)
 }
 
 func TestFS_LStat_CanReadFile(t *testing.T) {
@@ -57,7 +100,7 @@ func TestFS_LStat_CanReadFile(t *testing.T) {
 	assert.Equal(t, goja.Undefined(), res)
 	assert.Equal(t, goja.Null(), err)
 	actual := value.Export().(map[string]any)
-	assert.Equal(t, expected.Size(), actual["dev"])
+	assert.Equal(t, expected.Size(), actual["size"])
 	assert.Equal(t, vm.ToValue(false), actual["isDirectory"].(func(call goja.FunctionCall) goja.Value)(goja.FunctionCall{}))
 	assert.Equal(t, vm.ToValue(false), actual["isSymbolicLink"].(func(call goja.FunctionCall) goja.Value)(goja.FunctionCall{}))
 	assert.Equal(t, vm.ToValue(false), actual["isBlockDevice"].(func(call goja.FunctionCall) goja.Value)(goja.FunctionCall{}))
@@ -89,7 +132,8 @@ func TestFS_LStat_FileNotExists(t *testing.T) {
 
 	// Assert
 	assert.Equal(t, goja.Undefined(), res)
-	assert.Contains(t, err.Export(), "no such file or directory")
+	assert.Contains(t, err.ToObject(vm).Get("message").String(), "no such file or directory")
+	assert.Equal(t, "ENOENT", err.ToObject(vm).Get("code").String())
 	assert.Equal(t, goja.Null(), value)
 }
 
@@ -152,8 +196,16 @@ func TestRequire(t *testing.T) {
 
 	// Assert
 	assert.NotNil(t, exports.Get("realpath"))
+	assert.NotNil(t, exports.Get("realpathSync"))
 	assert.NotNil(t, exports.Get("promises"))
 	assert.NotNil(t, exports.Get("lstat"))
+	assert.NotNil(t, exports.Get("stat"))
+	assert.NotNil(t, exports.Get("readdir"))
+	assert.NotNil(t, exports.Get("readFileSync"))
+	assert.NotNil(t, exports.Get("existsSync"))
+	assert.NotNil(t, exports.Get("statSync"))
+	assert.NotNil(t, exports.Get("lstatSync"))
+	assert.NotNil(t, exports.Get("readdirSync"))
 	assert.NotNil(t, exports.Get("readFile"))
 }
 
@@ -174,3 +226,332 @@ func TestEnable(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, res)
 }
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	requireModule := registry.Enable(runtime)
+	fileSystem := fstest.MapFS{}
+
+	// Act
+	before := Of(runtime)
+	Enable(runtime, registry, requireModule, "/wd", fileSystem)
+	after := Of(runtime)
+
+	// Assert
+	assert.Nil(t, before)
+	require.NotNil(t, after)
+	assert.Equal(t, "/wd", after.CurrentWorkingDirectory)
+	assert.Equal(t, fileSystem, after.FileSystem)
+}
+
+func TestFS_LStat_ReportsDirectory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	vm := goja.New()
+	fs := &FS{r: vm}
+
+	var value goja.Value
+	callback := func(call goja.FunctionCall) goja.Value {
+		value = call.Argument(1)
+		return goja.Undefined()
+	}
+
+	// Act
+	fs.LStat(goja.FunctionCall{Arguments: []goja.Value{vm.ToValue("./testdata"), vm.ToValue(callback)}})
+
+	// Assert
+	stats := value.ToObject(vm)
+	isDirectory, _ := goja.AssertFunction(stats.Get("isDirectory"))
+	isFile, _ := goja.AssertFunction(stats.Get("isFile"))
+	directory, _ := isDirectory(stats)
+	file, _ := isFile(stats)
+	assert.True(t, directory.ToBoolean())
+	assert.False(t, file.ToBoolean())
+}
+
+func TestFS_LStat_DoesNotFollowSymbolicLink(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte("openapi: 3.1.0"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "link.yaml")))
+
+	vm := goja.New()
+	module := vm.NewObject()
+	_ = module.Set("exports", vm.NewObject())
+	Require(&FS{r: vm})(vm, module)
+	_ = vm.Set("fs", module.Get("exports"))
+	_ = vm.Set("link", filepath.Join(dir, "link.yaml"))
+
+	// Act
+	res, err := vm.RunString(`const types = [];
+	fs.lstat(link, (err, stats) => types.push(stats.isSymbolicLink(), stats.isFile()));
+	fs.stat(link, (err, stats) => types.push(stats.isSymbolicLink(), stats.isFile(), stats.size));
+	types;`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{true, false, false, true, int64(14)}, res.Export())
+}
+
+func TestFS_Stat_FileSystemDirectory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	vm := goja.New()
+	fs := &FS{r: vm, CurrentWorkingDirectory: "/wd", FileSystem: fstest.MapFS{"api/v1/openapi.yaml": {Data: []byte("openapi: 3.1.0")}}}
+
+	var err goja.Value
+	var value goja.Value
+	callback := func(call goja.FunctionCall) goja.Value {
+		err = call.Argument(0)
+		value = call.Argument(1)
+		return goja.Undefined()
+	}
+
+	// Act
+	fs.Stat(goja.FunctionCall{Arguments: []goja.Value{vm.ToValue("/wd/api/v1"), vm.ToValue(callback)}})
+
+	// Assert
+	assert.Equal(t, goja.Null(), err)
+	isDirectory, _ := goja.AssertFunction(value.ToObject(vm).Get("isDirectory"))
+	directory, _ := isDirectory(value)
+	assert.True(t, directory.ToBoolean())
+}
+
+func TestFS_ReadDir_MergesFileSystemAndOS(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "os.yaml"), []byte("openapi: 3.1.0"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "both.yaml"), []byte("openapi: 3.1.0"), 0o600))
+
+	vm := goja.New()
+	fs := &FS{r: vm, CurrentWorkingDirectory: dir, FileSystem: fstest.MapFS{
+		"both.yaml":        {Data: []byte("openapi: 3.1.0")},
+		"api/openapi.yaml": {Data: []byte("openapi: 3.1.0")},
+	}}
+
+	var names goja.Value
+	var entries goja.Value
+	callback := func(value *goja.Value) func(call goja.FunctionCall) goja.Value {
+		return func(call goja.FunctionCall) goja.Value {
+			*value = call.Argument(1)
+			return goja.Undefined()
+		}
+	}
+
+	// Act
+	fs.ReadDir(goja.FunctionCall{Arguments: []goja.Value{vm.ToValue(dir), vm.ToValue(callback(&names))}})
+	fs.ReadDir(goja.FunctionCall{Arguments: []goja.Value{
+		vm.ToValue(dir), vm.ToValue(map[string]any{"withFileTypes": true}), vm.ToValue(callback(&entries)),
+	}})
+
+	// Assert
+	assert.Equal(t, []any{"api", "both.yaml", "os.yaml"}, names.Export())
+	_ = vm.Set("entries", entries)
+	types, err := vm.RunString(`entries.map((entry) => entry.name + ':' + entry.isDirectory() + ':' + entry.isFile())`)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"api:true:false", "both.yaml:false:true", "os.yaml:false:true"}, types.Export())
+}
+
+func TestFS_ReadDir_DirectoryNotExists(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	vm := goja.New()
+	fs := &FS{r: vm}
+
+	var err goja.Value
+	var value goja.Value
+	callback := func(call goja.FunctionCall) goja.Value {
+		err = call.Argument(0)
+		value = call.Argument(1)
+		return goja.Undefined()
+	}
+
+	// Act
+	fs.ReadDir(goja.FunctionCall{Arguments: []goja.Value{vm.ToValue("./doesnotexist"), vm.ToValue(callback)}})
+
+	// Assert
+	assert.Contains(t, err.ToObject(vm).Get("message").String(), "no such file or directory")
+	assert.Equal(t, "ENOENT", err.ToObject(vm).Get("code").String())
+	assert.Equal(t, goja.Null(), value)
+}
+
+// runFS runs the script with the fs module of the FS as the fs global, returning the exported result
+func runFS(t *testing.T, fs *FS, script string) any {
+	t.Helper()
+
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	registry.Enable(runtime)
+	fs.r = runtime
+	module := runtime.NewObject()
+	_ = module.Set("exports", runtime.NewObject())
+	Require(fs)(runtime, module)
+	_ = runtime.Set("fs", module.Get("exports"))
+
+	res, err := runtime.RunString(script)
+	require.NoError(t, err)
+
+	return res.Export()
+}
+
+func TestFS_ReadFileSync(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{FileSystem: testdata}
+
+	// Act
+	res := runFS(t, fs, `[
+		fs.readFileSync('testdata/file.yaml', 'utf8'),
+		fs.readFileSync('testdata/file.yaml', { encoding: 'utf8' }),
+		fs.readFileSync('testdata/file.yaml').toString(),
+		typeof fs.readFileSync('testdata/file.yaml'),
+	]`)
+
+	// Assert
+	assert.Equal(t, []any{"key: value", "key: value", "key: value", "object"}, res)
+}
+
+func TestFS_ReadFileSync_ThrowsNodeErrors(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{}
+	script := `const errors = [];
+	for (const path of ['./doesnotexist', './testdata']) {
+		try {
+			fs.readFileSync(path);
+		} catch (err) {
+			errors.push({ message: err.message, code: err.code, errno: err.errno, syscall: err.syscall, path: err.path, error: err instanceof Error });
+		}
+	}
+	errors;`
+
+	// Act
+	res := runFS(t, fs, script)
+
+	// Assert
+	assert.Equal(t, []any{
+		map[string]any{
+			"message": "ENOENT: no such file or directory, open './doesnotexist'",
+			"code":    "ENOENT", "errno": int64(-2), "syscall": "open", "path": "./doesnotexist", "error": true,
+		},
+		map[string]any{
+			"message": "EISDIR: illegal operation on a directory, read",
+			"code":    "EISDIR", "errno": int64(-21), "syscall": "read", "path": nil, "error": true,
+		},
+	}, res)
+}
+
+func TestFS_ExistsSync(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{CurrentWorkingDirectory: "/wd", FileSystem: fstest.MapFS{"openapi.yaml": {}}}
+
+	// Act
+	res := runFS(t, fs, `[fs.existsSync('/wd/openapi.yaml'), fs.existsSync('./testdata'), fs.existsSync('./doesnotexist'), fs.existsSync()]`)
+
+	// Assert
+	assert.Equal(t, []any{true, true, false, false}, res)
+}
+
+func TestFS_StatSync(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{}
+	script := `let code;
+	try { fs.statSync('./doesnotexist'); } catch (err) { code = err.code; }
+	[
+		fs.statSync('./testdata').isDirectory(),
+		fs.lstatSync('./testdata/file.yaml').isFile(),
+		fs.statSync('./doesnotexist', { throwIfNoEntry: false }),
+		code,
+	]`
+
+	// Act
+	res := runFS(t, fs, script)
+
+	// Assert
+	assert.Equal(t, []any{true, true, nil, "ENOENT"}, res)
+}
+
+func TestFS_ReadDirSync(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{}
+	script := `let code;
+	try { fs.readdirSync('./doesnotexist'); } catch (err) { code = err.code + ':' + err.syscall; }
+	[fs.readdirSync('./testdata'), fs.readdirSync('./testdata', { withFileTypes: true })[0].isFile(), code]`
+
+	// Act
+	res := runFS(t, fs, script)
+
+	// Assert
+	assert.Equal(t, []any{[]any{"file.yaml"}, true, "ENOENT:scandir"}, res)
+}
+
+func TestFS_PromiseStat(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{}
+
+	// Act
+	resolved := runFS(t, fs, `fs.promises.stat('./testdata/file.yaml')`).(*goja.Promise)
+	rejected := runFS(t, fs, `fs.promises.lstat('./doesnotexist')`).(*goja.Promise)
+
+	// Assert
+	assert.Equal(t, goja.PromiseStateFulfilled, resolved.State())
+	assert.Equal(t, int64(10), resolved.Result().ToObject(nil).Get("size").ToInteger())
+	assert.Equal(t, goja.PromiseStateRejected, rejected.State())
+	assert.Equal(t, "ENOENT", rejected.Result().ToObject(nil).Get("code").String())
+}
+
+func TestFS_ReadFile_PassesNodeErrors(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	fs := &FS{}
+	script := `const errors = [];
+	fs.readFile('./doesnotexist', (err) => errors.push(err.code, err.syscall, err.path, err instanceof Error));
+	fs.readFile('./testdata', 'utf8', (err) => errors.push(err.code, err.syscall));
+	fs.promises.readFile('./doesnotexist').catch((err) => errors.push(err.code, err.message));
+	errors;`
+
+	// Act
+	res := runFS(t, fs, script)
+
+	// Assert
+	assert.Equal(t, []any{
+		"ENOENT", "open", "./doesnotexist", true,
+		"EISDIR", "read",
+		"ENOENT", "ENOENT: no such file or directory, open './doesnotexist'",
+	}, res)
+}
+
+func TestFS_Guard_DeniesSystemFileSystemAccess(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var guarded []string
+	fs := &FS{
+		CurrentWorkingDirectory: "/wd",
+		FileSystem:              fstest.MapFS{"openapi.yaml": {Data: []byte("openapi: 3.1.0")}},
+		Guard: func(op string, path string) error {
+			guarded = append(guarded, op+" "+path)
+
+			return &iofs.PathError{Op: op, Path: path, Err: iofs.ErrPermission}
+		},
+	}
+	script := `const results = [fs.readFileSync('/wd/openapi.yaml', 'utf8'), fs.readdirSync('/wd'), fs.existsSync('/etc/passwd')];
+	try { fs.readFileSync('/etc/passwd'); } catch (err) { results.push(err.code); }
+	try { fs.realpathSync('/etc/passwd'); } catch (err) { results.push(err.code); }
+	results;`
+
+	// Act
+	res := runFS(t, fs, script)
+
+	// Assert
+	assert.Equal(t, []any{"openapi: 3.1.0", []any{"openapi.yaml"}, false, "EACCES", "EACCES"}, res)
+	assert.Equal(t, []string{"scandir /wd", "stat /etc/passwd", "open /etc/passwd", "realpath /etc/passwd"}, guarded)
+}
@@ -0,0 +1,108 @@
+package fs
+
+import (
+	"io/fs"
+
+	"github.com/dop251/goja"
+)
+
+// file type bits of the Node stats.mode, see https://nodejs.org/api/fs.html#file-type-constants
+const (
+	sIFREG  = 0o100000
+	sIFDIR  = 0o040000
+	sIFLNK  = 0o120000
+	sIFIFO  = 0o010000
+	sIFSOCK = 0o140000
+	sIFCHR  = 0o020000
+	sIFBLK  = 0o060000
+)
+
+// blockSize of the stats.blksize and the unit of stats.blocks
+const blockSize = 4096
+
+// mode of the fs.FileMode as the Node stats.mode, i.e. the file type bits and the permission bits
+func mode(m fs.FileMode) int64 {
+	bits := int64(m.Perm())
+	switch {
+	case m&fs.ModeSymlink != 0:
+		bits |= sIFLNK
+	case m.IsDir():
+		bits |= sIFDIR
+	case m&fs.ModeNamedPipe != 0:
+		bits |= sIFIFO
+	case m&fs.ModeSocket != 0:
+		bits |= sIFSOCK
+	case m&fs.ModeCharDevice != 0:
+		bits |= sIFCHR
+	case m&fs.ModeDevice != 0:
+		bits |= sIFBLK
+	default:
+		bits |= sIFREG
+	}
+
+	return bits
+}
+
+// setTypes sets the is<Type> methods of a Stats or Dirent object for the fs.FileMode
+func setTypes(runtime *goja.Runtime, object *goja.Object, m fs.FileMode) {
+	types := map[string]bool{
+		"isFile":            m.IsRegular(),
+		"isDirectory":       m.IsDir(),
+		"isSymbolicLink":    m&fs.ModeSymlink != 0,
+		"isBlockDevice":     m&fs.ModeDevice != 0 && m&fs.ModeCharDevice == 0,
+		"isCharacterDevice": m&fs.ModeCharDevice != 0,
+		"isFIFO":            m&fs.ModeNamedPipe != 0,
+		"isSocket":          m&fs.ModeSocket != 0,
+	}
+
+	for name, is := range types {
+		_ = object.Set(name, func(_ goja.FunctionCall) goja.Value {
+			return runtime.ToValue(is)
+		})
+	}
+}
+
+// stats object of the fs.FileInfo, see https://nodejs.org/api/fs.html#class-fsstats. The access, change and birth
+// times are not available through fs.FileInfo and are reported as the modification time.
+func stats(runtime *goja.Runtime, info fs.FileInfo) *goja.Object {
+	object := runtime.NewObject()
+	setTypes(runtime, object, info.Mode())
+
+	mtimeMs := info.ModTime().UnixMilli()
+	date := func() goja.Value {
+		d, err := runtime.New(runtime.Get("Date"), runtime.ToValue(mtimeMs))
+		if err != nil {
+			return goja.Undefined()
+		}
+
+		return d
+	}
+
+	_ = object.Set("dev", 0)
+	_ = object.Set("ino", 0)
+	_ = object.Set("mode", mode(info.Mode()))
+	_ = object.Set("nlink", 1)
+	_ = object.Set("uid", 0)
+	_ = object.Set("gid", 0)
+	_ = object.Set("rdev", 0)
+	_ = object.Set("size", info.Size())
+	_ = object.Set("blksize", blockSize)
+	_ = object.Set("blocks", (info.Size()+blockSize-1)/blockSize)
+	for _, name := range []string{"atime", "mtime", "ctime", "birthtime"} {
+		_ = object.Set(name+"Ms", mtimeMs)
+		_ = object.Set(name, date())
+	}
+
+	return object
+}
+
+// dirent object of the fs.DirEntry in the directory, see https://nodejs.org/api/fs.html#class-fsdirent
+func dirent(runtime *goja.Runtime, directory string, entry fs.DirEntry) *goja.Object {
+	object := runtime.NewObject()
+	setTypes(runtime, object, entry.Type())
+	_ = object.Set("name", entry.Name())
+	_ = object.Set("parentPath", directory)
+	_ = object.Set("path", directory)
+
+	return object
+}
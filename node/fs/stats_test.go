@@ -0,0 +1,73 @@
+package fs
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMode(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		mode     fs.FileMode
+		expected int64
+	}{
+		"regular file":     {mode: 0o644, expected: 0o100644},
+		"directory":        {mode: fs.ModeDir | 0o755, expected: 0o40755},
+		"symbolic link":    {mode: fs.ModeSymlink | 0o777, expected: 0o120777},
+		"named pipe":       {mode: fs.ModeNamedPipe | 0o600, expected: 0o10600},
+		"socket":           {mode: fs.ModeSocket | 0o755, expected: 0o140755},
+		"character device": {mode: fs.ModeDevice | fs.ModeCharDevice | 0o666, expected: 0o20666},
+		"block device":     {mode: fs.ModeDevice | 0o660, expected: 0o60660},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual := mode(tt.mode)
+
+			// Assert
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	info, err := os.Stat("./testdata/file.yaml")
+	require.NoError(t, err)
+
+	// Act
+	object := stats(runtime, info)
+
+	// Assert
+	_ = runtime.Set("stats", object)
+	res, runErr := runtime.RunString(`[stats.isFile(), stats.isDirectory(), stats.size, stats.mtimeMs === stats.mtime.getTime()]`)
+	require.NoError(t, runErr)
+	assert.Equal(t, []any{true, false, info.Size(), true}, res.Export())
+}
+
+func TestDirent(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	entries, err := fs.ReadDir(fstest.MapFS{"api/openapi.yaml": {}}, ".")
+	require.NoError(t, err)
+
+	// Act
+	object := dirent(runtime, "/wd", entries[0])
+
+	// Assert
+	_ = runtime.Set("dirent", object)
+	res, runErr := runtime.RunString(`[dirent.name, dirent.parentPath, dirent.isDirectory(), dirent.isFile()]`)
+	require.NoError(t, runErr)
+	assert.Equal(t, []any{"api", "/wd", true, false}, res.Export())
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
+)
+
+// ErrUnsupportedScheme when fetching a resource that is not http or https
+var ErrUnsupportedScheme = errors.New("unsupported scheme")
+
+// Fetch implements the fetch global, see https://developer.mozilla.org/en-US/docs/Web/API/Window/fetch
+type Fetch struct {
+	h *HTTP
+}
+
+// Fetch the resource, i.e. fetch(resource[, options]), returning a promise of a Response
+func (f *Fetch) Fetch(call goja.FunctionCall) goja.Value {
+	r := f.h.r
+	promise, resolve, reject := r.NewPromise()
+
+	req, err := f.request(call.Argument(0), call.Argument(1))
+	if err != nil {
+		_ = reject(r.NewTypeError("fetch failed: %s", err))
+
+		return r.ToValue(promise)
+	}
+
+	f.h.do(req, func(resp *http.Response, body []byte, err error) {
+		if err != nil {
+			_ = reject(r.NewTypeError("fetch failed: %s", err))
+
+			return
+		}
+
+		_ = resolve(f.response(resp, body))
+	})
+
+	return r.ToValue(promise)
+}
+
+// request of the resource (a string, URL or Request like object with a url) and options
+func (f *Fetch) request(resource goja.Value, options goja.Value) (*http.Request, error) {
+	r := f.h.r
+	rawURL := resource.String()
+	if object, ok := resource.(*goja.Object); ok {
+		if u := object.Get("url"); isSet(u) {
+			rawURL = u.String()
+		} else if href := object.Get("href"); isSet(href) {
+			rawURL = href.String()
+		}
+	}
+
+	method := http.MethodGet
+	header := http.Header{}
+	var body []byte
+	if isSet(options) {
+		object := options.ToObject(r)
+		if v := object.Get("method"); isSet(v) {
+			method = strings.ToUpper(v.String())
+		}
+
+		if v := object.Get("headers"); isSet(v) {
+			setHeaders(r, header, v)
+		}
+
+		if v := object.Get("body"); isSet(v) {
+			body = buffer.DecodeBytes(r, v, goja.Undefined())
+		}
+	}
+
+	req, err := http.NewRequestWithContext(f.h.context(), method, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("'%s': %w", req.URL.Scheme, ErrUnsupportedScheme)
+	}
+	req.Header = header
+
+	return req, nil
+}
+
+// response object of the http.Response with the read body, see https://developer.mozilla.org/en-US/docs/Web/API/Response
+func (f *Fetch) response(resp *http.Response, body []byte) *goja.Object {
+	r := f.h.r
+	response := r.NewObject()
+	_ = response.Set("ok", resp.StatusCode >= 200 && resp.StatusCode < 300)
+	_ = response.Set("status", resp.StatusCode)
+	_ = response.Set("statusText", http.StatusText(resp.StatusCode))
+	_ = response.Set("url", resp.Request.URL.String())
+	_ = response.Set("redirected", resp.Request.Response != nil)
+	_ = response.Set("headers", f.headers(resp.Header))
+	_ = response.Set("bodyUsed", false)
+
+	// consume the body once, like the body of a Response can only be read once
+	consume := func(convert func() (goja.Value, error)) func(goja.FunctionCall) goja.Value {
+		return func(goja.FunctionCall) goja.Value {
+			promise, resolve, reject := r.NewPromise()
+			if response.Get("bodyUsed").ToBoolean() {
+				_ = reject(r.NewTypeError("Body is unusable: Body has already been read"))
+
+				return r.ToValue(promise)
+			}
+			_ = response.Set("bodyUsed", true)
+
+			if value, err := convert(); err != nil {
+				_ = reject(err)
+			} else {
+				_ = resolve(value)
+			}
+
+			return r.ToValue(promise)
+		}
+	}
+
+	_ = response.Set("text", consume(func() (goja.Value, error) {
+		return r.ToValue(string(body)), nil
+	}))
+	_ = response.Set("json", consume(func() (goja.Value, error) {
+		parse, _ := goja.AssertFunction(r.Get("JSON").ToObject(r).Get("parse"))
+
+		return parse(goja.Undefined(), r.ToValue(string(body)))
+	}))
+	_ = response.Set("arrayBuffer", consume(func() (goja.Value, error) {
+		return r.ToValue(r.NewArrayBuffer(body)), nil
+	}))
+
+	return response
+}
+
+// headers object of the response, see https://developer.mozilla.org/en-US/docs/Web/API/Headers
+func (f *Fetch) headers(header http.Header) *goja.Object {
+	r := f.h.r
+	headers, _ := nodeHeaders(header)
+	object := r.NewObject()
+	_ = object.Set("get", func(call goja.FunctionCall) goja.Value {
+		values := header.Values(call.Argument(0).String())
+		if len(values) == 0 {
+			return goja.Null()
+		}
+
+		return r.ToValue(strings.Join(values, ", "))
+	})
+	_ = object.Set("has", func(call goja.FunctionCall) goja.Value {
+		return r.ToValue(len(header.Values(call.Argument(0).String())) > 0)
+	})
+	_ = object.Set("forEach", func(call goja.FunctionCall) goja.Value {
+		callback, ok := goja.AssertFunction(call.Argument(0))
+		if !ok {
+			panic(r.NewTypeError("The \"callback\" argument must be of type function"))
+		}
+
+		for _, name := range sortedKeys(headers) {
+			value := strings.Join(header.Values(name), ", ")
+			if _, err := callback(call.Argument(1), r.ToValue(value), r.ToValue(name), object); err != nil {
+				panic(err)
+			}
+		}
+
+		return goja.Undefined()
+	})
+
+	return object
+}
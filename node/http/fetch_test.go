@@ -0,0 +1,83 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetch_ResolvesResponse(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"method": "` + r.Method + `", "accept": "` + r.Header.Get("Accept") + `"}`))
+	}))
+	defer server.Close()
+
+	script := `fetch('` + server.URL + `', { method: 'put', headers: { Accept: 'application/json' } })
+		.then((res) => res.json().then((body) => done({
+			ok: res.ok,
+			status: res.status,
+			contentType: res.headers.get('content-type'),
+			bodyUsed: res.bodyUsed,
+			body,
+		})));`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, map[string]any{
+		"ok":          true,
+		"status":      int64(200),
+		"contentType": "application/json",
+		"bodyUsed":    true,
+		"body":        map[string]any{"method": "PUT", "accept": "application/json"},
+	}, result)
+}
+
+func TestFetch_RejectsReadingBodyTwice(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	script := `fetch('` + server.URL + `')
+		.then((res) => res.text().then(() => res.text()))
+		.catch((err) => done(err.message));`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, "Body is unusable: Body has already been read", result)
+}
+
+func TestFetch_RejectsUnsupportedScheme(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := `fetch('file:///etc/passwd').catch((err) => done(err.message));`
+
+	// Act
+	result := run(t, http.DefaultClient, script)
+
+	// Assert
+	assert.Equal(t, "fetch failed: 'file': unsupported scheme", result)
+}
+
+func TestFetch_RejectsWithoutClient(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := `fetch('http://localhost/ruleset.yaml').catch((err) => done(err.message));`
+
+	// Act
+	result := run(t, nil, script)
+
+	// Assert
+	assert.Equal(t, "fetch failed: "+ErrNoClient.Error(), result)
+}
@@ -1,16 +1,757 @@
 package http
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Emptyless/go-spectral/internal/task"
+	"github.com/Emptyless/go-spectral/node/events"
 	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
 	"github.com/dop251/goja_nodejs/require"
+	log "github.com/sirupsen/logrus"
 )
 
 // ModuleName of 'http' package
 const ModuleName = "http"
 
-// Enable the http package with a no-op
-func Enable(_ *goja.Runtime, registry *require.Registry, _ *require.RequireModule) {
-	f := func(_ *goja.Runtime, _ *goja.Object) {}
-	registry.RegisterNativeModule("node:"+ModuleName, f)
-	registry.RegisterNativeModule(ModuleName, f)
+// ErrNoClient when a request is made without a Client
+var ErrNoClient = errors.New("network requests are not available without a http client")
+
+// keepAliveInterval of the interval that keeps the event loop running while a request is in flight
+const keepAliveInterval = time.Hour
+
+// Loop runs a function on the event loop of the runtime, e.g. *eventloop.EventLoop
+type Loop interface {
+	RunOnLoop(fn func(*goja.Runtime)) bool
+}
+
+// Client performs the requests of the http and https packages and the fetch global
+type Client struct {
+	// HTTPClient performing the requests, every request fails with ErrNoClient if nil
+	HTTPClient *http.Client
+
+	// Loop to call back on once a request completes. The runtime must have the setInterval and clearInterval globals
+	// of the event loop, which are used to keep the loop running while a request is in flight.
+	Loop Loop
+
+	// Context of the requests if not nil, e.g. to cancel in-flight requests when the lint is cancelled
+	Context func() context.Context
+}
+
+// HTTP holds the goja.Runtime and the Client performing the requests for a protocol
+type HTTP struct {
+	r        *goja.Runtime
+	client   *Client
+	protocol string
+}
+
+// do the request in the background and call done on the event loop with the read body once it completes
+func (h *HTTP) do(req *http.Request, done func(resp *http.Response, body []byte, err error)) {
+	h.send(req, func(resp *http.Response, err error) {
+		if err != nil {
+			done(nil, nil, err)
+
+			return
+		}
+
+		h.read(resp, func(body []byte, err error) { done(resp, body, err) })
+	})
+}
+
+// send the request in the background and call done on the event loop once the headers of the response are received
+func (h *HTTP) send(req *http.Request, done func(resp *http.Response, err error)) {
+	if h.client == nil || h.client.HTTPClient == nil || h.client.Loop == nil {
+		task.Microtask(h.r, func() { done(nil, ErrNoClient) })
+
+		return
+	}
+
+	release := h.keepAlive()
+	go func() {
+		resp, err := h.client.HTTPClient.Do(req)
+		h.client.Loop.RunOnLoop(func(_ *goja.Runtime) {
+			release()
+			done(resp, err)
+		})
+	}()
+}
+
+// read the body of the response in the background and call done on the event loop once it is read and closed
+func (h *HTTP) read(resp *http.Response, done func(body []byte, err error)) {
+	release := h.keepAlive()
+	go func() {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		h.client.Loop.RunOnLoop(func(_ *goja.Runtime) {
+			release()
+			done(body, err)
+		})
+	}()
+}
+
+// context of a request
+func (h *HTTP) context() context.Context {
+	if h.client == nil || h.client.Context == nil {
+		return context.Background()
+	}
+
+	return h.client.Context()
+}
+
+// keepAlive the event loop with an interval until release is called, such that the loop waits for the response
+func (h *HTTP) keepAlive() (release func()) {
+	setInterval, ok := goja.AssertFunction(h.r.Get("setInterval"))
+	clearInterval, clearOk := goja.AssertFunction(h.r.Get("clearInterval"))
+	if !ok || !clearOk {
+		return func() {}
+	}
+
+	noop := h.r.ToValue(func(goja.FunctionCall) goja.Value { return goja.Undefined() })
+	interval, err := setInterval(goja.Undefined(), noop, h.r.ToValue(keepAliveInterval.Milliseconds()))
+	if err != nil {
+		return func() {}
+	}
+
+	return func() {
+		_, _ = clearInterval(goja.Undefined(), interval)
+	}
+}
+
+// newEmitter creates an instance of the EventEmitter of the events package
+func newEmitter(runtime *goja.Runtime) *goja.Object {
+	object, err := runtime.New(require.Require(runtime, events.ModuleName))
+	if err != nil {
+		panic(err)
+	}
+
+	return object
+}
+
+// listen to the event of the emitter with the method, e.g. on or once
+func listen(runtime *goja.Runtime, object *goja.Object, method string, name string, listener goja.Value) {
+	fn, ok := goja.AssertFunction(object.Get(method))
+	if !ok {
+		panic(runtime.NewTypeError("%s is not a function", method))
+	}
+
+	if _, err := fn(object, runtime.ToValue(name), listener); err != nil {
+		panic(err)
+	}
+}
+
+// emit the event on the emitter from the event loop, logging an error thrown by a listener since it has no caller
+func emit(runtime *goja.Runtime, object *goja.Object, name string, args ...goja.Value) {
+	fn, ok := goja.AssertFunction(object.Get("emit"))
+	if !ok {
+		return
+	}
+
+	if _, err := fn(object, append([]goja.Value{runtime.ToValue(name)}, args...)...); err != nil {
+		log.Warnf("http: uncaught error in '%s' listener: %v", name, err)
+	}
+}
+
+// Request creates a ClientRequest, i.e. http.request(url[, options][, callback]) or http.request(options[, callback])
+func (h *HTTP) Request(call goja.FunctionCall) goja.Value {
+	return h.request(call).object
+}
+
+// Get is Request that ends the request immediately
+func (h *HTTP) Get(call goja.FunctionCall) goja.Value {
+	req := h.request(call)
+	req.end(goja.FunctionCall{})
+
+	return req.object
+}
+
+// request parses the arguments into a clientRequest
+func (h *HTTP) request(call goja.FunctionCall) *clientRequest {
+	args := call.Arguments
+	u := &url.URL{Scheme: strings.TrimSuffix(h.protocol, ":"), Host: "localhost", Path: "/"}
+	if len(args) > 0 && isURL(args[0]) {
+		parsed, err := url.Parse(urlString(args[0]))
+		if err != nil {
+			panic(h.r.NewTypeError("Invalid URL: %s", urlString(args[0])))
+		}
+		u = parsed
+		args = args[1:]
+	}
+
+	var options *goja.Object
+	if len(args) > 0 {
+		if object, ok := args[0].(*goja.Object); ok && !isFunction(args[0]) {
+			options = object
+			args = args[1:]
+		}
+	}
+
+	req := &clientRequest{h: h, method: http.MethodGet, url: u, headers: http.Header{}}
+	req.object = newEmitter(h.r)
+	if options != nil {
+		req.apply(options)
+	}
+
+	if req.url.Scheme+":" != h.protocol {
+		panic(h.r.NewTypeError("Protocol \"%s:\" not supported. Expected \"%s\"", req.url.Scheme, h.protocol))
+	}
+
+	if len(args) > 0 {
+		if isFunction(args[0]) {
+			listen(h.r, req.object, "once", "response", args[0])
+		}
+	}
+
+	_ = req.object.Set("method", req.method)
+	_ = req.object.Set("protocol", req.url.Scheme+":")
+	_ = req.object.Set("host", req.url.Hostname())
+	_ = req.object.Set("path", req.url.RequestURI())
+	_ = req.object.Set("write", req.write)
+	_ = req.object.Set("end", req.end)
+	_ = req.object.Set("setHeader", req.setHeader)
+	_ = req.object.Set("getHeader", req.getHeader)
+	_ = req.object.Set("removeHeader", req.removeHeader)
+	_ = req.object.Set("setTimeout", req.setTimeout)
+	_ = req.object.Set("abort", req.abort)
+	_ = req.object.Set("destroy", req.destroy)
+
+	return req
+}
+
+// clientRequest is an outgoing request, see https://nodejs.org/api/http.html#class-httpclientrequest
+type clientRequest struct {
+	h         *HTTP
+	object    *goja.Object
+	method    string
+	url       *url.URL
+	headers   http.Header
+	body      bytes.Buffer
+	timeout   time.Duration
+	cancel    context.CancelFunc
+	ended     bool
+	responded bool
+	destroyed bool
+
+	// clearTimer of the timeout, nil if no timer is set
+	clearTimer func()
+}
+
+// apply the request options, see https://nodejs.org/api/http.html#httprequesturl-options-callback
+func (c *clientRequest) apply(options *goja.Object) {
+	if v := options.Get("protocol"); isSet(v) {
+		c.url.Scheme = strings.TrimSuffix(v.String(), ":")
+	}
+
+	host := c.url.Hostname()
+	if v := options.Get("hostname"); isSet(v) {
+		host = v.String()
+	} else if v := options.Get("host"); isSet(v) {
+		host = v.String()
+	}
+
+	port := c.url.Port()
+	if v := options.Get("port"); isSet(v) {
+		port = v.String()
+	}
+
+	c.url.Host = host
+	if port != "" {
+		c.url.Host += ":" + port
+	}
+
+	if v := options.Get("path"); isSet(v) {
+		if parsed, err := url.ParseRequestURI(v.String()); err == nil {
+			c.url.Path, c.url.RawPath, c.url.RawQuery = parsed.Path, parsed.RawPath, parsed.RawQuery
+		}
+	}
+
+	if v := options.Get("method"); isSet(v) {
+		c.method = strings.ToUpper(v.String())
+	}
+
+	if v := options.Get("auth"); isSet(v) {
+		username, password, _ := strings.Cut(v.String(), ":")
+		c.url.User = url.UserPassword(username, password)
+	}
+
+	if v := options.Get("timeout"); isSet(v) {
+		c.timeout = time.Duration(v.ToInteger()) * time.Millisecond
+	}
+
+	if v := options.Get("headers"); isSet(v) {
+		setHeaders(c.h.r, c.headers, v)
+	}
+}
+
+// write a chunk of the request body
+func (c *clientRequest) write(call goja.FunctionCall) goja.Value {
+	if isSet(call.Argument(0)) {
+		c.body.Write(buffer.DecodeBytes(c.h.r, call.Argument(0), call.Argument(1)))
+	}
+
+	return c.h.r.ToValue(true)
+}
+
+// end the request, optionally with a last chunk of the body, and send it
+func (c *clientRequest) end(call goja.FunctionCall) goja.Value {
+	if c.ended {
+		return c.object
+	}
+	c.ended = true
+
+	if chunk := call.Argument(0); isSet(chunk) && !isFunction(chunk) {
+		c.body.Write(buffer.DecodeBytes(c.h.r, chunk, call.Argument(1)))
+	}
+
+	ctx, cancel := context.WithCancel(c.h.context())
+	c.cancel = cancel
+
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url.String(), bytes.NewReader(c.body.Bytes()))
+	if err != nil {
+		cancel()
+		panic(c.h.r.NewGoError(err))
+	}
+	req.Header = c.headers
+
+	c.h.send(req, func(resp *http.Response, err error) {
+		c.responded = true
+		c.stopTimer()
+		if c.destroyed {
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+
+			return
+		}
+
+		if err != nil {
+			cancel()
+			emit(c.h.r, c.object, "error", c.h.r.NewGoError(err))
+			emit(c.h.r, c.object, "close")
+
+			return
+		}
+
+		// the message cancels the context once its body is read or it is destroyed
+		emit(c.h.r, c.object, "response", newIncomingMessage(c.h, resp, cancel).object)
+	})
+	c.startTimer()
+
+	return c.object
+}
+
+// startTimer emits 'timeout' once the request is in flight for the timeout without a response. Like Node, the request
+// is not aborted, i.e. a 'timeout' listener must destroy (or abort) the request to stop it.
+func (c *clientRequest) startTimer() {
+	c.stopTimer()
+	if c.timeout <= 0 || !c.ended || c.responded || c.destroyed {
+		return
+	}
+
+	setTimeout, ok := goja.AssertFunction(c.h.r.Get("setTimeout"))
+	clearTimeout, clearOk := goja.AssertFunction(c.h.r.Get("clearTimeout"))
+	if !ok || !clearOk {
+		return
+	}
+
+	timer, err := setTimeout(goja.Undefined(), c.h.r.ToValue(func(goja.FunctionCall) goja.Value {
+		c.clearTimer = nil
+		emit(c.h.r, c.object, "timeout")
+
+		return goja.Undefined()
+	}), c.h.r.ToValue(c.timeout.Milliseconds()))
+	if err != nil {
+		return
+	}
+
+	c.clearTimer = func() {
+		_, _ = clearTimeout(goja.Undefined(), timer)
+	}
+}
+
+// stopTimer of the timeout if it is set
+func (c *clientRequest) stopTimer() {
+	if c.clearTimer != nil {
+		c.clearTimer()
+		c.clearTimer = nil
+	}
+}
+
+// setHeader of the request
+func (c *clientRequest) setHeader(call goja.FunctionCall) goja.Value {
+	c.headers.Del(call.Argument(0).String())
+	setHeader(c.headers, call.Argument(0).String(), call.Argument(1))
+
+	return c.object
+}
+
+// getHeader of the request, undefined if not set
+func (c *clientRequest) getHeader(call goja.FunctionCall) goja.Value {
+	values := c.headers.Values(call.Argument(0).String())
+	if len(values) == 0 {
+		return goja.Undefined()
+	}
+
+	return c.h.r.ToValue(strings.Join(values, ", "))
+}
+
+// removeHeader of the request
+func (c *clientRequest) removeHeader(call goja.FunctionCall) goja.Value {
+	c.headers.Del(call.Argument(0).String())
+
+	return goja.Undefined()
+}
+
+// setTimeout of the request, emitting 'timeout' (and calling the optional callback) once it passes
+func (c *clientRequest) setTimeout(call goja.FunctionCall) goja.Value {
+	c.timeout = time.Duration(call.Argument(0).ToInteger()) * time.Millisecond
+	if isFunction(call.Argument(1)) {
+		listen(c.h.r, c.object, "once", "timeout", call.Argument(1))
+	}
+	c.startTimer()
+
+	return c.object
+}
+
+// abort the request
+func (c *clientRequest) abort(_ goja.FunctionCall) goja.Value {
+	c.stop()
+	emit(c.h.r, c.object, "abort")
+	emit(c.h.r, c.object, "close")
+
+	return goja.Undefined()
+}
+
+// destroy the request, emitting the optional error
+func (c *clientRequest) destroy(call goja.FunctionCall) goja.Value {
+	c.stop()
+	if err := call.Argument(0); isSet(err) {
+		emit(c.h.r, c.object, "error", err)
+	}
+	emit(c.h.r, c.object, "close")
+
+	return c.object
+}
+
+// stop the request such that the response is no longer emitted
+func (c *clientRequest) stop() {
+	c.destroyed = true
+	c.stopTimer()
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// incomingMessage is a received response, see https://nodejs.org/api/http.html#class-httpincomingmessage
+type incomingMessage struct {
+	r         *goja.Runtime
+	object    *goja.Object
+	cancel    context.CancelFunc
+	body      []byte
+	err       error
+	read      bool
+	encoding  goja.Value
+	flowing   bool
+	destroyed bool
+}
+
+// newIncomingMessage of the response, of which the body is read in the background. The body is emitted once it is
+// read and the message is consumed, i.e. when a 'data' listener is added or resume or pipe is called. The cancel of
+// the context of the request is called once the body is read or the message is destroyed.
+func newIncomingMessage(h *HTTP, resp *http.Response, cancel context.CancelFunc) *incomingMessage {
+	runtime := h.r
+	m := &incomingMessage{r: runtime, object: newEmitter(runtime), cancel: cancel, encoding: goja.Undefined()}
+	h.read(resp, func(body []byte, err error) {
+		cancel()
+		m.body, m.err, m.read = body, err, true
+		if m.flowing {
+			m.emitBody()
+		}
+	})
+
+	on := m.object.Get("on")
+	_ = m.object.Set("on", m.on(on))
+	_ = m.object.Set("addListener", m.on(on))
+
+	headers, rawHeaders := nodeHeaders(resp.Header)
+	_ = m.object.Set("statusCode", resp.StatusCode)
+	_ = m.object.Set("statusMessage", http.StatusText(resp.StatusCode))
+	_ = m.object.Set("httpVersion", strconv.Itoa(resp.ProtoMajor)+"."+strconv.Itoa(resp.ProtoMinor))
+	_ = m.object.Set("headers", headers)
+	_ = m.object.Set("rawHeaders", rawHeaders)
+	_ = m.object.Set("url", resp.Request.URL.String())
+	_ = m.object.Set("setEncoding", m.setEncoding)
+	_ = m.object.Set("resume", m.resume)
+	_ = m.object.Set("pause", func(goja.FunctionCall) goja.Value { return m.object })
+	_ = m.object.Set("pipe", m.pipe)
+	_ = m.object.Set("destroy", m.destroy)
+
+	return m
+}
+
+// destroy the message, cancelling the request such that the rest of the body is not read, and emit the optional error
+func (m *incomingMessage) destroy(call goja.FunctionCall) goja.Value {
+	if m.destroyed {
+		return m.object
+	}
+	m.destroyed = true
+	m.cancel()
+
+	if err := call.Argument(0); isSet(err) {
+		emit(m.r, m.object, "error", err)
+	}
+	emit(m.r, m.object, "close")
+
+	return m.object
+}
+
+// on returns the method adding a listener with the on method of the EventEmitter, where a 'data' listener makes the
+// message flow like a Readable does
+func (m *incomingMessage) on(on goja.Value) func(call goja.FunctionCall) goja.Value {
+	fn, _ := goja.AssertFunction(on)
+
+	return func(call goja.FunctionCall) goja.Value {
+		res, err := fn(call.This, call.Arguments...)
+		if err != nil {
+			panic(err)
+		}
+
+		if call.Argument(0).String() == "data" {
+			m.flow()
+		}
+
+		return res
+	}
+}
+
+// setEncoding of the emitted data, emitting strings instead of Buffer's
+func (m *incomingMessage) setEncoding(call goja.FunctionCall) goja.Value {
+	m.encoding = call.Argument(0)
+
+	return m.object
+}
+
+// resume the message, emitting the body
+func (m *incomingMessage) resume(_ goja.FunctionCall) goja.Value {
+	m.flow()
+
+	return m.object
+}
+
+// pipe the body to the writable destination, ending it after the body is written
+func (m *incomingMessage) pipe(call goja.FunctionCall) goja.Value {
+	destination := call.Argument(0).ToObject(m.r)
+	write, _ := goja.AssertFunction(destination.Get("write"))
+	end, _ := goja.AssertFunction(destination.Get("end"))
+	listen(m.r, m.object, "on", "data", m.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		if write == nil {
+			return goja.Undefined()
+		}
+
+		res, err := write(destination, call.Arguments...)
+		if err != nil {
+			panic(err)
+		}
+
+		return res
+	}))
+	listen(m.r, m.object, "once", "end", m.r.ToValue(func(goja.FunctionCall) goja.Value {
+		if end == nil {
+			return goja.Undefined()
+		}
+
+		res, err := end(destination)
+		if err != nil {
+			panic(err)
+		}
+
+		return res
+	}))
+	m.flow()
+
+	return destination
+}
+
+// flow emits the body and the end of the message once it is read, once
+func (m *incomingMessage) flow() {
+	if m.flowing {
+		return
+	}
+	m.flowing = true
+
+	if m.read {
+		m.emitBody()
+	}
+}
+
+// emitBody and the end of the read message in a microtask, or the error if the body could not be read
+func (m *incomingMessage) emitBody() {
+	task.Microtask(m.r, func() {
+		if m.destroyed {
+			return
+		}
+
+		if m.err != nil {
+			emit(m.r, m.object, "error", m.r.NewGoError(m.err))
+			emit(m.r, m.object, "close")
+
+			return
+		}
+
+		if len(m.body) > 0 {
+			emit(m.r, m.object, "data", buffer.EncodeBytes(m.r, m.body, m.encoding))
+		}
+		emit(m.r, m.object, "end")
+		emit(m.r, m.object, "close")
+	})
+}
+
+// nodeHeaders are the lower-cased headers as an object and the raw headers as a flat list of names and values
+func nodeHeaders(header http.Header) (map[string]any, []any) {
+	names := sortedKeys(header)
+	headers := make(map[string]any, len(header))
+	rawHeaders := make([]any, 0, 2*len(header))
+	for _, name := range names {
+		values := header[name]
+		for _, value := range values {
+			rawHeaders = append(rawHeaders, name, value)
+		}
+
+		lower := strings.ToLower(name)
+		if lower == "set-cookie" {
+			cookies := make([]any, len(values))
+			for i, value := range values {
+				cookies[i] = value
+			}
+			headers[lower] = cookies
+		} else {
+			headers[lower] = strings.Join(values, ", ")
+		}
+	}
+
+	return headers, rawHeaders
+}
+
+// sortedKeys of the map
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// setHeaders from an object of names and values or a Headers like object with a forEach(value, name) method
+func setHeaders(runtime *goja.Runtime, header http.Header, value goja.Value) {
+	object := value.ToObject(runtime)
+	if forEach, ok := goja.AssertFunction(object.Get("forEach")); ok && !isArray(object) {
+		_, _ = forEach(object, runtime.ToValue(func(call goja.FunctionCall) goja.Value {
+			setHeader(header, call.Argument(1).String(), call.Argument(0))
+
+			return goja.Undefined()
+		}))
+
+		return
+	}
+
+	for _, name := range object.Keys() {
+		setHeader(header, name, object.Get(name))
+	}
+}
+
+// setHeader adds the value or, if it is an array, each of its values to the header
+func setHeader(header http.Header, name string, value goja.Value) {
+	if values, ok := value.Export().([]any); ok {
+		for _, v := range values {
+			header.Add(name, fmt.Sprint(v))
+		}
+
+		return
+	}
+
+	header.Add(name, value.String())
+}
+
+// isSet if the value is neither undefined nor null
+func isSet(value goja.Value) bool {
+	return value != nil && !goja.IsUndefined(value) && !goja.IsNull(value)
+}
+
+// isFunction if the value is callable
+func isFunction(value goja.Value) bool {
+	_, ok := goja.AssertFunction(value)
+
+	return ok
+}
+
+// isArray if the object is an Array
+func isArray(object *goja.Object) bool {
+	return object.ClassName() == "Array"
+}
+
+// isURL if the value is a string or a URL object with a href
+func isURL(value goja.Value) bool {
+	if object, ok := value.(*goja.Object); ok {
+		return isSet(object.Get("href"))
+	}
+
+	return isSet(value)
+}
+
+// urlString of a string or URL object
+func urlString(value goja.Value) string {
+	if object, ok := value.(*goja.Object); ok {
+		return object.Get("href").String()
+	}
+
+	return value.String()
+}
+
+// RequireProtocol returns the ModuleLoader of the http package for the protocol (i.e. "http:" or "https:") performing
+// the requests with the client
+func RequireProtocol(client *Client, protocol string) func(runtime *goja.Runtime, module *goja.Object) {
+	return func(runtime *goja.Runtime, module *goja.Object) {
+		h := &HTTP{r: runtime, client: client, protocol: protocol}
+
+		statusCodes := runtime.NewObject()
+		for code := 100; code < 600; code++ {
+			if text := http.StatusText(code); text != "" {
+				_ = statusCodes.Set(strconv.Itoa(code), text)
+			}
+		}
+
+		agent := func(call goja.ConstructorCall) *goja.Object {
+			return call.This
+		}
+
+		exports := module.Get("exports").(*goja.Object)
+		_ = exports.Set("request", h.Request)
+		_ = exports.Set("get", h.Get)
+		_ = exports.Set("STATUS_CODES", statusCodes)
+		_ = exports.Set("METHODS", []string{
+			http.MethodConnect, http.MethodDelete, http.MethodGet, http.MethodHead, http.MethodOptions,
+			http.MethodPatch, http.MethodPost, http.MethodPut, http.MethodTrace,
+		})
+		_ = exports.Set("Agent", agent)
+		_ = exports.Set("globalAgent", runtime.NewObject())
+	}
+}
+
+// Enable the http package without a Client, every request fails with ErrNoClient
+func Enable(runtime *goja.Runtime, registry *require.Registry, requireModule *require.RequireModule) {
+	EnableClient(runtime, registry, requireModule, nil)
+}
+
+// EnableClient enables the http package and the fetch global performing requests with the client. The requests and
+// responses are emitters of the events package, which must be enabled.
+func EnableClient(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule, client *Client) {
+	registry.RegisterNativeModule("node:"+ModuleName, RequireProtocol(client, "http:"))
+	registry.RegisterNativeModule(ModuleName, RequireProtocol(client, "http:"))
+	_ = runtime.Set("fetch", (&Fetch{h: &HTTP{r: runtime, client: client}}).Fetch)
 }
@@ -1,14 +1,47 @@
 package http
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Emptyless/go-spectral/node/events"
 	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/eventloop"
 	noderequire "github.com/dop251/goja_nodejs/require"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// run the script on an event loop with the http package and fetch global performing requests with the client. The
+// script reports its result by calling done(value).
+func run(t *testing.T, client *http.Client, script string) any {
+	t.Helper()
+
+	registry := noderequire.NewRegistry()
+	loop := eventloop.NewEventLoop(eventloop.WithRegistry(registry), eventloop.EnableConsole(false))
+	defer loop.Terminate()
+
+	var result any
+	var err error
+	loop.Run(func(runtime *goja.Runtime) {
+		var c *Client
+		if client != nil {
+			c = &Client{HTTPClient: client, Loop: loop}
+		}
+		events.Enable(runtime, registry, nil)
+		EnableClient(runtime, registry, nil, c)
+
+		_ = runtime.Set("done", func(value any) { result = value })
+		_, err = runtime.RunString(script)
+	})
+	require.NoError(t, err)
+
+	return result
+}
+
 func TestEnable(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -26,3 +59,209 @@ func TestEnable(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, res)
 }
+
+func TestGet_EmitsResponse(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Ruleset", "company")
+		_, _ = w.Write([]byte("extends: spectral:oas"))
+	}))
+	defer server.Close()
+
+	script := `require('http').get('` + server.URL + `/ruleset.yaml', (res) => {
+		let body = '';
+		res.setEncoding('utf8');
+		res.on('data', (chunk) => body += chunk);
+		res.on('end', () => done({ status: res.statusCode, header: res.headers['x-ruleset'], body }));
+	});`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, map[string]any{"status": int64(200), "header": "company", "body": "extends: spectral:oas"}, result)
+}
+
+func TestRequest_SendsMethodHeadersAndBody(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(r.Method + " " + r.URL.RequestURI() + " " + r.Header.Get("Authorization") + " " + string(body)))
+	}))
+	defer server.Close()
+
+	script := `const req = require('node:http').request('` + server.URL + `', {
+		method: 'post',
+		path: '/rulesets?name=company',
+		headers: { Authorization: 'Bearer token' },
+	}, (res) => {
+		const chunks = [];
+		res.on('data', (chunk) => chunks.push(chunk));
+		res.on('end', () => done(chunks.map(String).join('')));
+	});
+	req.write('hello ');
+	req.end('world');`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, "POST /rulesets?name=company Bearer token hello world", result)
+}
+
+func TestRequest_EmitsTimeout(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	script := `const events = [];
+	const req = require('http').request('` + server.URL + `', { timeout: 10 });
+	req.on('timeout', () => {
+		events.push('timeout');
+		req.destroy(new Error('timed out'));
+	});
+	req.on('error', () => events.push('error'));
+	req.on('close', () => done(events.concat('close')));
+	req.end();`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, []any{"timeout", "error", "close"}, result)
+}
+
+func TestRequest_TimeoutDoesNotAbort(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	script := `const events = [];
+	const req = require('http').get('` + server.URL + `', (res) => {
+		events.push('response');
+		res.setEncoding('utf8');
+		res.on('data', (chunk) => events.push(chunk));
+		res.on('end', () => done(events));
+	});
+	req.setTimeout(10, () => events.push('timeout'));`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, []any{"timeout", "response", "openapi: 3.1.0"}, result)
+}
+
+func TestResponse_DestroyCancelsRequest(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("openapi: "))
+		w.(http.Flusher).Flush()
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	script := `const events = [];
+	require('http').get('` + server.URL + `', (res) => {
+		res.on('data', () => events.push('data'));
+		res.on('end', () => events.push('end'));
+		res.on('close', () => done(events.concat('close')));
+		res.destroy();
+	});`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, []any{"close"}, result)
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		assert.Fail(t, "request was not cancelled")
+	}
+}
+
+func TestRequest_EmitsErrorWithoutClient(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := `require('http').get('http://localhost/ruleset.yaml').on('error', (err) => done(err.message));`
+
+	// Act
+	result := run(t, nil, script)
+
+	// Assert
+	assert.Equal(t, ErrNoClient.Error(), result)
+}
+
+func TestRequest_ThrowsOnUnsupportedProtocol(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := `try { require('http').get('https://localhost/ruleset.yaml'); } catch (err) { done(err.message); }`
+
+	// Act
+	result := run(t, nil, script)
+
+	// Assert
+	assert.Equal(t, `Protocol "https:" not supported. Expected "http:"`, result)
+}
+
+func TestResponse_Pipe(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	script := `let body = '';
+	require('http').get('` + server.URL + `', (res) => res.pipe({
+		write: (chunk) => body += chunk,
+		end: () => done(body),
+	}));`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, "openapi: 3.1.0", result)
+}
+
+func TestRequest_IsEventEmitter(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	script := `const EventEmitter = require('events');
+	const calls = [];
+	const req = require('http').get('` + server.URL + `', (res) => {
+		res.prependListener('end', () => calls.push('prepended'));
+		res.on('end', () => done([req instanceof EventEmitter, res instanceof EventEmitter, ...calls, 'end']));
+		res.resume();
+	});
+	req.on(EventEmitter.errorMonitor, () => calls.push('monitor'));`
+
+	// Act
+	result := run(t, server.Client(), script)
+
+	// Assert
+	assert.Equal(t, []any{true, true, "prepended", "end"}, result)
+}
@@ -3,14 +3,20 @@ package https
 import (
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
+
+	nodehttp "github.com/Emptyless/go-spectral/node/http"
 )
 
 // ModuleName of the https package
 const ModuleName = "https"
 
-// Enable the https package with a no-op
-func Enable(_ *goja.Runtime, registry *require.Registry, _ *require.RequireModule) {
-	f := func(_ *goja.Runtime, _ *goja.Object) {}
-	registry.RegisterNativeModule("node:"+ModuleName, f)
-	registry.RegisterNativeModule(ModuleName, f)
+// Enable the https package without a Client, every request fails with nodehttp.ErrNoClient
+func Enable(runtime *goja.Runtime, registry *require.Registry, requireModule *require.RequireModule) {
+	EnableClient(runtime, registry, requireModule, nil)
+}
+
+// EnableClient enables the https package performing requests with the client
+func EnableClient(_ *goja.Runtime, registry *require.Registry, _ *require.RequireModule, client *nodehttp.Client) {
+	registry.RegisterNativeModule("node:"+ModuleName, nodehttp.RequireProtocol(client, "https:"))
+	registry.RegisterNativeModule(ModuleName, nodehttp.RequireProtocol(client, "https:"))
 }
@@ -1,9 +1,14 @@
 package https
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/Emptyless/go-spectral/node/events"
+	nodehttp "github.com/Emptyless/go-spectral/node/http"
 	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/eventloop"
 	noderequire "github.com/dop251/goja_nodejs/require"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,3 +31,35 @@ func TestEnable(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, res)
 }
+
+func TestEnableClient_GetsOverTLS(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("extends: spectral:oas"))
+	}))
+	defer server.Close()
+
+	registry := noderequire.NewRegistry()
+	loop := eventloop.NewEventLoop(eventloop.WithRegistry(registry), eventloop.EnableConsole(false))
+	defer loop.Terminate()
+
+	var body string
+	var err error
+
+	// Act
+	loop.Run(func(runtime *goja.Runtime) {
+		events.Enable(runtime, registry, nil)
+		EnableClient(runtime, registry, nil, &nodehttp.Client{HTTPClient: server.Client(), Loop: loop})
+
+		_ = runtime.Set("done", func(value string) { body = value })
+		_, err = runtime.RunString(`require('https').get('` + server.URL + `', (res) => {
+			res.setEncoding('utf8');
+			res.on('data', done);
+		});`)
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "extends: spectral:oas", body)
+}
@@ -0,0 +1,103 @@
+// Package args validates the arguments of the node packages, reporting an invalid argument with the same error as Node
+package args
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/dop251/goja"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
+)
+
+// maxStringLength of a string in the description of a received value, longer strings are truncated
+const maxStringLength = 28
+
+// InvalidArgType is the ERR_INVALID_ARG_TYPE TypeError of the argument with the name, which is named a property if the
+// name is a property path (e.g. "superCtor.prototype"). The expected type is worded like Node does, e.g.
+// "of type function" or "an instance of Stream".
+func InvalidArgType(runtime *goja.Runtime, name string, expected string, value goja.Value) *goja.Object {
+	kind := "argument"
+	if strings.Contains(name, ".") {
+		kind = "property"
+	}
+
+	return nodeerrors.NewTypeError(runtime, nodeerrors.ErrCodeInvalidArgType, "The \"%s\" %s must be %s. Received %s", name, kind, expected, Received(value))
+}
+
+// Received describes the value in the message of an error like Node does, e.g. "type number (1)"
+func Received(value goja.Value) string {
+	switch {
+	case value == nil || goja.IsUndefined(value):
+		return "undefined"
+	case goja.IsNull(value):
+		return "null"
+	}
+
+	if object, ok := value.(*goja.Object); ok {
+		if _, ok := goja.AssertFunction(object); ok {
+			return fmt.Sprintf("function %s", object.Get("name"))
+		}
+
+		if constructor, ok := object.Get("constructor").(*goja.Object); ok {
+			return fmt.Sprintf("an instance of %s", constructor.Get("name"))
+		}
+
+		return "an instance of Object"
+	}
+
+	switch TypeOf(value) {
+	case "string":
+		s := value.String()
+		if len(s) > maxStringLength {
+			s = s[:maxStringLength-3] + "..."
+		}
+
+		if strings.Contains(s, "'") {
+			quoted, _ := json.Marshal(s)
+
+			return fmt.Sprintf("type string (%s)", quoted)
+		}
+
+		return fmt.Sprintf("type string ('%s')", s)
+	case "bigint":
+		return fmt.Sprintf("type bigint (%sn)", value)
+	case "symbol":
+		return fmt.Sprintf("type symbol (Symbol(%s))", value)
+	default:
+		return fmt.Sprintf("type %s (%s)", TypeOf(value), value)
+	}
+}
+
+// TypeOf the value like the JavaScript typeof operator
+func TypeOf(value goja.Value) string {
+	switch {
+	case value == nil || goja.IsUndefined(value):
+		return "undefined"
+	case goja.IsNull(value):
+		return "object"
+	}
+
+	switch v := value.(type) {
+	case *goja.Symbol:
+		return "symbol"
+	case *goja.Object:
+		if _, ok := goja.AssertFunction(v); ok {
+			return "function"
+		}
+
+		return "object"
+	}
+
+	switch value.Export().(type) {
+	case int64, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case *big.Int:
+		return "bigint"
+	default:
+		return "string"
+	}
+}
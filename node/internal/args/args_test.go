@@ -0,0 +1,96 @@
+package args
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceived(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected string
+	}{
+		"undefined": {script: `undefined`, expected: "undefined"},
+		"null":      {script: `null`, expected: "null"},
+		"number":    {script: `1`, expected: "type number (1)"},
+		"boolean":   {script: `false`, expected: "type boolean (false)"},
+		"bigint":    {script: `10n`, expected: "type bigint (10n)"},
+		"symbol":    {script: `Symbol('a')`, expected: "type symbol (Symbol(a))"},
+		"string":    {script: `'str'`, expected: "type string ('str')"},
+		"quote":     {script: `"it's"`, expected: `type string ("it's")`},
+		"long":      {script: `'a'.repeat(40)`, expected: "type string ('aaaaaaaaaaaaaaaaaaaaaaaaa...')"},
+		"function":  {script: `(function listener() {})`, expected: "function listener"},
+		"object":    {script: `({})`, expected: "an instance of Object"},
+		"instance":  {script: `new Map()`, expected: "an instance of Map"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			value, err := goja.New().RunString(tt.script)
+			require.NoError(t, err)
+
+			// Act
+			res := Received(value)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	values, err := runtime.RunString(`[undefined, null, 1, 1.5, true, 'a', Symbol('a'), 1n, function() {}, {}]`)
+	require.NoError(t, err)
+
+	// Act
+	var types []string
+	for i := range 10 {
+		types = append(types, TypeOf(values.ToObject(runtime).Get(strconv.Itoa(i))))
+	}
+
+	// Assert
+	assert.Equal(t, []string{"undefined", "object", "number", "number", "boolean", "string", "symbol", "bigint", "function", "object"}, types)
+}
+
+func TestInvalidArgType(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		name     string
+		expected string
+	}{
+		"argument": {
+			name:     "listener",
+			expected: `The "listener" argument must be of type function. Received type number (1)`,
+		},
+		"property": {
+			name:     "superCtor.prototype",
+			expected: `The "superCtor.prototype" property must be of type function. Received type number (1)`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			runtime := goja.New()
+
+			// Act
+			err := InvalidArgType(runtime, tt.name, "of type function", runtime.ToValue(1))
+
+			// Assert
+			assert.Equal(t, "ERR_INVALID_ARG_TYPE", err.Get("code").String())
+			assert.Equal(t, tt.expected, err.Get("message").String())
+			assert.True(t, err.Get("constructor").SameAs(runtime.Get("TypeError")))
+		})
+	}
+}
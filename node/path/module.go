@@ -1,9 +1,11 @@
 package path
 
 import (
-	"path"
-	"strings"
+	"fmt"
+	"net/url"
+	"os"
 
+	"github.com/Emptyless/go-spectral/node/internal/args"
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 )
@@ -11,66 +13,205 @@ import (
 // ModuleName of the path package
 const ModuleName = "path"
 
-// Path holds the goja.Runtime for value conversion
+// implementation of the path functions, i.e. posix or win32
+type implementation interface {
+	sep() string
+	delimiter() string
+	resolve(paths ...string) string
+	normalize(path string) string
+	isAbsolute(path string) bool
+	join(paths ...string) string
+	relative(from string, to string) string
+	toNamespacedPath(path string) string
+	dirname(path string) string
+	basename(path string, suffix string) string
+	extname(path string) string
+	format(parsed Parsed) string
+	parse(path string) Parsed
+}
+
+// Path holds the goja.Runtime for value conversion. The functions have posix semantics unless windows is set, in
+// which case they have the win32 semantics of path.win32.
 type Path struct {
-	r *goja.Runtime
+	r       *goja.Runtime
+	windows bool
 }
 
-// Dirname of argument
-func (p *Path) Dirname(call goja.FunctionCall) goja.Value {
-	dirname := path.Dir(call.Argument(0).String())
-	return p.r.ToValue(dirname)
+// implementation of the functions of the Path
+func (p *Path) implementation() implementation {
+	if p.windows {
+		return win32{cwd: p.cwd}
+	}
+
+	return posix{cwd: p.cwd}
+}
+
+// cwd is the process.cwd() of the runtime, falling back to os.Getwd if the process module is not enabled
+func (p *Path) cwd() string {
+	if process, ok := p.r.Get("process").(*goja.Object); ok {
+		if cwd, ok := goja.AssertFunction(process.Get("cwd")); ok {
+			if v, err := cwd(process); err == nil {
+				return v.String()
+			}
+		}
+	}
+
+	wd, _ := os.Getwd()
+
+	return wd
+}
+
+// string argument of the call, panicking with a Node ERR_INVALID_ARG_TYPE TypeError if it is not a string
+func (p *Path) string(value goja.Value, name string) string {
+	if s, ok := value.(goja.String); ok {
+		return s.String()
+	}
+
+	panic(args.InvalidArgType(p.r, name, "of type string", value))
 }
 
-// Resolve args to path
+// Resolve the paths into an absolute path, prepending process.cwd() if the paths are relative
 func (p *Path) Resolve(call goja.FunctionCall) goja.Value {
-	args := make([]string, len(call.Arguments))
+	paths := make([]string, len(call.Arguments))
 	for i, arg := range call.Arguments {
-		args[i] = arg.String()
+		paths[i] = p.string(arg, fmt.Sprintf("paths[%d]", i))
 	}
 
-	return p.r.ToValue(path.Join(args...))
+	return p.r.ToValue(p.implementation().resolve(paths...))
 }
 
-// Relative path. Not implemented.
-func (p *Path) Relative(_ goja.FunctionCall) goja.Value {
-	panic("not implemented")
+// Normalize the path, resolving the '.' and '..' segments
+func (p *Path) Normalize(call goja.FunctionCall) goja.Value {
+	return p.r.ToValue(p.implementation().normalize(p.string(call.Argument(0), "path")))
 }
 
-// Extname is the name of the extension of the argument
-func (p *Path) Extname(call goja.FunctionCall) goja.Value {
-	return p.r.ToValue(path.Ext(call.Argument(0).String()))
+// IsAbsolute if the path is absolute
+func (p *Path) IsAbsolute(call goja.FunctionCall) goja.Value {
+	return p.r.ToValue(p.implementation().isAbsolute(p.string(call.Argument(0), "path")))
+}
+
+// Join the paths with the separator and normalize the result
+func (p *Path) Join(call goja.FunctionCall) goja.Value {
+	paths := make([]string, len(call.Arguments))
+	for i, arg := range call.Arguments {
+		paths[i] = p.string(arg, "path")
+	}
+
+	return p.r.ToValue(p.implementation().join(paths...))
+}
+
+// Relative path from the first to the second argument
+func (p *Path) Relative(call goja.FunctionCall) goja.Value {
+	from := p.string(call.Argument(0), "from")
+	to := p.string(call.Argument(1), "to")
+
+	return p.r.ToValue(p.implementation().relative(from, to))
+}
+
+// ToNamespacedPath of the argument, which is returned as is if it is not a string
+func (p *Path) ToNamespacedPath(call goja.FunctionCall) goja.Value {
+	path, ok := call.Argument(0).(goja.String)
+	if !ok {
+		return call.Argument(0)
+	}
+
+	return p.r.ToValue(p.implementation().toNamespacedPath(path.String()))
 }
 
-// IsURL checks if the input is an URL. Not implemented.
-func (p *Path) IsURL(_ goja.FunctionCall) goja.Value {
-	panic("not implemented")
+// Dirname of argument
+func (p *Path) Dirname(call goja.FunctionCall) goja.Value {
+	return p.r.ToValue(p.implementation().dirname(p.string(call.Argument(0), "path")))
 }
 
-// Basename of the argument.
+// Basename of the argument, without the optional suffix
 func (p *Path) Basename(call goja.FunctionCall) goja.Value {
-	base := path.Base(call.Argument(0).String())
-	if len(call.Arguments) == 2 { //nolint:mnd // function optionally has two arguments
-		base = strings.TrimSuffix(base, call.Argument(1).String())
+	suffix := ""
+	if !goja.IsUndefined(call.Argument(1)) {
+		suffix = p.string(call.Argument(1), "suffix")
 	}
 
-	return p.r.ToValue(base)
+	return p.r.ToValue(p.implementation().basename(p.string(call.Argument(0), "path"), suffix))
 }
 
-// Require the path package
-func Require(runtime *goja.Runtime, module *goja.Object) {
-	s := &Path{r: runtime}
-	runtime.ToValue(s)
+// Extname is the name of the extension of the argument
+func (p *Path) Extname(call goja.FunctionCall) goja.Value {
+	return p.r.ToValue(p.implementation().extname(p.string(call.Argument(0), "path")))
+}
+
+// Format the path object, i.e. an object with the root, dir, base, ext and name properties
+func (p *Path) Format(call goja.FunctionCall) goja.Value {
+	object, ok := call.Argument(0).(*goja.Object)
+	if _, isFunction := goja.AssertFunction(call.Argument(0)); !ok || isFunction || object.ClassName() == "Array" {
+		panic(args.InvalidArgType(p.r, "pathObject", "of type object", call.Argument(0)))
+	}
+
+	// like Node, a property is used if it is truthy
+	property := func(name string) string {
+		if v := object.Get(name); v != nil && v.ToBoolean() {
+			return v.String()
+		}
+
+		return ""
+	}
+
+	return p.r.ToValue(p.implementation().format(Parsed{
+		Root: property("root"),
+		Dir:  property("dir"),
+		Base: property("base"),
+		Ext:  property("ext"),
+		Name: property("name"),
+	}))
+}
+
+// Parse the path into an object with the root, dir, base, ext and name properties
+func (p *Path) Parse(call goja.FunctionCall) goja.Value {
+	parsed := p.implementation().parse(p.string(call.Argument(0), "path"))
+	object := p.r.NewObject()
+	_ = object.Set("root", parsed.Root)
+	_ = object.Set("dir", parsed.Dir)
+	_ = object.Set("base", parsed.Base)
+	_ = object.Set("ext", parsed.Ext)
+	_ = object.Set("name", parsed.Name)
+
+	return object
+}
+
+// IsURL checks if the input is an absolute URL. A scheme of a single letter is a drive letter rather than a scheme.
+func (p *Path) IsURL(call goja.FunctionCall) goja.Value {
+	u, err := url.Parse(call.Argument(0).String())
 
-	p := runtime.NewObject()
+	return p.r.ToValue(err == nil && len(u.Scheme) > 1)
+}
+
+// set the functions and properties of the Path on the object
+func (p *Path) set(object *goja.Object) {
+	impl := p.implementation()
+	_ = object.Set("sep", impl.sep())
+	_ = object.Set("delimiter", impl.delimiter())
+	_ = object.Set("resolve", p.Resolve)
+	_ = object.Set("normalize", p.Normalize)
+	_ = object.Set("isAbsolute", p.IsAbsolute)
+	_ = object.Set("join", p.Join)
+	_ = object.Set("relative", p.Relative)
+	_ = object.Set("toNamespacedPath", p.ToNamespacedPath)
+	_ = object.Set("_makeLong", p.ToNamespacedPath)
+	_ = object.Set("dirname", p.Dirname)
+	_ = object.Set("basename", p.Basename)
+	_ = object.Set("extname", p.Extname)
+	_ = object.Set("format", p.Format)
+	_ = object.Set("parse", p.Parse)
+}
+
+// Require the path package, where the exports are the posix functions and path.win32 has the Windows semantics
+func Require(runtime *goja.Runtime, module *goja.Object) {
 	exports := module.Get("exports").(*goja.Object) //nolint:forcetypeassert // based on library reference implementation
-	_ = exports.Set("posix", p)
-	for _, o := range []*goja.Object{p, exports} { // set all methods to be equivalent for posix/non-posix
-		_ = o.Set("dirname", s.Dirname)
-		_ = o.Set("resolve", s.Resolve)
-		_ = o.Set("relative", s.Relative)
-		_ = o.Set("extname", s.Extname)
-		_ = o.Set("basename", s.Basename)
+	windows := runtime.NewObject()
+	(&Path{r: runtime}).set(exports)
+	(&Path{r: runtime, windows: true}).set(windows)
+
+	for _, o := range []*goja.Object{exports, windows} {
+		_ = o.Set("posix", exports)
+		_ = o.Set("win32", windows)
 	}
 }
 
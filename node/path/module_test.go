@@ -29,53 +29,92 @@ func TestPath_Resolve(t *testing.T) {
 	p := &Path{r: runtime}
 
 	// Act
-	res := p.Resolve(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue("testdata"), runtime.ToValue("file.yaml")}})
+	res := p.Resolve(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue("/wd/spec"), runtime.ToValue("../testdata"), runtime.ToValue("file.yaml")}})
 
 	// Assert
-	assert.Equal(t, "testdata/file.yaml", res.Export())
+	assert.Equal(t, "/wd/testdata/file.yaml", res.Export())
 }
 
-func TestPath_Relative_NotImplementedShouldPanic(t *testing.T) {
+func TestPath_Resolve_UsesProcessCwd(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	runtime := goja.New()
+	_, err := runtime.RunString(`var process = { cwd: function() { return '/wd'; } };`)
+	require.NoError(t, err)
 	p := &Path{r: runtime}
 
 	// Act
-	f := func() {
-		p.Relative(goja.FunctionCall{})
-	}
+	res := p.Resolve(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue("testdata"), runtime.ToValue("file.yaml")}})
 
 	// Assert
-	assert.Panics(t, f)
+	assert.Equal(t, "/wd/testdata/file.yaml", res.Export())
 }
 
-func TestPath_Extname(t *testing.T) {
+func TestPath_Relative(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	runtime := goja.New()
 	p := &Path{r: runtime}
 
 	// Act
-	res := p.Extname(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue("testdata/file.yaml")}})
+	res := p.Relative(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue("/wd/spec/ruleset.yaml"), runtime.ToValue("/wd/refs/schema.yaml")}})
 
 	// Assert
-	assert.Equal(t, ".yaml", res.Export())
+	assert.Equal(t, "../../refs/schema.yaml", res.Export())
 }
 
-func TestPath_IsURL(t *testing.T) {
+func TestPath_Relative_NotAStringShouldThrowTypeError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	r := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(r, registry, registry.Enable(r))
+
+	// Act
+	res, err := r.RunString(`try { path.relative('/wd', 1) } catch (e) { e.name + ' ' + e.code + ': ' + e.message }`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `TypeError ERR_INVALID_ARG_TYPE: The "to" argument must be of type string. Received type number (1)`, res.Export())
+}
+
+func TestPath_Extname(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	runtime := goja.New()
 	p := &Path{r: runtime}
 
 	// Act
-	f := func() {
-		p.IsURL(goja.FunctionCall{})
-	}
+	res := p.Extname(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue("testdata/file.yaml")}})
 
 	// Assert
-	assert.Panics(t, f)
+	assert.Equal(t, ".yaml", res.Export())
+}
+
+func TestPath_IsURL(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"https://example.com/openapi.yaml": true,
+		"file:///wd/openapi.yaml":          true,
+		"/wd/openapi.yaml":                 false,
+		"C:\\wd\\openapi.yaml":             false,
+		"openapi.yaml":                     false,
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			runtime := goja.New()
+			p := &Path{r: runtime}
+
+			// Act
+			res := p.IsURL(goja.FunctionCall{Arguments: []goja.Value{runtime.ToValue(input)}})
+
+			// Assert
+			assert.Equal(t, expected, res.Export())
+		})
+	}
 }
 
 func TestPath_Basename(t *testing.T) {
@@ -103,12 +142,62 @@ func TestRequire(t *testing.T) {
 	Require(r, module)
 
 	// Assert
-	assert.NotNil(t, exports.Get("posix"))
-	assert.NotNil(t, exports.Get("dirname"))
-	assert.NotNil(t, exports.Get("resolve"))
-	assert.NotNil(t, exports.Get("relative"))
-	assert.NotNil(t, exports.Get("extname"))
-	assert.NotNil(t, exports.Get("basename"))
+	assert.Equal(t, exports, exports.Get("posix"))
+	for _, name := range []string{"resolve", "normalize", "isAbsolute", "join", "relative", "toNamespacedPath", "dirname", "basename", "extname", "format", "parse"} {
+		assert.NotNil(t, exports.Get(name), name)
+	}
+	assert.Equal(t, "/", exports.Get("sep").String())
+	assert.Equal(t, ":", exports.Get("delimiter").String())
+	win32 := exports.Get("win32").ToObject(r)
+	assert.Equal(t, "\\", win32.Get("sep").String())
+	assert.Equal(t, ";", win32.Get("delimiter").String())
+	assert.Equal(t, win32, win32.Get("win32"))
+	assert.Equal(t, exports, win32.Get("posix"))
+}
+
+func TestRequire_Win32(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	r := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(r, registry, registry.Enable(r))
+
+	// Act
+	res, err := r.RunString(`[path.win32.join('C:\\wd', '../spec', 'openapi.yaml'), path.win32.relative('C:\\wd\\spec', 'c:/WD/refs')]`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{"C:\\spec\\openapi.yaml", "..\\refs"}, res.Export())
+}
+
+func TestPath_ParseFormat(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	r := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(r, registry, registry.Enable(r))
+
+	// Act
+	res, err := r.RunString(`var parsed = path.parse('/wd/spec/openapi.yaml'); [parsed.root, parsed.dir, parsed.base, parsed.ext, parsed.name, path.format(parsed), path.format({ dir: 'spec', name: 'openapi', ext: 'json' })]`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{"/", "/wd/spec", "openapi.yaml", ".yaml", "openapi", "/wd/spec/openapi.yaml", "spec/openapi.json"}, res.Export())
+}
+
+func TestPath_ToNamespacedPath_NotAStringIsReturned(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	r := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(r, registry, registry.Enable(r))
+
+	// Act
+	res, err := r.RunString(`[path.toNamespacedPath(1), path.win32.toNamespacedPath('C:\\wd')]`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(1), "\\\\?\\C:\\wd"}, res.Export())
 }
 
 func TestEnable(t *testing.T) {
@@ -0,0 +1,490 @@
+package path
+
+import "strings"
+
+// Parsed path, i.e. the object returned by path.parse and accepted by path.format
+type Parsed struct {
+	Root string
+	Dir  string
+	Base string
+	Ext  string
+	Name string
+}
+
+// posix path functions, ported from the posix object of Node's lib/path.js. Paths are indexed by byte rather than by
+// UTF-16 code unit, which is equivalent since the separators and dots are ASCII and never part of a multibyte rune.
+type posix struct {
+	// cwd resolves relative paths, i.e. process.cwd()
+	cwd func() string
+}
+
+// isPosixPathSeparator is only the forward slash
+func isPosixPathSeparator(c byte) bool {
+	return c == '/'
+}
+
+// normalizeString resolves the '.' and '..' segments of the path without a root, where '..' segments that cannot be
+// resolved are kept if allowAboveRoot
+func normalizeString(path string, allowAboveRoot bool, separator string, isPathSeparator func(byte) bool) string {
+	res := ""
+	lastSegmentLength := 0
+	lastSlash := -1
+	dots := 0
+	var code byte
+	for i := 0; i <= len(path); i++ {
+		switch {
+		case i < len(path):
+			code = path[i]
+		case isPathSeparator(code):
+			return res
+		default:
+			code = '/'
+		}
+
+		switch {
+		case isPathSeparator(code):
+			switch {
+			case lastSlash == i-1 || dots == 1:
+				// NOOP
+			case dots == 2: //nolint:mnd // a '..' segment
+				if len(res) < 2 || lastSegmentLength != 2 || !strings.HasSuffix(res, "..") {
+					if len(res) > 2 { //nolint:mnd // more than a '..' segment
+						if lastSlashIndex := strings.LastIndex(res, separator); lastSlashIndex == -1 {
+							res = ""
+							lastSegmentLength = 0
+						} else {
+							res = res[:lastSlashIndex]
+							lastSegmentLength = len(res) - 1 - strings.LastIndex(res, separator)
+						}
+						lastSlash = i
+						dots = 0
+
+						continue
+					} else if len(res) != 0 {
+						res = ""
+						lastSegmentLength = 0
+						lastSlash = i
+						dots = 0
+
+						continue
+					}
+				}
+				if allowAboveRoot {
+					if len(res) > 0 {
+						res += separator + ".."
+					} else {
+						res = ".."
+					}
+					lastSegmentLength = 2
+				}
+			default:
+				if len(res) > 0 {
+					res += separator + path[lastSlash+1:i]
+				} else {
+					res = path[lastSlash+1 : i]
+				}
+				lastSegmentLength = i - lastSlash - 1
+			}
+			lastSlash = i
+			dots = 0
+		case code == '.' && dots != -1:
+			dots++
+		default:
+			dots = -1
+		}
+	}
+
+	return res
+}
+
+// basename of the path after start (e.g. a drive letter), without the suffix unless the suffix is the whole basename
+func basename(path string, suffix string, start int, isPathSeparator func(byte) bool) string {
+	end := -1
+	matchedSlash := true
+	if suffix != "" && len(suffix) <= len(path) {
+		if suffix == path {
+			return ""
+		}
+
+		extIdx := len(suffix) - 1
+		firstNonSlashEnd := -1
+		for i := len(path) - 1; i >= start; i-- {
+			code := path[i]
+			if isPathSeparator(code) {
+				if !matchedSlash {
+					start = i + 1
+
+					break
+				}
+
+				continue
+			}
+
+			if firstNonSlashEnd == -1 {
+				matchedSlash = false
+				firstNonSlashEnd = i + 1
+			}
+
+			if extIdx >= 0 {
+				if code == suffix[extIdx] {
+					if extIdx--; extIdx == -1 {
+						end = i
+					}
+				} else {
+					extIdx = -1
+					end = firstNonSlashEnd
+				}
+			}
+		}
+
+		if start == end {
+			end = firstNonSlashEnd
+		} else if end == -1 {
+			end = len(path)
+		}
+
+		return path[start:end]
+	}
+
+	for i := len(path) - 1; i >= start; i-- {
+		if isPathSeparator(path[i]) {
+			if !matchedSlash {
+				start = i + 1
+
+				break
+			}
+		} else if end == -1 {
+			matchedSlash = false
+			end = i + 1
+		}
+	}
+
+	if end == -1 {
+		return ""
+	}
+
+	return path[start:end]
+}
+
+// extension of the last segment of the path from index start, returning the start of the segment (startPart if the
+// segment starts at start), the start of the extension (-1 if the segment has no extension) and the end of the
+// segment (-1 if there is no segment). A leading dot (e.g. '.profile') and the '..' segment are not an extension.
+func extension(path string, start int, startPart int, isPathSeparator func(byte) bool) (int, int, int) {
+	startDot, end := -1, -1
+	matchedSlash := true
+	preDotState := 0 // 0 if no dot was seen, 1 if a dot was seen and -1 if another character follows the dot
+	for i := len(path) - 1; i >= start; i-- {
+		code := path[i]
+		if isPathSeparator(code) {
+			if !matchedSlash {
+				startPart = i + 1
+
+				break
+			}
+
+			continue
+		}
+
+		if end == -1 {
+			matchedSlash = false
+			end = i + 1
+		}
+
+		if code == '.' {
+			if startDot == -1 {
+				startDot = i
+			} else if preDotState != 1 {
+				preDotState = 1
+			}
+		} else if startDot != -1 {
+			preDotState = -1
+		}
+	}
+
+	if startDot == -1 || end == -1 || preDotState == 0 || (preDotState == 1 && startDot == end-1 && startDot == startPart+1) {
+		startDot = -1
+	}
+
+	return startPart, startDot, end
+}
+
+// format the Parsed path, where the Dir takes precedence over the Root and the Base over the Name and Ext
+func format(separator string, parsed Parsed) string {
+	dir := parsed.Dir
+	if dir == "" {
+		dir = parsed.Root
+	}
+
+	base := parsed.Base
+	if base == "" {
+		base = parsed.Name
+		if parsed.Ext != "" && parsed.Ext[0] != '.' {
+			base += "."
+		}
+		base += parsed.Ext
+	}
+
+	switch dir {
+	case "":
+		return base
+	case parsed.Root:
+		return dir + base
+	default:
+		return dir + separator + base
+	}
+}
+
+// sep is the segment separator
+func (posix) sep() string {
+	return "/"
+}
+
+// delimiter separates the paths of e.g. $PATH
+func (posix) delimiter() string {
+	return ":"
+}
+
+// resolve the paths from right to left into an absolute path, prepending the cwd until the path is absolute
+func (p posix) resolve(paths ...string) string {
+	resolvedPath := ""
+	resolvedAbsolute := false
+	for i := len(paths) - 1; i >= -1 && !resolvedAbsolute; i-- {
+		var path string
+		if i >= 0 {
+			path = paths[i]
+		} else {
+			path = p.cwd()
+		}
+
+		if path == "" {
+			continue
+		}
+
+		resolvedPath = path + "/" + resolvedPath
+		resolvedAbsolute = path[0] == '/'
+	}
+
+	resolvedPath = normalizeString(resolvedPath, !resolvedAbsolute, "/", isPosixPathSeparator)
+	if resolvedAbsolute {
+		return "/" + resolvedPath
+	}
+
+	if resolvedPath == "" {
+		return "."
+	}
+
+	return resolvedPath
+}
+
+// normalize the path, resolving the '.' and '..' segments and keeping a trailing separator
+func (posix) normalize(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	isAbsolute := path[0] == '/'
+	trailingSeparator := path[len(path)-1] == '/'
+	path = normalizeString(path, !isAbsolute, "/", isPosixPathSeparator)
+	if path == "" {
+		switch {
+		case isAbsolute:
+			return "/"
+		case trailingSeparator:
+			return "./"
+		default:
+			return "."
+		}
+	}
+
+	if trailingSeparator {
+		path += "/"
+	}
+
+	if isAbsolute {
+		return "/" + path
+	}
+
+	return path
+}
+
+// isAbsolute if the path starts with a separator
+func (posix) isAbsolute(path string) bool {
+	return path != "" && path[0] == '/'
+}
+
+// join the non-empty paths and normalize the result
+func (p posix) join(paths ...string) string {
+	var parts []string
+	for _, path := range paths {
+		if path != "" {
+			parts = append(parts, path)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "."
+	}
+
+	return p.normalize(strings.Join(parts, "/"))
+}
+
+// relative path from 'from' to 'to' after resolving both
+func (p posix) relative(from string, to string) string {
+	if from == to {
+		return ""
+	}
+
+	from, to = p.resolve(from), p.resolve(to)
+	if from == to {
+		return ""
+	}
+
+	const fromStart, toStart = 1, 1
+	fromEnd := len(from)
+	fromLen := fromEnd - fromStart
+	toLen := len(to) - toStart
+	length := min(fromLen, toLen)
+	lastCommonSep := -1
+	i := 0
+	for ; i < length; i++ {
+		fromCode := from[fromStart+i]
+		if fromCode != to[toStart+i] {
+			break
+		} else if fromCode == '/' {
+			lastCommonSep = i
+		}
+	}
+
+	if i == length {
+		if toLen > length {
+			if to[toStart+i] == '/' {
+				// from is the exact base path of to, e.g. from='/foo/bar'; to='/foo/bar/baz'
+				return to[toStart+i+1:]
+			}
+			if i == 0 {
+				// from is the root, e.g. from='/'; to='/foo'
+				return to[toStart+i:]
+			}
+		} else if fromLen > length {
+			if from[fromStart+i] == '/' {
+				// to is the exact base path of from, e.g. from='/foo/bar/baz'; to='/foo/bar'
+				lastCommonSep = i
+			} else if i == 0 {
+				// to is the root, e.g. from='/foo/bar'; to='/'
+				lastCommonSep = 0
+			}
+		}
+	}
+
+	out := ""
+	for i = fromStart + lastCommonSep + 1; i <= fromEnd; i++ {
+		if i == fromEnd || from[i] == '/' {
+			if out == "" {
+				out = ".."
+			} else {
+				out += "/.."
+			}
+		}
+	}
+
+	return out + to[toStart+lastCommonSep:]
+}
+
+// toNamespacedPath is a noop on posix
+func (posix) toNamespacedPath(path string) string {
+	return path
+}
+
+// dirname of the path, i.e. the path without its last segment
+func (posix) dirname(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	hasRoot := path[0] == '/'
+	end := -1
+	matchedSlash := true
+	for i := len(path) - 1; i >= 1; i-- {
+		if path[i] == '/' {
+			if !matchedSlash {
+				end = i
+
+				break
+			}
+		} else {
+			matchedSlash = false
+		}
+	}
+
+	switch {
+	case end == -1 && hasRoot:
+		return "/"
+	case end == -1:
+		return "."
+	case hasRoot && end == 1:
+		return "//"
+	default:
+		return path[:end]
+	}
+}
+
+// basename is the last segment of the path without the suffix
+func (posix) basename(path string, suffix string) string {
+	return basename(path, suffix, 0, isPosixPathSeparator)
+}
+
+// extname is the extension of the last segment of the path, e.g. '.yaml'
+func (posix) extname(path string) string {
+	_, startDot, end := extension(path, 0, 0, isPosixPathSeparator)
+	if startDot == -1 {
+		return ""
+	}
+
+	return path[startDot:end]
+}
+
+// format the Parsed path
+func (posix) format(parsed Parsed) string {
+	return format("/", parsed)
+}
+
+// parse the path into its root, dir, base, ext and name
+func (posix) parse(path string) Parsed {
+	var ret Parsed
+	if path == "" {
+		return ret
+	}
+
+	isAbsolute := path[0] == '/'
+	rootEnd := 0
+	if isAbsolute {
+		ret.Root = "/"
+		rootEnd = 1
+	}
+
+	startPart, startDot, end := extension(path, rootEnd, 0, isPosixPathSeparator)
+	if end != -1 {
+		start := startPart
+		if startPart == 0 && isAbsolute {
+			start = 1
+		}
+
+		if startDot == -1 {
+			ret.Base = path[start:end]
+			ret.Name = ret.Base
+		} else {
+			ret.Name = path[start:startDot]
+			ret.Base = path[start:end]
+			ret.Ext = path[startDot:end]
+		}
+	}
+
+	switch {
+	case startPart > 0:
+		ret.Dir = path[:startPart-1]
+	case isAbsolute:
+		ret.Dir = "/"
+	}
+
+	return ret
+}
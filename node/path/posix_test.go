@@ -0,0 +1,495 @@
+package path
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The posix tests are the vectors of Node's test/parallel/test-path-*.js, with the expected values as returned by
+// Node for the cwd /wd
+
+func TestPosix_Dirname(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"/a/b/":  "/a",
+		"/a/b":   "/a",
+		"/a":     "/",
+		"":       ".",
+		"/":      "/",
+		"////":   "/",
+		"//a":    "//",
+		"foo":    ".",
+		"a/":     ".",
+		"a//b":   "a/",
+		"/a/b//": "/a",
+		"//a/b":  "//a",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.dirname(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestPosix_Basename(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path     string
+		suffix   string
+		expected string
+	}{
+		{path: "/dir/basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "/basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "basename.ext/", suffix: "", expected: "basename.ext"},
+		{path: "basename.ext//", suffix: "", expected: "basename.ext"},
+		{path: "aaa/bbb", suffix: "/bbb", expected: "bbb"},
+		{path: "aaa/bbb", suffix: "a/bbb", expected: "bbb"},
+		{path: "aaa/bbb", suffix: "bbb", expected: "bbb"},
+		{path: "aaa/bbb//", suffix: "bbb", expected: "bbb"},
+		{path: "aaa/bbb", suffix: "bb", expected: "b"},
+		{path: "aaa/bbb", suffix: "b", expected: "bb"},
+		{path: "/aaa/bbb", suffix: "/bbb", expected: "bbb"},
+		{path: "/aaa/bbb", suffix: "a/bbb", expected: "bbb"},
+		{path: "/aaa/bbb", suffix: "bbb", expected: "bbb"},
+		{path: "/aaa/bbb//", suffix: "bbb", expected: "bbb"},
+		{path: "/aaa/bbb", suffix: "bb", expected: "b"},
+		{path: "/aaa/bbb", suffix: "b", expected: "bb"},
+		{path: "/aaa/bbb", suffix: "", expected: "bbb"},
+		{path: "/aaa/", suffix: "", expected: "aaa"},
+		{path: "/aaa/b", suffix: "", expected: "b"},
+		{path: "/a/b", suffix: "", expected: "b"},
+		{path: "//a", suffix: "", expected: "a"},
+		{path: "", suffix: "", expected: ""},
+		{path: "\\dir\\basename.ext", suffix: "", expected: "\\dir\\basename.ext"},
+		{path: "\\basename.ext", suffix: "", expected: "\\basename.ext"},
+		{path: "basename.ext\\", suffix: "", expected: "basename.ext\\"},
+		{path: "foo", suffix: "", expected: "foo"},
+		{path: "file.js", suffix: ".js", expected: "file"},
+		{path: "file.js", suffix: "file.js", expected: ""},
+		{path: "a", suffix: "a", expected: ""},
+		{path: "/a/b/c.txt", suffix: "c.txt", expected: "c.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.basename(tt.path, tt.suffix)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestPosix_Extname(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"":                   "",
+		"/path/to/file":      "",
+		"/path/to/file.ext":  ".ext",
+		"/path.to/file.ext":  ".ext",
+		"/path.to/file":      "",
+		"/path.to/.file":     "",
+		"/path.to/.file.ext": ".ext",
+		"/path/to/f.ext":     ".ext",
+		"/path/to/..ext":     ".ext",
+		"/path/to/..":        "",
+		"file":               "",
+		"file.ext":           ".ext",
+		".file":              "",
+		".file.ext":          ".ext",
+		"/file":              "",
+		"/file.ext":          ".ext",
+		"/.file":             "",
+		"/.file.ext":         ".ext",
+		".path/file.ext":     ".ext",
+		"file.ext.ext":       ".ext",
+		"file.":              ".",
+		".":                  "",
+		"./":                 "",
+		".file.":             ".",
+		".file..":            ".",
+		"..":                 "",
+		"../":                "",
+		"..file.ext":         ".ext",
+		"..file":             ".file",
+		"..file.":            ".",
+		"..file..":           ".",
+		"...":                ".",
+		"...ext":             ".ext",
+		"....":               ".",
+		"file.ext/":          ".ext",
+		"file.ext//":         ".ext",
+		"file/":              "",
+		"file//":             "",
+		"file./":             ".",
+		"file.//":            ".",
+		".\\":                "",
+		"..\\":               ".\\",
+		"file.ext\\":         ".ext\\",
+		"file.ext\\\\":       ".ext\\\\",
+		"file\\":             "",
+		"file\\\\":           "",
+		"file.\\":            ".\\",
+		"file.\\\\":          ".\\\\",
+		"C:file.ext":         ".ext",
+		"C:.ext":             ".ext",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.extname(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestPosix_Normalize(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"./fixtures///b/../b/c.js":                 "fixtures/b/c.js",
+		"/foo/../../../bar":                        "/bar",
+		"a//b//../b":                               "a/b",
+		"a//b//./c":                                "a/b/c",
+		"a//b//.":                                  "a/b",
+		"/a/b/c/../../../x/y/z":                    "/x/y/z",
+		"///..//./foo/.//bar":                      "/foo/bar",
+		"bar/foo../../":                            "bar/",
+		"bar/foo../..":                             "bar",
+		"bar/foo../../baz":                         "bar/baz",
+		"bar/foo../":                               "bar/foo../",
+		"bar/foo..":                                "bar/foo..",
+		"../foo../../../bar":                       "../../bar",
+		"../.../.././.../../../bar":                "../../bar",
+		"../../../foo/../../../bar":                "../../../../../bar",
+		"../../../foo/../../../bar/../../":         "../../../../../../",
+		"../foobar/barfoo/foo/../../../bar/../../": "../../",
+		"../.../../foobar/../../../bar/../../baz":  "../../../../baz",
+		"foo/bar\\baz":                             "foo/bar\\baz",
+		"":                                         ".",
+		".":                                        ".",
+		"./":                                       "./",
+		"/":                                        "/",
+		"//":                                       "/",
+		"a/..":                                     ".",
+		"a/../":                                    "./",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.normalize(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestPosix_Join(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		paths    []string
+		expected string
+	}{
+		{paths: []string{".", "x/b", "..", "/b/c.js"}, expected: "x/b/c.js"},
+		{paths: []string{}, expected: "."},
+		{paths: []string{"/.", "x/b", "..", "/b/c.js"}, expected: "/x/b/c.js"},
+		{paths: []string{"/foo", "../../../bar"}, expected: "/bar"},
+		{paths: []string{"foo", "../../../bar"}, expected: "../../bar"},
+		{paths: []string{"foo/", "../../../bar"}, expected: "../../bar"},
+		{paths: []string{"foo/x", "../../../bar"}, expected: "../bar"},
+		{paths: []string{"foo/x", "./bar"}, expected: "foo/x/bar"},
+		{paths: []string{"foo/x/", "./bar"}, expected: "foo/x/bar"},
+		{paths: []string{"foo/x/", ".", "bar"}, expected: "foo/x/bar"},
+		{paths: []string{"./"}, expected: "./"},
+		{paths: []string{".", "./"}, expected: "./"},
+		{paths: []string{".", ".", "."}, expected: "."},
+		{paths: []string{".", "./", "."}, expected: "."},
+		{paths: []string{".", "/./", "."}, expected: "."},
+		{paths: []string{".", "/////./", "."}, expected: "."},
+		{paths: []string{"."}, expected: "."},
+		{paths: []string{"", "."}, expected: "."},
+		{paths: []string{"", "foo"}, expected: "foo"},
+		{paths: []string{"foo", "/bar"}, expected: "foo/bar"},
+		{paths: []string{"", "/foo"}, expected: "/foo"},
+		{paths: []string{"", "", "/foo"}, expected: "/foo"},
+		{paths: []string{"", "", "foo"}, expected: "foo"},
+		{paths: []string{"foo", ""}, expected: "foo"},
+		{paths: []string{"foo/", ""}, expected: "foo/"},
+		{paths: []string{"foo", "", "/bar"}, expected: "foo/bar"},
+		{paths: []string{"./", "..", "/foo"}, expected: "../foo"},
+		{paths: []string{"./", "..", "..", "/foo"}, expected: "../../foo"},
+		{paths: []string{".", "..", "..", "/foo"}, expected: "../../foo"},
+		{paths: []string{"", "..", "..", "/foo"}, expected: "../../foo"},
+		{paths: []string{"/"}, expected: "/"},
+		{paths: []string{"/", "."}, expected: "/"},
+		{paths: []string{"/", ".."}, expected: "/"},
+		{paths: []string{"/", "..", ".."}, expected: "/"},
+		{paths: []string{""}, expected: "."},
+		{paths: []string{"", ""}, expected: "."},
+		{paths: []string{" /foo"}, expected: " /foo"},
+		{paths: []string{" ", "foo"}, expected: " /foo"},
+		{paths: []string{" ", "."}, expected: " "},
+		{paths: []string{" ", "/"}, expected: " /"},
+		{paths: []string{" ", ""}, expected: " "},
+		{paths: []string{"/", "foo"}, expected: "/foo"},
+		{paths: []string{"/", "/foo"}, expected: "/foo"},
+		{paths: []string{"/", "//foo"}, expected: "/foo"},
+		{paths: []string{"/", "", "/foo"}, expected: "/foo"},
+		{paths: []string{"", "/", "foo"}, expected: "/foo"},
+		{paths: []string{"", "/", "/foo"}, expected: "/foo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.paths, ","), func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.join(tt.paths...)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestPosix_Resolve(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		paths    []string
+		expected string
+	}{
+		{paths: []string{"/var/lib", "../", "file/"}, expected: "/var/file"},
+		{paths: []string{"/var/lib", "/../", "file/"}, expected: "/file"},
+		{paths: []string{"a/b/c/", "../../.."}, expected: "/wd"},
+		{paths: []string{"."}, expected: "/wd"},
+		{paths: []string{"/some/dir", ".", "/absolute/"}, expected: "/absolute"},
+		{paths: []string{"/foo/tmp.3/", "../tmp.3/cycles/root.js"}, expected: "/foo/tmp.3/cycles/root.js"},
+		{paths: []string{}, expected: "/wd"},
+		{paths: []string{""}, expected: "/wd"},
+		{paths: []string{"testdata", "file.yaml"}, expected: "/wd/testdata/file.yaml"},
+		{paths: []string{"/foo", "bar", "baz/asdf", "quux", ".."}, expected: "/foo/bar/baz/asdf"},
+		{paths: []string{"foo/bar", "/tmp/file/", "..", "a/../subfile"}, expected: "/tmp/subfile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.paths, ","), func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.resolve(tt.paths...)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestPosix_Relative(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{from: "/var/lib", to: "/var", expected: ".."},
+		{from: "/var/lib", to: "/bin", expected: "../../bin"},
+		{from: "/var/lib", to: "/var/lib", expected: ""},
+		{from: "/var/lib", to: "/var/apache", expected: "../apache"},
+		{from: "/var/", to: "/var/lib", expected: "lib"},
+		{from: "/", to: "/var/lib", expected: "var/lib"},
+		{from: "/foo/test", to: "/foo/test/bar/package.json", expected: "bar/package.json"},
+		{from: "/Users/a/web/b/test/mails", to: "/Users/a/web/b", expected: "../.."},
+		{from: "/foo/bar/baz-quux", to: "/foo/bar/baz", expected: "../baz"},
+		{from: "/foo/bar/baz", to: "/foo/bar/baz-quux", expected: "../baz-quux"},
+		{from: "/baz-quux", to: "/baz", expected: "../baz"},
+		{from: "/baz", to: "/baz-quux", expected: "../baz-quux"},
+		{from: "/page1/page2/foo", to: "/", expected: "../../.."},
+		{from: "", to: "", expected: ""},
+		{from: "a", to: "", expected: ".."},
+		{from: "", to: "a", expected: "a"},
+		{from: "spec/ruleset.yaml", to: "spec/refs/schema.yaml", expected: "../refs/schema.yaml"},
+		{from: "/wd", to: "openapi.yaml", expected: "openapi.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+","+tt.to, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.relative(tt.from, tt.to)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestPosix_IsAbsolute(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"/home/foo":    true,
+		"/home/foo/..": true,
+		"bar/":         false,
+		"./baz":        false,
+		"":             false,
+		"/":            true,
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.isAbsolute(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestPosix_Parse(t *testing.T) {
+	t.Parallel()
+	tests := map[string]Parsed{
+		"/home/user/dir/file.txt":              {Root: "/", Dir: "/home/user/dir", Base: "file.txt", Ext: ".txt", Name: "file"},
+		"/home/user/a dir/another File.zip":    {Root: "/", Dir: "/home/user/a dir", Base: "another File.zip", Ext: ".zip", Name: "another File"},
+		"/home/user/a dir//another&File.":      {Root: "/", Dir: "/home/user/a dir/", Base: "another&File.", Ext: ".", Name: "another&File"},
+		"/home/user/a$$$dir//another File.zip": {Root: "/", Dir: "/home/user/a$$$dir/", Base: "another File.zip", Ext: ".zip", Name: "another File"},
+		"user/dir/another File.zip":            {Root: "", Dir: "user/dir", Base: "another File.zip", Ext: ".zip", Name: "another File"},
+		"file":                                 {Root: "", Dir: "", Base: "file", Ext: "", Name: "file"},
+		".\\file":                              {Root: "", Dir: "", Base: ".\\file", Ext: "", Name: ".\\file"},
+		"./file":                               {Root: "", Dir: ".", Base: "file", Ext: "", Name: "file"},
+		"C:\\foo":                              {Root: "", Dir: "", Base: "C:\\foo", Ext: "", Name: "C:\\foo"},
+		"/":                                    {Root: "/", Dir: "/", Base: "", Ext: "", Name: ""},
+		"":                                     {Root: "", Dir: "", Base: "", Ext: "", Name: ""},
+		"//":                                   {Root: "/", Dir: "/", Base: "", Ext: "", Name: ""},
+		"///":                                  {Root: "/", Dir: "/", Base: "", Ext: "", Name: ""},
+		"/foo///":                              {Root: "/", Dir: "/", Base: "foo", Ext: "", Name: "foo"},
+		"/foo///bar.baz":                       {Root: "/", Dir: "/foo//", Base: "bar.baz", Ext: ".baz", Name: "bar"},
+		"/x/y/":                                {Root: "/", Dir: "/x", Base: "y", Ext: "", Name: "y"},
+		"/..":                                  {Root: "/", Dir: "/", Base: "..", Ext: ".", Name: "."},
+		"/.":                                   {Root: "/", Dir: "/", Base: ".", Ext: "", Name: "."},
+		".":                                    {Root: "", Dir: "", Base: ".", Ext: "", Name: "."},
+		"..":                                   {Root: "", Dir: "", Base: "..", Ext: "", Name: ".."},
+		"./":                                   {Root: "", Dir: "", Base: ".", Ext: "", Name: "."},
+		"../":                                  {Root: "", Dir: "", Base: "..", Ext: "", Name: ".."},
+		".file":                                {Root: "", Dir: "", Base: ".file", Ext: "", Name: ".file"},
+		"file.":                                {Root: "", Dir: "", Base: "file.", Ext: ".", Name: "file"},
+		"/a/b.tar.gz":                          {Root: "/", Dir: "/a", Base: "b.tar.gz", Ext: ".gz", Name: "b.tar"},
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.parse(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestPosix_Format(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		parsed   Parsed
+		expected string
+	}{
+		{parsed: Parsed{Root: "/", Dir: "/", Base: ""}, expected: "/"},
+		{parsed: Parsed{Root: "/", Dir: "/", Base: "a"}, expected: "/a"},
+		{parsed: Parsed{Dir: "/a", Base: "b"}, expected: "/a/b"},
+		{parsed: Parsed{Dir: "some/dir", Name: "x", Ext: ".js"}, expected: "some/dir/x.js"},
+		{parsed: Parsed{Name: "x", Ext: "js"}, expected: "x.js"},
+		{parsed: Parsed{Name: "x", Ext: "."}, expected: "x."},
+		{parsed: Parsed{Name: "x", Ext: ".."}, expected: "x.."},
+		{parsed: Parsed{Root: "/", Name: "x"}, expected: "/x"},
+		{parsed: Parsed{Base: "a.js", Name: "b", Ext: ".ts"}, expected: "a.js"},
+		{parsed: Parsed{}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.format(tt.parsed)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestPosix_ToNamespacedPath(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"":                          "",
+		"C:\\foo":                   "C:\\foo",
+		"C:/foo":                    "C:/foo",
+		"\\\\foo\\bar":              "\\\\foo\\bar",
+		"//foo//bar":                "//foo//bar",
+		"\\\\?\\foo":                "\\\\?\\foo",
+		"c:":                        "c:",
+		"foo":                       "foo",
+		"\\\\.\\pipe\\x":            "\\\\.\\pipe\\x",
+		"\\\\?\\c:\\Windows/System": "\\\\?\\c:\\Windows/System",
+		"C:\\foo\\..":               "C:\\foo\\..",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := posix{cwd: func() string { return "/wd" }}
+
+			// Act
+			res := p.toNamespacedPath(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
@@ -0,0 +1,639 @@
+package path
+
+import (
+	"slices"
+	"strings"
+)
+
+// windowsReservedNames are the DOS device names, which Windows interprets as a device rather than a file
+var windowsReservedNames = []string{
+	"CON", "PRN", "AUX", "NUL",
+	"COM1", "COM2", "COM3", "COM4", "COM5", "COM6", "COM7", "COM8", "COM9",
+	"LPT1", "LPT2", "LPT3", "LPT4", "LPT5", "LPT6", "LPT7", "LPT8", "LPT9",
+}
+
+// win32 path functions, ported from the win32 object of Node's lib/path.js. Both the backslash and the forward slash
+// are separators and a path may have a drive letter (e.g. 'C:') or UNC (e.g. '\\server\share') root.
+type win32 struct {
+	// cwd resolves relative paths, i.e. process.cwd(). The drive specific working directories of Windows (the '=C:'
+	// environment variables) are not supported.
+	cwd func() string
+}
+
+// isPathSeparator is a backslash or forward slash
+func isPathSeparator(c byte) bool {
+	return c == '/' || c == '\\'
+}
+
+// isWindowsDeviceRoot is a drive letter
+func isWindowsDeviceRoot(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// hasDeviceRoot if the path starts with a drive letter and colon, e.g. 'C:'
+func hasDeviceRoot(path string) bool {
+	return len(path) >= 2 && isWindowsDeviceRoot(path[0]) && path[1] == ':'
+}
+
+// isWindowsReservedName if the path before the colon is a reserved name. Like Node, a colonIndex of -1 slices off the
+// last character.
+func isWindowsReservedName(path string, colonIndex int) bool {
+	end := colonIndex
+	if end < 0 {
+		end = max(len(path)+end, 0)
+	}
+
+	return slices.Contains(windowsReservedNames, strings.ToUpper(path[:end]))
+}
+
+// uncRoot matches the server and share of a path starting with two separators (e.g. '\\server\share'), returning the
+// server, the start and the end of the share. ok is false if the path has no server and share.
+func uncRoot(path string) (server string, last int, j int, ok bool) {
+	j, last = 2, 2
+	for j < len(path) && !isPathSeparator(path[j]) {
+		j++
+	}
+
+	if j == len(path) || j == last {
+		return "", 0, 0, false
+	}
+
+	server = path[last:j]
+	last = j
+	for j < len(path) && isPathSeparator(path[j]) {
+		j++
+	}
+
+	if j == len(path) || j == last {
+		return "", 0, 0, false
+	}
+
+	last = j
+	for j < len(path) && !isPathSeparator(path[j]) {
+		j++
+	}
+
+	return server, last, j, true
+}
+
+// sep is the segment separator
+func (win32) sep() string {
+	return "\\"
+}
+
+// delimiter separates the paths of e.g. %PATH%
+func (win32) delimiter() string {
+	return ";"
+}
+
+// resolve the paths from right to left into an absolute path with a device, prepending the cwd until the path is
+// absolute
+func (w win32) resolve(paths ...string) string {
+	resolvedDevice := ""
+	resolvedTail := ""
+	resolvedAbsolute := false
+	for i := len(paths) - 1; i >= -1; i-- {
+		var path string
+		switch {
+		case i >= 0:
+			if path = paths[i]; path == "" {
+				continue
+			}
+		case resolvedDevice == "":
+			path = w.cwd()
+		default:
+			// the cwd must point to the resolved device, otherwise default to the root of the device
+			path = w.cwd()
+			if len(path) > 2 && strings.ToLower(path[:2]) != strings.ToLower(resolvedDevice) && path[2] == '\\' {
+				path = resolvedDevice + "\\"
+			}
+		}
+
+		rootEnd := 0
+		device := ""
+		isAbsolute := false
+		switch {
+		case len(path) == 1:
+			if isPathSeparator(path[0]) {
+				rootEnd = 1
+				isAbsolute = true
+			}
+		case isPathSeparator(path[0]):
+			isAbsolute = true
+			if isPathSeparator(path[1]) {
+				if server, last, j, ok := uncRoot(path); ok {
+					device = "\\\\" + server + "\\" + path[last:j]
+					rootEnd = j
+				}
+			} else {
+				rootEnd = 1
+			}
+		case hasDeviceRoot(path):
+			device = path[:2]
+			rootEnd = 2
+			if len(path) > 2 && isPathSeparator(path[2]) {
+				isAbsolute = true
+				rootEnd = 3
+			}
+		}
+
+		if device != "" {
+			if resolvedDevice == "" {
+				resolvedDevice = device
+			} else if strings.ToLower(device) != strings.ToLower(resolvedDevice) {
+				// the path points to another device, so it is not applicable
+				continue
+			}
+		}
+
+		if resolvedAbsolute {
+			if resolvedDevice != "" {
+				break
+			}
+		} else {
+			resolvedTail = path[rootEnd:] + "\\" + resolvedTail
+			resolvedAbsolute = isAbsolute
+			if isAbsolute && resolvedDevice != "" {
+				break
+			}
+		}
+	}
+
+	resolvedTail = normalizeString(resolvedTail, !resolvedAbsolute, "\\", isPathSeparator)
+	if resolvedAbsolute {
+		return resolvedDevice + "\\" + resolvedTail
+	}
+
+	if resolved := resolvedDevice + resolvedTail; resolved != "" {
+		return resolved
+	}
+
+	return "."
+}
+
+// normalize the path, resolving the '.' and '..' segments and keeping a trailing separator. A relative path that
+// would be interpreted as a device (e.g. 'x/../C:/Windows' or 'CON:') is prefixed with '.\'.
+func (win32) normalize(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	if len(path) == 1 {
+		if path[0] == '/' {
+			return "\\"
+		}
+
+		return path
+	}
+
+	rootEnd := 0
+	device := ""
+	hasDevice := false
+	isAbsolute := false
+	if isPathSeparator(path[0]) {
+		isAbsolute = true
+		if isPathSeparator(path[1]) {
+			if server, last, j, ok := uncRoot(path); ok {
+				if j == len(path) {
+					// only a UNC root, so there is nothing left to normalize
+					return "\\\\" + server + "\\" + path[last:] + "\\"
+				}
+
+				device, hasDevice = "\\\\"+server+"\\"+path[last:j], true
+				rootEnd = j
+			}
+		} else {
+			rootEnd = 1
+		}
+	} else if colonIndex := strings.IndexByte(path, ':'); colonIndex > 0 {
+		if isWindowsDeviceRoot(path[0]) && colonIndex == 1 {
+			device, hasDevice = path[:2], true
+			rootEnd = 2
+			if len(path) > 2 && isPathSeparator(path[2]) {
+				isAbsolute = true
+				rootEnd = 3
+			}
+		} else if isWindowsReservedName(path, colonIndex) {
+			device, hasDevice = path[:colonIndex+1], true
+			rootEnd = colonIndex + 1
+		}
+	}
+
+	tail := ""
+	if rootEnd < len(path) {
+		tail = normalizeString(path[rootEnd:], !isAbsolute, "\\", isPathSeparator)
+	}
+
+	if tail == "" && !isAbsolute {
+		tail = "."
+	}
+
+	if tail != "" && isPathSeparator(path[len(path)-1]) {
+		tail += "\\"
+	}
+
+	if !isAbsolute && !hasDevice && strings.Contains(path, ":") {
+		// the tail of a relative path without a device must not be interpreted as a device
+		if hasDeviceRoot(tail) {
+			return ".\\" + tail
+		}
+
+		for index := strings.IndexByte(path, ':'); index != -1; {
+			if index == len(path)-1 || isPathSeparator(path[index+1]) {
+				return ".\\" + tail
+			}
+
+			next := strings.IndexByte(path[index+1:], ':')
+			if next == -1 {
+				break
+			}
+			index += next + 1
+		}
+	}
+
+	switch {
+	case isWindowsReservedName(path, strings.IndexByte(path, ':')):
+		return ".\\" + device + tail
+	case !hasDevice && isAbsolute:
+		return "\\" + tail
+	case !hasDevice:
+		return tail
+	case isAbsolute:
+		return device + "\\" + tail
+	default:
+		return device + tail
+	}
+}
+
+// isAbsolute if the path starts with a separator or a drive letter followed by a separator
+func (win32) isAbsolute(path string) bool {
+	if path == "" {
+		return false
+	}
+
+	return isPathSeparator(path[0]) || (len(path) > 2 && hasDeviceRoot(path) && isPathSeparator(path[2]))
+}
+
+// join the non-empty paths and normalize the result. Leading separators are collapsed into one, unless the first
+// path is a UNC root.
+func (w win32) join(paths ...string) string {
+	var parts []string
+	for _, path := range paths {
+		if path != "" {
+			parts = append(parts, path)
+		}
+	}
+
+	if len(parts) == 0 {
+		return "."
+	}
+
+	joined, firstPart := strings.Join(parts, "\\"), parts[0]
+
+	// the first part must not be mistaken for a UNC root, e.g. join('//', 'server') is '\server'
+	needsReplace := true
+	slashCount := 0
+	if isPathSeparator(firstPart[0]) {
+		slashCount++
+		if len(firstPart) > 1 && isPathSeparator(firstPart[1]) {
+			slashCount++
+			if len(firstPart) > 2 { //nolint:mnd // a possible UNC root, i.e. two separators and a server
+				if isPathSeparator(firstPart[2]) {
+					slashCount++
+				} else {
+					needsReplace = false
+				}
+			}
+		}
+	}
+
+	if needsReplace {
+		for slashCount < len(joined) && isPathSeparator(joined[slashCount]) {
+			slashCount++
+		}
+
+		if slashCount >= 2 { //nolint:mnd // a separator for each part
+			joined = "\\" + joined[slashCount:]
+		}
+	}
+
+	return w.normalize(joined)
+}
+
+// relative path from 'from' to 'to' after resolving both, comparing the paths case-insensitively
+func (w win32) relative(from string, to string) string {
+	if from == to {
+		return ""
+	}
+
+	fromOrig, toOrig := w.resolve(from), w.resolve(to)
+	if fromOrig == toOrig {
+		return ""
+	}
+
+	from, to = strings.ToLower(fromOrig), strings.ToLower(toOrig)
+	if from == to {
+		return ""
+	}
+
+	if len(fromOrig) != len(from) || len(toOrig) != len(to) {
+		// lowering changed the length, so the indices of the lowered paths do not apply to the original paths
+		return relativeSegments(fromOrig, toOrig)
+	}
+
+	fromStart, fromEnd := trimBackslashes(from)
+	fromLen := fromEnd - fromStart
+	toStart, toEnd := trimBackslashes(to)
+	toLen := toEnd - toStart
+	length := min(fromLen, toLen)
+	lastCommonSep := -1
+	i := 0
+	for ; i < length; i++ {
+		fromCode := from[fromStart+i]
+		if fromCode != to[toStart+i] {
+			break
+		} else if fromCode == '\\' {
+			lastCommonSep = i
+		}
+	}
+
+	if i != length {
+		if lastCommonSep == -1 {
+			// the paths have another device
+			return toOrig
+		}
+	} else {
+		if toLen > length {
+			if to[toStart+i] == '\\' {
+				// from is the exact base path of to, e.g. from='C:\foo\bar'; to='C:\foo\bar\baz'
+				return toOrig[toStart+i+1:]
+			}
+			if i == 2 { //nolint:mnd // the length of a device root
+				// from is the device root, e.g. from='C:\'; to='C:\foo'
+				return toOrig[toStart+i:]
+			}
+		}
+		if fromLen > length {
+			if from[fromStart+i] == '\\' {
+				// to is the exact base path of from, e.g. from='C:\foo\bar'; to='C:\foo'
+				lastCommonSep = i
+			} else if i == 2 { //nolint:mnd // the length of a device root
+				// to is the device root, e.g. from='C:\foo\bar'; to='C:\'
+				lastCommonSep = 3
+			}
+		}
+		if lastCommonSep == -1 {
+			lastCommonSep = 0
+		}
+	}
+
+	out := ""
+	for i = fromStart + lastCommonSep + 1; i <= fromEnd; i++ {
+		if i == fromEnd || from[i] == '\\' {
+			if out == "" {
+				out = ".."
+			} else {
+				out += "\\.."
+			}
+		}
+	}
+
+	toStart += lastCommonSep
+	if out != "" {
+		return out + toOrig[toStart:toEnd]
+	}
+
+	if toStart < len(toOrig) && toOrig[toStart] == '\\' {
+		toStart++
+	}
+
+	return toOrig[toStart:toEnd]
+}
+
+// trimBackslashes returns the start and end of the path without leading and trailing backslashes
+func trimBackslashes(path string) (int, int) {
+	start := 0
+	for start < len(path) && path[start] == '\\' {
+		start++
+	}
+
+	end := len(path)
+	for end-1 > start && path[end-1] == '\\' {
+		end--
+	}
+
+	return start, end
+}
+
+// relativeSegments is the relative path between the resolved paths compared by segment
+func relativeSegments(from string, to string) string {
+	fromSplit := strings.Split(from, "\\")
+	if fromSplit[len(fromSplit)-1] == "" {
+		fromSplit = fromSplit[:len(fromSplit)-1]
+	}
+
+	toSplit := strings.Split(to, "\\")
+	if toSplit[len(toSplit)-1] == "" {
+		toSplit = toSplit[:len(toSplit)-1]
+	}
+
+	length := min(len(fromSplit), len(toSplit))
+	i := 0
+	for ; i < length; i++ {
+		if strings.ToLower(fromSplit[i]) != strings.ToLower(toSplit[i]) {
+			break
+		}
+	}
+
+	switch {
+	case i == 0:
+		return to
+	case i == length && len(toSplit) > length:
+		return strings.Join(toSplit[i:], "\\")
+	case i == length && len(fromSplit) > length:
+		return strings.Repeat("..\\", len(fromSplit)-1-i) + ".."
+	case i == length:
+		return ""
+	default:
+		return strings.Repeat("..\\", len(fromSplit)-i) + strings.Join(toSplit[i:], "\\")
+	}
+}
+
+// toNamespacedPath of the path, i.e. the '\\?\' prefixed long path of an absolute path with a device
+func (w win32) toNamespacedPath(path string) string {
+	if path == "" {
+		return path
+	}
+
+	resolvedPath := w.resolve(path)
+	if len(resolvedPath) <= 2 { //nolint:mnd // a device without a root
+		return path
+	}
+
+	if resolvedPath[0] == '\\' {
+		if resolvedPath[1] == '\\' && resolvedPath[2] != '?' && resolvedPath[2] != '.' {
+			// a UNC root
+			return "\\\\?\\UNC\\" + resolvedPath[2:]
+		}
+	} else if hasDeviceRoot(resolvedPath) && resolvedPath[2] == '\\' {
+		return "\\\\?\\" + resolvedPath
+	}
+
+	return resolvedPath
+}
+
+// dirname of the path, i.e. the path without its last segment
+func (win32) dirname(path string) string {
+	if path == "" {
+		return "."
+	}
+
+	if len(path) == 1 {
+		if isPathSeparator(path[0]) {
+			return path
+		}
+
+		return "."
+	}
+
+	rootEnd, offset := -1, 0
+	if isPathSeparator(path[0]) {
+		rootEnd, offset = 1, 1
+		if isPathSeparator(path[1]) {
+			if _, last, j, ok := uncRoot(path); ok {
+				if j == len(path) {
+					// only a UNC root
+					return path
+				}
+
+				if j != last {
+					rootEnd, offset = j+1, j+1
+				}
+			}
+		}
+	} else if hasDeviceRoot(path) {
+		rootEnd = 2
+		if len(path) > 2 && isPathSeparator(path[2]) {
+			rootEnd = 3
+		}
+		offset = rootEnd
+	}
+
+	end := -1
+	matchedSlash := true
+	for i := len(path) - 1; i >= offset; i-- {
+		if isPathSeparator(path[i]) {
+			if !matchedSlash {
+				end = i
+
+				break
+			}
+		} else {
+			matchedSlash = false
+		}
+	}
+
+	if end == -1 {
+		if rootEnd == -1 {
+			return "."
+		}
+
+		end = rootEnd
+	}
+
+	return path[:end]
+}
+
+// basename is the last segment of the path without the suffix, ignoring a drive letter
+func (win32) basename(path string, suffix string) string {
+	start := 0
+	if hasDeviceRoot(path) {
+		start = 2
+	}
+
+	return basename(path, suffix, start, isPathSeparator)
+}
+
+// extname is the extension of the last segment of the path, ignoring a drive letter
+func (win32) extname(path string) string {
+	start := 0
+	if hasDeviceRoot(path) {
+		start = 2
+	}
+
+	_, startDot, end := extension(path, start, start, isPathSeparator)
+	if startDot == -1 {
+		return ""
+	}
+
+	return path[startDot:end]
+}
+
+// format the Parsed path
+func (win32) format(parsed Parsed) string {
+	return format("\\", parsed)
+}
+
+// parse the path into its root, dir, base, ext and name
+func (win32) parse(path string) Parsed {
+	var ret Parsed
+	if path == "" {
+		return ret
+	}
+
+	if len(path) == 1 {
+		if isPathSeparator(path[0]) {
+			ret.Root, ret.Dir = path, path
+		} else {
+			ret.Base, ret.Name = path, path
+		}
+
+		return ret
+	}
+
+	rootEnd := 0
+	if isPathSeparator(path[0]) {
+		rootEnd = 1
+		if isPathSeparator(path[1]) {
+			if _, last, j, ok := uncRoot(path); ok {
+				if j == len(path) {
+					rootEnd = j
+				} else if j != last {
+					rootEnd = j + 1
+				}
+			}
+		}
+	} else if hasDeviceRoot(path) {
+		if len(path) <= 2 || (len(path) == 3 && isPathSeparator(path[2])) { //nolint:mnd // only the device root
+			ret.Root, ret.Dir = path, path
+
+			return ret
+		}
+
+		rootEnd = 2
+		if isPathSeparator(path[2]) {
+			rootEnd = 3
+		}
+	}
+
+	ret.Root = path[:rootEnd]
+	startPart, startDot, end := extension(path, rootEnd, rootEnd, isPathSeparator)
+	if end != -1 {
+		if startDot == -1 {
+			ret.Base = path[startPart:end]
+			ret.Name = ret.Base
+		} else {
+			ret.Name = path[startPart:startDot]
+			ret.Base = path[startPart:end]
+			ret.Ext = path[startDot:end]
+		}
+	}
+
+	if startPart > 0 && startPart != rootEnd {
+		ret.Dir = path[:startPart-1]
+	} else {
+		ret.Dir = ret.Root
+	}
+
+	return ret
+}
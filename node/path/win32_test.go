@@ -0,0 +1,604 @@
+package path
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The win32 tests are the vectors of Node's test/parallel/test-path-*.js, with the expected values as returned by
+// Node for the cwd C:\wd
+
+func TestWin32_Dirname(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"c:\\":                          "c:\\",
+		"c:\\foo":                       "c:\\",
+		"c:\\foo\\":                     "c:\\",
+		"c:\\foo\\bar":                  "c:\\foo",
+		"c:\\foo\\bar\\":                "c:\\foo",
+		"c:\\foo\\bar\\baz":             "c:\\foo\\bar",
+		"c:\\foo bar\\baz":              "c:\\foo bar",
+		"\\":                            "\\",
+		"\\foo":                         "\\",
+		"\\foo\\":                       "\\",
+		"\\foo\\bar":                    "\\foo",
+		"\\foo\\bar\\":                  "\\foo",
+		"\\foo\\bar\\baz":               "\\foo\\bar",
+		"\\foo bar\\baz":                "\\foo bar",
+		"c:":                            "c:",
+		"c:foo":                         "c:",
+		"c:foo\\":                       "c:",
+		"c:foo\\bar":                    "c:foo",
+		"c:foo\\bar\\":                  "c:foo",
+		"c:foo\\bar\\baz":               "c:foo\\bar",
+		"c:foo bar\\baz":                "c:foo bar",
+		"file:stream":                   ".",
+		"dir\\file:stream":              "dir",
+		"\\\\unc\\share":                "\\\\unc\\share",
+		"\\\\unc\\share\\foo":           "\\\\unc\\share\\",
+		"\\\\unc\\share\\foo\\":         "\\\\unc\\share\\",
+		"\\\\unc\\share\\foo\\bar":      "\\\\unc\\share\\foo",
+		"\\\\unc\\share\\foo\\bar\\":    "\\\\unc\\share\\foo",
+		"\\\\unc\\share\\foo\\bar\\baz": "\\\\unc\\share\\foo\\bar",
+		"/a/b/":                         "/a",
+		"/a/b":                          "/a",
+		"/a":                            "/",
+		"":                              ".",
+		"/":                             "/",
+		"////":                          "/",
+		"foo":                           ".",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.dirname(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestWin32_Basename(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path     string
+		suffix   string
+		expected string
+	}{
+		{path: "\\dir\\basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "\\basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "basename.ext\\", suffix: "", expected: "basename.ext"},
+		{path: "basename.ext\\\\", suffix: "", expected: "basename.ext"},
+		{path: "foo", suffix: "", expected: "foo"},
+		{path: "aaa\\bbb", suffix: "\\bbb", expected: "bbb"},
+		{path: "aaa\\bbb", suffix: "a\\bbb", expected: "bbb"},
+		{path: "aaa\\bbb", suffix: "bbb", expected: "bbb"},
+		{path: "aaa\\bbb\\\\\\\\", suffix: "bbb", expected: "bbb"},
+		{path: "aaa\\bbb", suffix: "bb", expected: "b"},
+		{path: "aaa\\bbb", suffix: "b", expected: "bb"},
+		{path: "C:", suffix: "", expected: ""},
+		{path: "C:.", suffix: "", expected: "."},
+		{path: "C:\\", suffix: "", expected: ""},
+		{path: "C:\\dir\\base.ext", suffix: "", expected: "base.ext"},
+		{path: "C:\\basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "C:basename.ext", suffix: "", expected: "basename.ext"},
+		{path: "C:basename.ext\\", suffix: "", expected: "basename.ext"},
+		{path: "C:basename.ext\\\\", suffix: "", expected: "basename.ext"},
+		{path: "C:foo", suffix: "", expected: "foo"},
+		{path: "file:stream", suffix: "", expected: "file:stream"},
+		{path: "a", suffix: "a", expected: ""},
+		{path: "/dir/basename.ext", suffix: "", expected: "basename.ext"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.basename(tt.path, tt.suffix)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestWin32_Extname(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"":                   "",
+		"/path/to/file":      "",
+		"/path/to/file.ext":  ".ext",
+		"/path.to/file.ext":  ".ext",
+		"/path.to/file":      "",
+		"/path.to/.file":     "",
+		"/path.to/.file.ext": ".ext",
+		"/path/to/f.ext":     ".ext",
+		"/path/to/..ext":     ".ext",
+		"/path/to/..":        "",
+		"file":               "",
+		"file.ext":           ".ext",
+		".file":              "",
+		".file.ext":          ".ext",
+		"/file":              "",
+		"/file.ext":          ".ext",
+		"/.file":             "",
+		"/.file.ext":         ".ext",
+		".path/file.ext":     ".ext",
+		"file.ext.ext":       ".ext",
+		"file.":              ".",
+		".":                  "",
+		"./":                 "",
+		".file.":             ".",
+		".file..":            ".",
+		"..":                 "",
+		"../":                "",
+		"..file.ext":         ".ext",
+		"..file":             ".file",
+		"..file.":            ".",
+		"..file..":           ".",
+		"...":                ".",
+		"...ext":             ".ext",
+		"....":               ".",
+		"file.ext/":          ".ext",
+		"file.ext//":         ".ext",
+		"file/":              "",
+		"file//":             "",
+		"file./":             ".",
+		"file.//":            ".",
+		".\\":                "",
+		"..\\":               "",
+		"file.ext\\":         ".ext",
+		"file.ext\\\\":       ".ext",
+		"file\\":             "",
+		"file\\\\":           "",
+		"file.\\":            ".",
+		"file.\\\\":          ".",
+		"C:file.ext":         ".ext",
+		"C:.ext":             "",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.extname(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestWin32_Normalize(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"./fixtures///b/../b/c.js":         "fixtures\\b\\c.js",
+		"/foo/../../../bar":                "\\bar",
+		"a//b//../b":                       "a\\b",
+		"a//b//./c":                        "a\\b\\c",
+		"a//b//.":                          "a\\b",
+		"//server/share/dir/file.ext":      "\\\\server\\share\\dir\\file.ext",
+		"/a/b/c/../../../x/y/z":            "\\x\\y\\z",
+		"C:":                               "C:.",
+		"C:..\\abc":                        "C:..\\abc",
+		"C:..\\..\\abc\\..\\def":           "C:..\\..\\def",
+		"C:\\.":                            "C:\\",
+		"file:stream":                      "file:stream",
+		"bar\\foo..\\..\\":                 "bar\\",
+		"bar\\foo..\\..":                   "bar",
+		"bar\\foo..\\..\\baz":              "bar\\baz",
+		"bar\\foo..\\":                     "bar\\foo..\\",
+		"bar\\foo..":                       "bar\\foo..",
+		"..\\foo..\\..\\..\\bar":           "..\\..\\bar",
+		"..\\...\\..\\.\\...\\..\\..\\bar": "..\\..\\bar",
+		"../../../foo/../../../bar":        "..\\..\\..\\..\\..\\bar",
+		"../../../foo/../../../bar/../../": "..\\..\\..\\..\\..\\..\\",
+		"../foobar/barfoo/foo/../../../bar/../../": "..\\..\\",
+		"../.../../foobar/../../../bar/../../baz":  "..\\..\\..\\..\\baz",
+		"foo/bar\\baz":            "foo\\bar\\baz",
+		"\\\\.\\foo":              "\\\\.\\foo\\",
+		"\\\\.\\foo\\":            "\\\\.\\foo\\",
+		"test/../C:/Windows":      ".\\C:\\Windows",
+		"test/../C:Windows":       ".\\C:Windows",
+		"./upload/../C:/Windows":  ".\\C:\\Windows",
+		"./upload/../C:x":         ".\\C:x",
+		"test/../??/D:/Test":      ".\\??\\D:\\Test",
+		"test/C:/../../F:":        ".\\F:",
+		"test/C:foo/../../F:":     ".\\F:",
+		"test/C:/../../F:\\":      ".\\F:\\",
+		"test/C:foo/../../F:\\":   ".\\F:\\",
+		"test/C:/../../F:x":       ".\\F:x",
+		"test/C:foo/../../F:x":    ".\\F:x",
+		"/test/../??/D:/Test":     "\\??\\D:\\Test",
+		"/test/../?/D:/Test":      "\\?\\D:\\Test",
+		"C:/test/../C:/Windows":   "C:\\C:\\Windows",
+		"C:/test/../CON:/Windows": "C:\\CON:\\Windows",
+		"CON:":                    ".\\CON:.",
+		"CON:x":                   ".\\CON:x",
+		"con:x":                   ".\\con:x",
+		"COM1:":                   ".\\COM1:.",
+		"COM0:a":                  "COM0:a",
+		"LPT9:a":                  ".\\LPT9:a",
+		"NUL:x":                   ".\\NUL:x",
+		"aux:/x":                  ".\\aux:x",
+		"CONx":                    ".\\CONx",
+		"NUL":                     "NUL",
+		"\\\\unc\\share":          "\\\\unc\\share\\",
+		"\\\\unc\\share\\":        "\\\\unc\\share\\",
+		"\\\\?\\C:":               "\\\\?\\C:\\",
+		"":                        ".",
+		".":                       ".",
+		"\\":                      "\\",
+		"/":                       "\\",
+		"a:b":                     "a:b",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.normalize(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestWin32_Join(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		paths    []string
+		expected string
+	}{
+		{paths: []string{".", "x/b", "..", "/b/c.js"}, expected: "x\\b\\c.js"},
+		{paths: []string{}, expected: "."},
+		{paths: []string{"/.", "x/b", "..", "/b/c.js"}, expected: "\\x\\b\\c.js"},
+		{paths: []string{"/foo", "../../../bar"}, expected: "\\bar"},
+		{paths: []string{"foo", "../../../bar"}, expected: "..\\..\\bar"},
+		{paths: []string{"foo/", "../../../bar"}, expected: "..\\..\\bar"},
+		{paths: []string{"foo/x", "../../../bar"}, expected: "..\\bar"},
+		{paths: []string{"foo/x", "./bar"}, expected: "foo\\x\\bar"},
+		{paths: []string{"foo/x/", "./bar"}, expected: "foo\\x\\bar"},
+		{paths: []string{"foo/x/", ".", "bar"}, expected: "foo\\x\\bar"},
+		{paths: []string{"./"}, expected: ".\\"},
+		{paths: []string{".", "./"}, expected: ".\\"},
+		{paths: []string{".", ".", "."}, expected: "."},
+		{paths: []string{".", "./", "."}, expected: "."},
+		{paths: []string{".", "/./", "."}, expected: "."},
+		{paths: []string{".", "/////./", "."}, expected: "."},
+		{paths: []string{"."}, expected: "."},
+		{paths: []string{"", "."}, expected: "."},
+		{paths: []string{"", "foo"}, expected: "foo"},
+		{paths: []string{"foo", "/bar"}, expected: "foo\\bar"},
+		{paths: []string{"", "/foo"}, expected: "\\foo"},
+		{paths: []string{"", "", "/foo"}, expected: "\\foo"},
+		{paths: []string{"", "", "foo"}, expected: "foo"},
+		{paths: []string{"foo", ""}, expected: "foo"},
+		{paths: []string{"foo/", ""}, expected: "foo\\"},
+		{paths: []string{"foo", "", "/bar"}, expected: "foo\\bar"},
+		{paths: []string{"./", "..", "/foo"}, expected: "..\\foo"},
+		{paths: []string{"./", "..", "..", "/foo"}, expected: "..\\..\\foo"},
+		{paths: []string{".", "..", "..", "/foo"}, expected: "..\\..\\foo"},
+		{paths: []string{"", "..", "..", "/foo"}, expected: "..\\..\\foo"},
+		{paths: []string{"/"}, expected: "\\"},
+		{paths: []string{"/", "."}, expected: "\\"},
+		{paths: []string{"/", ".."}, expected: "\\"},
+		{paths: []string{"/", "..", ".."}, expected: "\\"},
+		{paths: []string{""}, expected: "."},
+		{paths: []string{"", ""}, expected: "."},
+		{paths: []string{" /foo"}, expected: " \\foo"},
+		{paths: []string{" ", "foo"}, expected: " \\foo"},
+		{paths: []string{" ", "."}, expected: " "},
+		{paths: []string{" ", "/"}, expected: " \\"},
+		{paths: []string{" ", ""}, expected: " "},
+		{paths: []string{"/", "foo"}, expected: "\\foo"},
+		{paths: []string{"/", "/foo"}, expected: "\\foo"},
+		{paths: []string{"/", "//foo"}, expected: "\\foo"},
+		{paths: []string{"/", "", "/foo"}, expected: "\\foo"},
+		{paths: []string{"", "/", "foo"}, expected: "\\foo"},
+		{paths: []string{"", "/", "/foo"}, expected: "\\foo"},
+		{paths: []string{"//foo/bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"\\/foo/bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"\\\\foo/bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"//foo", "bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"//foo/", "bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"//foo", "/bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"//foo", "", "bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"//foo/", "", "bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"//foo/", "", "/bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"", "//foo", "bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"", "//foo/", "bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"", "//foo/", "/bar"}, expected: "\\\\foo\\bar\\"},
+		{paths: []string{"\\", "foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"\\", "/foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"", "/", "/foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"//", "foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"//", "/foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"\\\\", "/", "/foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"//"}, expected: "\\"},
+		{paths: []string{"//foo"}, expected: "\\foo"},
+		{paths: []string{"//foo/"}, expected: "\\foo\\"},
+		{paths: []string{"//foo", "/"}, expected: "\\foo\\"},
+		{paths: []string{"//foo", "", "/"}, expected: "\\foo\\"},
+		{paths: []string{"///foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"////foo", "bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"\\\\\\/foo/bar"}, expected: "\\foo\\bar"},
+		{paths: []string{"c:"}, expected: "c:."},
+		{paths: []string{"c:."}, expected: "c:."},
+		{paths: []string{"c:", ""}, expected: "c:."},
+		{paths: []string{"", "c:"}, expected: "c:."},
+		{paths: []string{"c:.", "/"}, expected: "c:.\\"},
+		{paths: []string{"c:.", "file"}, expected: "c:file"},
+		{paths: []string{"c:", "/"}, expected: "c:\\"},
+		{paths: []string{"c:", "file"}, expected: "c:\\file"},
+		{paths: []string{"test/C:", "../F:"}, expected: ".\\test\\F:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.paths, ","), func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.join(tt.paths...)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestWin32_Resolve(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		paths    []string
+		expected string
+	}{
+		{paths: []string{"c:/blah\\blah", "d:/games", "c:../a"}, expected: "c:\\blah\\a"},
+		{paths: []string{"c:/ignore", "d:\\a/b\\c/d", "\\e.exe"}, expected: "d:\\e.exe"},
+		{paths: []string{"c:/ignore", "c:/some/file"}, expected: "c:\\some\\file"},
+		{paths: []string{"d:/ignore", "d:some/dir//"}, expected: "d:\\ignore\\some\\dir"},
+		{paths: []string{"."}, expected: "C:\\wd"},
+		{paths: []string{"//server/share", "..", "relative\\"}, expected: "\\\\server\\share\\relative"},
+		{paths: []string{"c:/", "//"}, expected: "c:\\"},
+		{paths: []string{"c:/", "//dir"}, expected: "c:\\dir"},
+		{paths: []string{"c:/", "//server/share"}, expected: "\\\\server\\share\\"},
+		{paths: []string{"c:/", "//server//share"}, expected: "\\\\server\\share\\"},
+		{paths: []string{"c:/", "///some//dir"}, expected: "c:\\some\\dir"},
+		{paths: []string{"C:\\foo\\tmp.3\\", "..\\tmp.3\\cycles\\root.js"}, expected: "C:\\foo\\tmp.3\\cycles\\root.js"},
+		{paths: []string{"a"}, expected: "C:\\wd\\a"},
+		{paths: []string{"d:a"}, expected: "d:\\a"},
+		{paths: []string{"c:foo"}, expected: "c:\\wd\\foo"},
+		{paths: []string{}, expected: "C:\\wd"},
+		{paths: []string{""}, expected: "C:\\wd"},
+		{paths: []string{"\\\\server"}, expected: "C:\\server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.paths, ","), func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.resolve(tt.paths...)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestWin32_Relative(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{from: "c:/blah\\blah", to: "d:/games", expected: "d:\\games"},
+		{from: "c:/aaaa/bbbb", to: "c:/aaaa", expected: ".."},
+		{from: "c:/aaaa/bbbb", to: "c:/cccc", expected: "..\\..\\cccc"},
+		{from: "c:/aaaa/bbbb", to: "c:/aaaa/bbbb", expected: ""},
+		{from: "c:/aaaa/bbbb", to: "c:/aaaa/cccc", expected: "..\\cccc"},
+		{from: "c:/aaaa/", to: "c:/aaaa/cccc", expected: "cccc"},
+		{from: "c:/", to: "c:\\aaaa\\bbbb", expected: "aaaa\\bbbb"},
+		{from: "c:/aaaa/bbbb", to: "d:\\", expected: "d:\\"},
+		{from: "c:/AaAa/bbbb", to: "c:/aaaa/bbbb", expected: ""},
+		{from: "c:/aaaaa/", to: "c:/aaaa/cccc", expected: "..\\aaaa\\cccc"},
+		{from: "C:\\foo\\bar\\baz\\quux", to: "C:\\", expected: "..\\..\\..\\.."},
+		{from: "C:\\foo\\test", to: "C:\\foo\\test\\bar\\package.json", expected: "bar\\package.json"},
+		{from: "C:\\foo\\bar\\baz-quux", to: "C:\\foo\\bar\\baz", expected: "..\\baz"},
+		{from: "C:\\foo\\bar\\baz", to: "C:\\foo\\bar\\baz-quux", expected: "..\\baz-quux"},
+		{from: "\\\\foo\\bar", to: "\\\\foo\\bar\\baz", expected: "baz"},
+		{from: "\\\\foo\\bar\\baz", to: "\\\\foo\\bar", expected: ".."},
+		{from: "\\\\foo\\bar\\baz-quux", to: "\\\\foo\\bar\\baz", expected: "..\\baz"},
+		{from: "\\\\foo\\bar\\baz", to: "\\\\foo\\bar\\baz-quux", expected: "..\\baz-quux"},
+		{from: "C:\\baz-quux", to: "C:\\baz", expected: "..\\baz"},
+		{from: "C:\\baz", to: "C:\\baz-quux", expected: "..\\baz-quux"},
+		{from: "\\\\foo\\baz-quux", to: "\\\\foo\\baz", expected: "..\\baz"},
+		{from: "\\\\foo\\baz", to: "\\\\foo\\baz-quux", expected: "..\\baz-quux"},
+		{from: "C:\\baz", to: "\\\\foo\\bar\\baz", expected: "\\\\foo\\bar\\baz"},
+		{from: "\\\\foo\\bar\\baz", to: "C:\\baz", expected: "C:\\baz"},
+		{from: "c:\\ÄÄÄ\\bbbb", to: "c:\\äää\\cccc", expected: "..\\cccc"},
+		{from: "c:\\ẞ\\x", to: "c:\\ß\\y", expected: "..\\y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+","+tt.to, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.relative(tt.from, tt.to)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestWin32_IsAbsolute(t *testing.T) {
+	t.Parallel()
+	tests := map[string]bool{
+		"/":                    true,
+		"//":                   true,
+		"//server":             true,
+		"//server/file":        true,
+		"\\\\server\\file":     true,
+		"\\\\":                 true,
+		"c":                    false,
+		"c:":                   false,
+		"c:\\":                 true,
+		"c:/":                  true,
+		"c://":                 true,
+		"C:/Users/":            true,
+		"C:\\Users\\":          true,
+		"C:cwd/another":        false,
+		"C:cwd\\another":       false,
+		"directory/directory":  false,
+		"directory\\directory": false,
+		"":                     false,
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.isAbsolute(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestWin32_Parse(t *testing.T) {
+	t.Parallel()
+	tests := map[string]Parsed{
+		"C:\\path\\dir\\index.html":                      {Root: "C:\\", Dir: "C:\\path\\dir", Base: "index.html", Ext: ".html", Name: "index"},
+		"C:\\another_path\\DIR\\1\\2\\33\\\\index":       {Root: "C:\\", Dir: "C:\\another_path\\DIR\\1\\2\\33\\", Base: "index", Ext: "", Name: "index"},
+		"another_path\\DIR with spaces\\1\\2\\33\\index": {Root: "", Dir: "another_path\\DIR with spaces\\1\\2\\33", Base: "index", Ext: "", Name: "index"},
+		"\\":                           {Root: "\\", Dir: "\\", Base: "", Ext: "", Name: ""},
+		"\\foo\\C:":                    {Root: "\\", Dir: "\\foo", Base: "C:", Ext: "", Name: "C:"},
+		"file":                         {Root: "", Dir: "", Base: "file", Ext: "", Name: "file"},
+		"file:stream":                  {Root: "", Dir: "", Base: "file:stream", Ext: "", Name: "file:stream"},
+		".\\file":                      {Root: "", Dir: ".", Base: "file", Ext: "", Name: "file"},
+		"C:":                           {Root: "C:", Dir: "C:", Base: "", Ext: "", Name: ""},
+		"C:.":                          {Root: "C:", Dir: "C:", Base: ".", Ext: "", Name: "."},
+		"C:..":                         {Root: "C:", Dir: "C:", Base: "..", Ext: "", Name: ".."},
+		"C:abc":                        {Root: "C:", Dir: "C:", Base: "abc", Ext: "", Name: "abc"},
+		"C:\\":                         {Root: "C:\\", Dir: "C:\\", Base: "", Ext: "", Name: ""},
+		"C:\\abc":                      {Root: "C:\\", Dir: "C:\\", Base: "abc", Ext: "", Name: "abc"},
+		"":                             {Root: "", Dir: "", Base: "", Ext: "", Name: ""},
+		"\\\\server\\share\\file_path": {Root: "\\\\server\\share\\", Dir: "\\\\server\\share\\", Base: "file_path", Ext: "", Name: "file_path"},
+		"\\\\server two\\shared folder\\file path.zip": {Root: "\\\\server two\\shared folder\\", Dir: "\\\\server two\\shared folder\\", Base: "file path.zip", Ext: ".zip", Name: "file path"},
+		"\\\\teela\\admin$\\system32":                  {Root: "\\\\teela\\admin$\\", Dir: "\\\\teela\\admin$\\", Base: "system32", Ext: "", Name: "system32"},
+		"\\\\?\\UNC\\server\\share":                    {Root: "\\\\?\\UNC\\", Dir: "\\\\?\\UNC\\server", Base: "share", Ext: "", Name: "share"},
+		"\\\\server\\share":                            {Root: "\\\\server\\share", Dir: "\\\\server\\share", Base: "", Ext: "", Name: ""},
+		"C:\\..":                                       {Root: "C:\\", Dir: "C:\\", Base: "..", Ext: "", Name: ".."},
+		"/x/y/":                                        {Root: "/", Dir: "/x", Base: "y", Ext: "", Name: "y"},
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.parse(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestWin32_Format(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		parsed   Parsed
+		expected string
+	}{
+		{parsed: Parsed{Root: "/", Dir: "/", Base: ""}, expected: "/"},
+		{parsed: Parsed{Root: "/", Dir: "/", Base: "a"}, expected: "/a"},
+		{parsed: Parsed{Dir: "/a", Base: "b"}, expected: "/a\\b"},
+		{parsed: Parsed{Dir: "some/dir", Name: "x", Ext: ".js"}, expected: "some/dir\\x.js"},
+		{parsed: Parsed{Name: "x", Ext: "js"}, expected: "x.js"},
+		{parsed: Parsed{Name: "x", Ext: "."}, expected: "x."},
+		{parsed: Parsed{Name: "x", Ext: ".."}, expected: "x.."},
+		{parsed: Parsed{Root: "/", Name: "x"}, expected: "/x"},
+		{parsed: Parsed{Base: "a.js", Name: "b", Ext: ".ts"}, expected: "a.js"},
+		{parsed: Parsed{}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.format(tt.parsed)
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestWin32_ToNamespacedPath(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"":                          "",
+		"C:\\foo":                   "\\\\?\\C:\\foo",
+		"C:/foo":                    "\\\\?\\C:\\foo",
+		"\\\\foo\\bar":              "\\\\?\\UNC\\foo\\bar\\",
+		"//foo//bar":                "\\\\?\\UNC\\foo\\bar\\",
+		"\\\\?\\foo":                "\\\\?\\foo\\",
+		"c:":                        "\\\\?\\c:\\wd",
+		"foo":                       "\\\\?\\C:\\wd\\foo",
+		"\\\\.\\pipe\\x":            "\\\\.\\pipe\\x",
+		"\\\\?\\c:\\Windows/System": "\\\\?\\c:\\Windows\\System",
+		"C:\\foo\\..":               "\\\\?\\C:\\",
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			p := win32{cwd: func() string { return "C:\\wd" }}
+
+			// Act
+			res := p.toNamespacedPath(path)
+
+			// Assert
+			assert.Equal(t, expected, res)
+		})
+	}
+}
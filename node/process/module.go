@@ -1,24 +1,57 @@
 package process
 
 import (
+	"io"
+	"maps"
 	"os"
 	"strings"
 
+	"github.com/Emptyless/go-spectral/internal/task"
+	"github.com/Emptyless/go-spectral/node/stream"
 	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
 	"github.com/dop251/goja_nodejs/require"
 )
 
 // ModuleName of process package
 const ModuleName = "process"
 
-// Process holds the goja.Runtime for converting values and a map of environment values (by os.Environ) when
-// the program started
+// defaultColumns of process.stdout and process.stderr, i.e. the width of a terminal that formatters wrap lines at
+const defaultColumns = 80
+
+// Options of the process package
+type Options struct {
+	// Stdout receives the writes to process.stdout, which are discarded if nil
+	Stdout io.Writer
+
+	// Stderr receives the writes to process.stderr, which are discarded if nil
+	Stderr io.Writer
+
+	// Env are the variables of process.env, which take precedence over the inherited os.Environ
+	Env map[string]string
+
+	// InheritEnv copies os.Environ into process.env, which leaks e.g. the secrets of a CI into the scripts
+	InheritEnv bool
+
+	// Argv of process.argv, defaults to DefaultArgv if nil
+	Argv []string
+}
+
+// DefaultArgv is the process.argv if the Options have none
+var DefaultArgv = []string{"spectral"}
+
+// Process holds the goja.Runtime for converting values and a map of environment values (by the Options) when
+// the package was enabled
 type Process struct {
-	r   *goja.Runtime
-	env map[string]string
+	r       *goja.Runtime
+	env     map[string]string
+	exports *goja.Object
 
 	// CurrentWorkingDirectory used by process
 	CurrentWorkingDirectory string
+
+	// Options of the process, e.g. the writers of process.stdout and process.stderr
+	Options Options
 }
 
 // On returns null
@@ -37,44 +70,174 @@ func (p *Process) Cwd(_ goja.FunctionCall) goja.Value {
 	return p.r.ToValue(cwd)
 }
 
-// Versions implemented by Node
+// NextTick queues the callback (with the optional arguments) on the nextTick queue, which is drained before the
+// microtasks
+func (p *Process) NextTick(call goja.FunctionCall) goja.Value {
+	callback, ok := goja.AssertFunction(call.Argument(0))
+	if !ok {
+		panic(p.r.NewTypeError("The \"callback\" argument must be of type function"))
+	}
+
+	var args []goja.Value
+	if len(call.Arguments) > 1 {
+		args = call.Arguments[1:]
+	}
+
+	task.NextTick(p.r, func() {
+		if _, err := callback(goja.Undefined(), args...); err != nil {
+			panic(err)
+		}
+	})
+
+	return goja.Undefined()
+}
+
+// Versions implemented by Node. The Versions are copied such that runtimes cannot modify the shared map
 func (p *Process) Versions() goja.Value {
-	return p.r.ToValue(Versions)
+	return p.r.ToValue(maps.Clone(Versions))
 }
 
-// Require the process package
-func Require(p *Process) func(runtime *goja.Runtime, module *goja.Object) {
-	return func(runtime *goja.Runtime, module *goja.Object) {
-		for _, e := range os.Environ() {
-			if p.env == nil {
-				p.env = map[string]string{}
+// Stdio is the Writable stream of process.stdout (fd 1) or process.stderr (fd 2) writing every chunk to the writer.
+// Like Node, the stream has an isTTY and columns property, where isTTY is true if the writer is a terminal.
+func (p *Process) Stdio(w io.Writer, fd int) *goja.Object {
+	if w == nil {
+		w = io.Discard
+	}
+
+	options := p.r.NewObject()
+	_ = options.Set("write", func(call goja.FunctionCall) goja.Value {
+		var res goja.Value = goja.Null()
+		if _, err := w.Write(buffer.Bytes(p.r, call.Argument(0))); err != nil {
+			res = p.r.NewGoError(err)
+		}
+
+		if callback, ok := goja.AssertFunction(call.Argument(2)); ok {
+			if _, err := callback(goja.Undefined(), res); err != nil {
+				panic(err)
 			}
+		}
+
+		return goja.Undefined()
+	})
 
+	writable, err := p.r.New(require.Require(p.r, stream.ModuleName).ToObject(p.r).Get("Writable"), options)
+	if err != nil {
+		panic(err)
+	}
+
+	_ = writable.Set("fd", fd)
+	_ = writable.Set("isTTY", isTerminal(w))
+	_ = writable.Set("columns", defaultColumns)
+
+	return writable
+}
+
+// isTerminal if the writer is a character device, e.g. os.Stdout if it is not redirected
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stdio defines the property of the exports as a getter of the Stdio stream, which is created once it is first used
+// such that the stream package is only required if the script writes to process.stdout or process.stderr
+func (p *Process) stdio(exports *goja.Object, name string, w io.Writer, fd int) {
+	var writable *goja.Object
+	_ = exports.DefineAccessorProperty(name, p.r.ToValue(func(goja.FunctionCall) goja.Value {
+		if writable == nil {
+			writable = p.Stdio(w, fd)
+		}
+
+		return writable
+	}), nil, goja.FLAG_TRUE, goja.FLAG_TRUE)
+}
+
+// env of process.env, i.e. os.Environ if the Options inherit it overwritten by the Options.Env
+func (o Options) env() map[string]string {
+	env := make(map[string]string)
+	if o.InheritEnv {
+		for _, e := range os.Environ() {
 			envKeyValue := strings.SplitN(e, "=", 2) //nolint:mnd // split in key=value, two parts
-			p.env[envKeyValue[0]] = envKeyValue[1]
+			env[envKeyValue[0]] = envKeyValue[1]
 		}
+	}
 
+	maps.Copy(env, o.Env)
+
+	return env
+}
+
+// argv of process.argv, an array holding a copy of the Options.Argv such that runtimes cannot modify it
+func (p *Process) argv() *goja.Object {
+	argv := p.Options.Argv
+	if argv == nil {
+		argv = DefaultArgv
+	}
+
+	values := make([]any, len(argv))
+	for i, arg := range argv {
+		values[i] = arg
+	}
+
+	return p.r.NewArray(values...)
+}
+
+// Reset process.env and process.argv to the Options, dropping the changes of a script to them. Any other property of
+// process is not reset.
+func (p *Process) Reset() {
+	p.env = p.Options.env()
+	if p.exports == nil {
+		return
+	}
+
+	_ = p.exports.Set("env", p.r.ToValue(p.env))
+	_ = p.exports.Set("argv", p.argv())
+}
+
+// Require the process package
+func Require(p *Process) func(runtime *goja.Runtime, module *goja.Object) {
+	return func(runtime *goja.Runtime, module *goja.Object) {
 		o := module.Get("exports").(*goja.Object) //nolint:forcetypeassert // based on library reference implementation
+		p.exports = o
 		_ = o.Set("env", runtime.ToValue(p.env))
 		_ = o.Set("on", p.On)
 		_ = o.Set("versions", p.Versions())
 		_ = o.Set("version", runtime.ToValue(Version))
 		_ = o.Set("cwd", p.Cwd)
-		_ = o.Set("stdout", runtime.NewObject())
-		_ = o.Set("stderr", runtime.NewObject())
-		_ = o.Set("argv", runtime.ToValue([]string{"spectral"}))
+		_ = o.Set("nextTick", p.NextTick)
+		p.stdio(o, "stdout", p.Options.Stdout, 1)
+		p.stdio(o, "stderr", p.Options.Stderr, 2) //nolint:mnd // file descriptor of stderr
+		_ = o.Set("argv", p.argv())
 	}
 }
 
-// Enable the process package
-func Enable(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule, currentWorkingDirectory string) {
+// Enable the process package, which relies on the stream package to be enabled for process.stdout and process.stderr.
+// The process.env inherits os.Environ.
+func Enable(runtime *goja.Runtime, registry *require.Registry, requireModule *require.RequireModule, currentWorkingDirectory string) {
+	EnableOptions(runtime, registry, requireModule, currentWorkingDirectory, Options{InheritEnv: true})
+}
+
+// EnableOptions enables the process package with the Options, e.g. to write process.stdout to os.Stdout. Unlike
+// Enable, process.env only has the Options.Env unless the Options.InheritEnv. The process and node:process packages
+// export the same object, which the returned Process resets.
+func EnableOptions(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule, currentWorkingDirectory string, options Options) *Process {
 	p := &Process{
 		r:                       runtime,
-		env:                     make(map[string]string),
+		env:                     options.env(),
 		CurrentWorkingDirectory: currentWorkingDirectory,
+		Options:                 options,
 	}
 
-	registry.RegisterNativeModule("node:"+ModuleName, Require(p))
 	registry.RegisterNativeModule(ModuleName, Require(p))
+	registry.RegisterNativeModule("node:"+ModuleName, func(runtime *goja.Runtime, module *goja.Object) {
+		_ = module.Set("exports", require.Require(runtime, ModuleName))
+	})
 	_ = runtime.Set("process", require.Require(runtime, ModuleName))
+
+	return p
 }
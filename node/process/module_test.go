@@ -1,9 +1,13 @@
 package process
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/Emptyless/go-spectral/node/events"
+	"github.com/Emptyless/go-spectral/node/stream"
 	"github.com/dop251/goja"
 	noderequire "github.com/dop251/goja_nodejs/require"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +42,40 @@ func TestProcess_Cwd(t *testing.T) {
 	assert.Equal(t, expected, res.Export())
 }
 
+func TestProcess_NextTick(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	process := &Process{r: runtime}
+	_ = runtime.Set("nextTick", process.NextTick)
+
+	// Act
+	res, err := runtime.RunString(`
+		var calls = [];
+		nextTick(function(a, b) { calls.push(a + b); }, 1, 2);
+		calls.push(0);
+		calls;
+	`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{int64(0), int64(3)}, res.Export())
+}
+
+func TestProcess_NextTick_RequiresCallback(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	process := &Process{r: runtime}
+	_ = runtime.Set("nextTick", process.NextTick)
+
+	// Act
+	_, err := runtime.RunString(`nextTick(1)`)
+
+	// Assert
+	require.Error(t, err)
+}
+
 func TestProcess_Versions(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -51,10 +89,217 @@ func TestProcess_Versions(t *testing.T) {
 	assert.Equal(t, Versions, res.Export())
 }
 
+// newRuntime with the events and stream package enabled, which process.stdout and process.stderr rely on
+func newRuntime() (*goja.Runtime, *noderequire.Registry, *noderequire.RequireModule) {
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	requireModule := registry.Enable(runtime)
+	events.Enable(runtime, registry, requireModule)
+	stream.Enable(runtime, registry, requireModule)
+
+	return runtime, registry, requireModule
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+// Write implementation of io.Writer
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestProcess_Stdio(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime, _, _ := newRuntime()
+	var stdout bytes.Buffer
+	process := &Process{r: runtime}
+	_ = runtime.Set("stdout", process.Stdio(&stdout, 1))
+
+	// Act
+	res, err := runtime.RunString(`
+		var calls = [];
+		var ret = stdout.write('hello ', function(err) { calls.push(err); });
+		stdout.write('776f726c64', 'hex');
+		[ret, stdout.isTTY, stdout.columns, stdout.fd, stdout instanceof require('stream').Writable, calls];
+	`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{true, false, int64(80), int64(1), true, []any{nil}}, res.Export())
+	assert.Equal(t, "hello world", stdout.String())
+}
+
+func TestProcess_Stdio_WriteError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime, _, _ := newRuntime()
+	process := &Process{r: runtime}
+	_ = runtime.Set("stderr", process.Stdio(failingWriter{}, 2))
+
+	// Act
+	res, err := runtime.RunString(`
+		var calls = [];
+		stderr.on('error', function(err) { calls.push('error: ' + err.message); });
+		stderr.write('a', function(err) { calls.push('callback: ' + err.message); });
+		calls;
+	`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{"callback: disk full", "error: disk full"}, res.Export())
+}
+
+func TestProcess_Stdio_DiscardsWithoutWriter(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime, _, _ := newRuntime()
+	process := &Process{r: runtime}
+	_ = runtime.Set("stdout", process.Stdio(nil, 1))
+
+	// Act
+	res, err := runtime.RunString(`stdout.write('a')`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, true, res.Export())
+}
+
+func TestEnableOptions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime, registry, requireModule := newRuntime()
+	var stdout, stderr bytes.Buffer
+
+	// Act
+	EnableOptions(runtime, registry, requireModule, "", Options{Stdout: &stdout, Stderr: &stderr})
+
+	// Assert
+	res, err := runtime.RunString(`
+		process.stdout.write('out');
+		process.stderr.end('err');
+		[process.stdout === process.stdout, process.stderr.fd];
+	`)
+	require.NoError(t, err)
+	assert.Equal(t, []any{true, int64(2)}, res.Export())
+	assert.Equal(t, "out", stdout.String())
+	assert.Equal(t, "err", stderr.String())
+}
+
+func TestEnableOptions_Env(t *testing.T) {
+	t.Setenv("GO_SPECTRAL_INHERITED", "inherited")
+	tests := map[string]struct {
+		options  Options
+		expected []any
+	}{
+		"inherits os.Environ": {
+			options:  Options{InheritEnv: true},
+			expected: []any{"inherited", nil},
+		},
+		"overwrites os.Environ": {
+			options:  Options{InheritEnv: true, Env: map[string]string{"GO_SPECTRAL_INHERITED": "env", "SPECTRAL_DEBUG": "1"}},
+			expected: []any{"env", "1"},
+		},
+		"only has env": {
+			options:  Options{Env: map[string]string{"SPECTRAL_DEBUG": "1"}},
+			expected: []any{nil, "1"},
+		},
+		"is empty": {
+			options:  Options{},
+			expected: []any{nil, nil},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			runtime, registry, requireModule := newRuntime()
+
+			// Act
+			EnableOptions(runtime, registry, requireModule, "", tt.options)
+
+			// Assert
+			res, err := runtime.RunString(`[process.env.GO_SPECTRAL_INHERITED, process.env.SPECTRAL_DEBUG]`)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
+
+func TestEnableOptions_Argv(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		argv     []string
+		expected []any
+	}{
+		"defaults": {
+			argv:     nil,
+			expected: []any{"spectral"},
+		},
+		"sets argv": {
+			argv:     []string{"node", "spectral", "lint"},
+			expected: []any{"node", "spectral", "lint"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			runtime, registry, requireModule := newRuntime()
+			EnableOptions(runtime, registry, requireModule, "", Options{Argv: tt.argv})
+
+			// Act
+			res, err := runtime.RunString(`var argv = process.argv.slice(); process.argv.push('modified'); argv`)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res.Export())
+			assert.Equal(t, []string{"spectral"}, DefaultArgv)
+		})
+	}
+}
+
+func TestEnableOptions_SharesExportsWithNodePrefix(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime, registry, requireModule := newRuntime()
+	EnableOptions(runtime, registry, requireModule, "", Options{})
+
+	// Act
+	res, err := runtime.RunString(`require('node:process') === process && require('process') === process`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, true, res.Export())
+}
+
+func TestProcess_Reset(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime, registry, requireModule := newRuntime()
+	p := EnableOptions(runtime, registry, requireModule, "", Options{Env: map[string]string{"SPECTRAL_DEBUG": "1"}, Argv: []string{"spectral", "lint"}})
+	_, err := runtime.RunString(`
+		process.env.SPECTRAL_DEBUG = '2';
+		process.env.TOKEN = 'secret';
+		process.argv.push('modified');
+	`)
+	require.NoError(t, err)
+
+	// Act
+	p.Reset()
+
+	// Assert
+	res, err := runtime.RunString(`[process.env.SPECTRAL_DEBUG, process.env.TOKEN, process.argv.join(' ')]`)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"1", nil, "spectral lint"}, res.Export())
+	assert.Equal(t, map[string]string{"SPECTRAL_DEBUG": "1"}, p.Options.Env)
+}
+
 func TestRequire(t *testing.T) {
 	t.Parallel()
 	// Arrange
-	runtime := goja.New()
+	runtime, _, _ := newRuntime()
 	p := &Process{r: runtime}
 
 	module := runtime.NewObject()
@@ -70,6 +315,7 @@ func TestRequire(t *testing.T) {
 	assert.NotNil(t, exports.Get("versions"))
 	assert.NotNil(t, exports.Get("version"))
 	assert.NotNil(t, exports.Get("cwd"))
+	assert.NotNil(t, exports.Get("nextTick"))
 	assert.NotNil(t, exports.Get("stdout"))
 	assert.NotNil(t, exports.Get("stderr"))
 	assert.NotNil(t, exports.Get("argv"))
@@ -0,0 +1,76 @@
+package stream
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dop251/goja_nodejs/buffer"
+)
+
+// decoder of the Buffer chunks of a readable stream into strings (i.e. readable.setEncoding) like the StringDecoder of
+// Node, which keeps the bytes of an incomplete character (or base64 group) until the next chunk
+type decoder struct {
+	encoding string
+	codec    buffer.StringCodec
+	pending  []byte
+}
+
+// newDecoder of the encoding, where a missing encoding is utf8. The decoder is nil if the encoding is not supported.
+func newDecoder(encoding string) *decoder {
+	encoding = strings.ToLower(encoding)
+	switch encoding {
+	case "":
+		encoding = "utf8"
+	case "utf-8":
+		encoding = "utf8"
+	}
+
+	codec := buffer.StringCodecByName(encoding)
+	if codec == nil {
+		return nil
+	}
+
+	return &decoder{encoding: encoding, codec: codec}
+}
+
+// write the bytes, returning the decoded complete characters
+func (d *decoder) write(data []byte) string {
+	data = append(d.pending, data...)
+	complete := len(data)
+	switch d.encoding {
+	case "utf8":
+		complete = completeUTF8(data)
+	case "base64":
+		complete -= len(data) % 3 //nolint:mnd // three bytes per four base64 characters
+	}
+
+	d.pending = append([]byte(nil), data[complete:]...)
+
+	return d.codec.Encode(data[:complete])
+}
+
+// end of the input, returning the decoded remaining bytes
+func (d *decoder) end() string {
+	data := d.pending
+	d.pending = nil
+	if len(data) == 0 {
+		return ""
+	}
+
+	return d.codec.Encode(data)
+}
+
+// completeUTF8 is the length of the data without the bytes of an incomplete character at its end
+func completeUTF8(data []byte) int {
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				return i
+			}
+
+			break
+		}
+	}
+
+	return len(data)
+}
@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		encoding string
+		chunks   [][]byte
+		expected []string
+	}{
+		"utf8": {
+			encoding: "utf8",
+			chunks:   [][]byte{[]byte("ab")},
+			expected: []string{"ab", ""},
+		},
+		"utf8 split character": {
+			encoding: "UTF-8",
+			chunks:   [][]byte{{0xe2}, {0x82}, {0xac, 'x'}},
+			expected: []string{"", "", "€x", ""},
+		},
+		"utf8 incomplete character at end": {
+			encoding: "",
+			chunks:   [][]byte{{'a', 0xe2, 0x82}},
+			expected: []string{"a", "�"},
+		},
+		"hex": {
+			encoding: "hex",
+			chunks:   [][]byte{{0x01}, {0xff}},
+			expected: []string{"01", "ff", ""},
+		},
+		"base64 split group": {
+			encoding: "base64",
+			chunks:   [][]byte{[]byte("ab"), []byte("cd")},
+			expected: []string{"", "YWJj", "ZA=="},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			d := newDecoder(tt.encoding)
+
+			// Act
+			var res []string
+			for _, chunk := range tt.chunks {
+				res = append(res, d.write(chunk))
+			}
+			res = append(res, d.end())
+
+			// Assert
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestDecoder_UnsupportedEncoding(t *testing.T) {
+	t.Parallel()
+	// Act
+	d := newDecoder("utf7")
+
+	// Assert
+	assert.Nil(t, d)
+}
@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"github.com/dop251/goja"
+)
+
+// Destroy the stream with the optional error, i.e. emit 'error' (if there is an error) and 'close' on the next tick.
+// The callback is called with the error once _destroy is done.
+func (s *Stream) Destroy(call goja.FunctionCall) goja.Value {
+	this, err, cb := call.This, call.Argument(0), call.Argument(1)
+	r, w := s.readableStateOf(this), s.writableStateOf(this)
+	if r == nil && w == nil {
+		r = s.readableState(this)
+	}
+
+	if (w != nil && w.destroyed) || (r != nil && r.destroyed) {
+		s.invoke(cb)
+
+		return this
+	}
+
+	if w != nil && (len(w.buffered) > 0 || len(w.onFinished) > 0) {
+		s.nextTick(func() {
+			s.errorBuffer(w)
+		})
+	}
+
+	s.setErrored(r, w, err)
+	if w != nil {
+		w.destroyed = true
+	}
+	if r != nil {
+		r.destroyed = true
+	}
+
+	called := false
+	onDestroy := func(err goja.Value) {
+		if called {
+			return
+		}
+		called = true
+
+		s.setErrored(r, w, err)
+		if w != nil {
+			w.closed = true
+		}
+		if r != nil {
+			r.closed = true
+		}
+
+		s.invoke(cb, err)
+		s.nextTick(func() {
+			if truthy(err) {
+				s.emitError(this, r, w, err)
+			}
+			s.emitClose(this, r, w)
+		})
+	}
+
+	if !truthy(err) {
+		err = goja.Null()
+	}
+	if thrown := s.try(func() {
+		s.call(this, "_destroy", err, s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+			onDestroy(call.Argument(0))
+
+			return goja.Undefined()
+		}))
+	}); thrown != nil {
+		onDestroy(thrown)
+	}
+
+	return this
+}
+
+// DefaultDestroy is the default _destroy, which calls the callback with the error
+func (s *Stream) DefaultDestroy(call goja.FunctionCall) goja.Value {
+	s.invoke(call.Argument(1), call.Argument(0))
+
+	return goja.Undefined()
+}
+
+// setErrored sets the error of the readable and writable state unless they have an error already
+func (s *Stream) setErrored(r *readableState, w *writableState, err goja.Value) {
+	if !truthy(err) {
+		return
+	}
+
+	if w != nil && w.errored == nil {
+		w.errored = err
+	}
+	if r != nil && r.errored == nil {
+		r.errored = err
+	}
+}
+
+// emitError emits the 'error' event unless an error was emitted already
+func (s *Stream) emitError(this goja.Value, r *readableState, w *writableState, err goja.Value) {
+	if (w != nil && w.errorEmitted) || (r != nil && r.errorEmitted) {
+		return
+	}
+
+	if w != nil {
+		w.errorEmitted = true
+	}
+	if r != nil {
+		r.errorEmitted = true
+	}
+	s.emit(this, "error", err)
+}
+
+// emitClose emits the 'close' event unless the stream was created with emitClose false
+func (s *Stream) emitClose(this goja.Value, r *readableState, w *writableState) {
+	if w != nil {
+		w.closeEmitted = true
+	}
+	if r != nil {
+		r.closeEmitted = true
+	}
+
+	if (w != nil && w.emitClose) || (r != nil && r.emitClose) {
+		s.emit(this, "close")
+	}
+}
+
+// errorOrDestroy destroys the stream with the error if it is destroyed automatically, or else emits the error
+func (s *Stream) errorOrDestroy(this goja.Value, err goja.Value, sync bool) {
+	r, w := s.readableStateOf(this), s.writableStateOf(this)
+	if (w != nil && w.destroyed) || (r != nil && r.destroyed) {
+		return
+	}
+
+	if (r != nil && r.autoDestroy) || (w != nil && w.autoDestroy) {
+		s.call(this, "destroy", err)
+
+		return
+	}
+
+	if !truthy(err) {
+		return
+	}
+
+	s.setErrored(r, w, err)
+	if sync {
+		s.nextTick(func() {
+			s.emitError(this, r, w, err)
+		})
+	} else {
+		s.emitError(this, r, w, err)
+	}
+}
+
+// destroyer destroys the stream with the error, which is an AbortError if the stream did not finish
+func (s *Stream) destroyer(this goja.Value, err goja.Value) {
+	r, w := s.readableStateOf(this), s.writableStateOf(this)
+	if (w != nil && w.destroyed) || (r != nil && r.destroyed) {
+		return
+	}
+
+	if !truthy(err) && !((r == nil || r.endEmitted) && (w == nil || w.finished)) {
+		err = s.abortError()
+	}
+
+	s.call(this, "destroy", err)
+}
+
+// abortError of an aborted operation, e.g. a stream that is destroyed before it finished
+func (s *Stream) abortError() goja.Value {
+	err := s.newError("ABORT_ERR", "The operation was aborted")
+	_ = err.Set("name", "AbortError")
+
+	return err
+}
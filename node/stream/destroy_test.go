@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestroy(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"without error": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+readable.on('close', function() { received.push('close:' + readable.closed); });
+readable.destroy();
+received.push('destroyed:' + readable.destroyed);`,
+			expected: []any{"destroyed:true", "close:true"},
+		},
+		"with error": {
+			script: `
+var writable = new stream.Writable();
+writable.on('error', function(err) { received.push('error:' + err.message); });
+writable.on('close', function() { received.push('close'); });
+writable.destroy(new Error('failed'), function(err) { received.push('callback:' + err.message); });
+received.push('errored:' + writable.errored.message);`,
+			expected: []any{"callback:failed", "errored:failed", "error:failed", "close"},
+		},
+		"custom destroy": {
+			script: `
+var readable = new stream.Readable({
+	read() {},
+	destroy(err, callback) { received.push('_destroy:' + err); callback(new Error('replaced')); },
+});
+readable.on('error', function(err) { received.push('error:' + err.message); });
+readable.destroy();`,
+			expected: []any{"_destroy:null", "error:replaced"},
+		},
+		"twice": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+readable.on('close', function() { received.push('close'); });
+readable.destroy();
+readable.destroy(null, function() { received.push('callback'); });`,
+			expected: []any{"callback", "close"},
+		},
+		"emitClose false": {
+			script: `
+var readable = new stream.Readable({ emitClose: false, read() {} });
+readable.on('close', function() { received.push('close'); });
+readable.destroy();`,
+			expected: []any{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
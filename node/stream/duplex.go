@@ -0,0 +1,173 @@
+package stream
+
+import (
+	"github.com/dop251/goja"
+)
+
+// Duplex is the constructor of a stream that is both readable and writable. Like Node, a Duplex inherits from Readable
+// and has the methods of Writable. Unless allowHalfOpen is true, the writable side is ended once the readable side
+// ended.
+func (s *Stream) Duplex(call goja.ConstructorCall) *goja.Object {
+	s.initDuplex(call.This, s.options(call.Argument(0)))
+
+	return nil
+}
+
+// initDuplex initializes the readable and writable state of the stream
+func (s *Stream) initDuplex(this *goja.Object, options *goja.Object) {
+	r := s.initReadable(this, options, true)
+	w := s.initWritable(this, options, true)
+	_ = this.Set("allowHalfOpen", boolOption(options, "allowHalfOpen", true))
+
+	if readable := options.Get("readable"); readable != nil && readable.StrictEquals(s.r.ToValue(false)) {
+		r.disabled = true
+		r.ended = true
+		r.endEmitted = true
+	}
+
+	if writable := options.Get("writable"); writable != nil && writable.StrictEquals(s.r.ToValue(false)) {
+		w.disabled = true
+		w.ending = true
+		w.ended = true
+		w.finished = true
+	}
+
+	s.function(this, options, "read", "_read")
+	s.function(this, options, "write", "_write")
+	s.function(this, options, "writev", "_writev")
+	s.function(this, options, "destroy", "_destroy")
+	s.function(this, options, "final", "_final")
+}
+
+// setTransform sets the methods of a transform stream on the prototype
+func (s *Stream) setTransform(prototype *goja.Object) {
+	s.set(prototype, []method{
+		{name: "_transform", fn: s.NotImplemented("_transform()")},
+		{name: "_write", fn: s.TransformWrite},
+		{name: "_read", fn: s.TransformRead},
+	})
+	_ = prototype.Set("_final", s.final)
+}
+
+// Transform is the constructor of a Duplex whose written chunks are transformed into the chunks that are read, i.e.
+// new Transform({ transform(chunk, encoding, callback) {} }). The optional flush pushes the final chunks once the
+// writable side ended.
+func (s *Stream) Transform(call goja.ConstructorCall) *goja.Object {
+	options := s.options(call.Argument(0))
+	s.initDuplex(call.This, options)
+	s.readableState(call.This).sync = false
+	s.function(call.This, options, "transform", "_transform")
+	s.function(call.This, options, "flush", "_flush")
+
+	s.on(call.This, "prefinish", func(c goja.FunctionCall) goja.Value {
+		if !c.This.ToObject(s.r).Get("_final").SameAs(s.final) {
+			s.callFinal(c.This, nil)
+		}
+
+		return goja.Undefined()
+	})
+
+	return nil
+}
+
+// Final is the _final of a transform stream, which calls _flush and ends the readable side
+func (s *Stream) Final(call goja.FunctionCall) goja.Value {
+	s.callFinal(call.This, call.Argument(0))
+
+	return goja.Undefined()
+}
+
+// callFinal flushes the transform stream with _flush (if it is implemented) and ends the readable side. The callback
+// (if any) is called once the stream is flushed.
+func (s *Stream) callFinal(this goja.Value, cb goja.Value) {
+	flush, ok := goja.AssertFunction(this.ToObject(s.r).Get("_flush"))
+	if !ok || s.readableState(this).destroyed {
+		s.call(this, "push", goja.Null())
+		s.invoke(cb)
+
+		return
+	}
+
+	if _, err := flush(this, s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		if er := call.Argument(0); truthy(er) {
+			if _, ok := goja.AssertFunction(cb); ok {
+				s.invoke(cb, er)
+			} else {
+				s.call(this, "destroy", er)
+			}
+
+			return goja.Undefined()
+		}
+
+		if data := call.Argument(1); !goja.IsNull(data) && !goja.IsUndefined(data) {
+			s.call(this, "push", data)
+		}
+		s.call(this, "push", goja.Null())
+		s.invoke(cb)
+
+		return goja.Undefined()
+	})); err != nil {
+		panic(err)
+	}
+}
+
+// TransformWrite is the _write of a transform stream, which transforms the chunk with _transform and pushes the
+// result. The callback of the write is held back while the readable side is full.
+func (s *Stream) TransformWrite(call goja.FunctionCall) goja.Value {
+	this, callback := call.This, call.Argument(2)
+	r, w := s.readableState(this), s.writableState(this)
+	length := r.length
+
+	s.call(this, "_transform", call.Argument(0), call.Argument(1), s.r.ToValue(func(c goja.FunctionCall) goja.Value {
+		if err := c.Argument(0); truthy(err) {
+			s.invoke(callback, err)
+
+			return goja.Undefined()
+		}
+
+		if val := c.Argument(1); !goja.IsNull(val) && !goja.IsUndefined(val) {
+			s.call(this, "push", val)
+		}
+
+		switch {
+		case r.ended:
+			s.nextTick(func() { s.invoke(callback) })
+		case w.ended || length == r.length || r.length < r.highWaterMark:
+			s.invoke(callback)
+		default:
+			_ = this.ToObject(s.r).SetSymbol(s.transformSymbol, callback)
+		}
+
+		return goja.Undefined()
+	}))
+
+	return goja.Undefined()
+}
+
+// TransformRead is the _read of a transform stream, which calls the held back callback of the last write
+func (s *Stream) TransformRead(call goja.FunctionCall) goja.Value {
+	object := call.This.ToObject(s.r)
+	if callback := object.GetSymbol(s.transformSymbol); callback != nil && !goja.IsNull(callback) && !goja.IsUndefined(callback) {
+		_ = object.SetSymbol(s.transformSymbol, goja.Null())
+		s.invoke(callback)
+	}
+
+	return goja.Undefined()
+}
+
+// setPassThrough sets the methods of a PassThrough on the prototype
+func (s *Stream) setPassThrough(prototype *goja.Object) {
+	_ = prototype.Set("_transform", s.PassThroughTransform)
+}
+
+// PassThrough is the constructor of a Transform that passes the written chunks through as is
+func (s *Stream) PassThrough(call goja.ConstructorCall) *goja.Object {
+	return s.Transform(call)
+}
+
+// PassThroughTransform is the _transform of a PassThrough, which pushes the chunk as is
+func (s *Stream) PassThroughTransform(call goja.FunctionCall) goja.Value {
+	s.invoke(call.Argument(2), goja.Null(), call.Argument(0))
+
+	return goja.Undefined()
+}
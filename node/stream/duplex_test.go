@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplex(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var duplex = new stream.Duplex({
+	readableObjectMode: true,
+	read() { this.push({ read: true }); this.push(null); },
+	write(chunk, encoding, callback) { received.push('write:' + chunk); callback(); },
+});
+duplex.on('data', function(data) { received.push('data:' + data.read); });
+duplex.on('end', function() { received.push('end'); });
+duplex.on('finish', function() { received.push('finish'); });
+duplex.end('a');
+[duplex.readableObjectMode, duplex.writableObjectMode, duplex.allowHalfOpen, received]`)
+
+	// Assert
+	assert.Equal(t, []any{true, false, true, []any{"write:a", "data:true", "finish", "end"}}, res.Export())
+}
+
+func TestDuplex_AllowHalfOpen(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var duplex = new stream.Duplex({
+	allowHalfOpen: false,
+	read() { this.push(null); },
+	write(chunk, encoding, callback) { callback(); },
+});
+duplex.on('finish', function() { received.push('finish'); });
+duplex.on('close', function() { received.push('close'); });
+duplex.resume();
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"finish", "close"}, res.Export())
+}
+
+func TestDuplex_DisabledSide(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var readable = new stream.Duplex({ writable: false, read() {} });
+var writable = new stream.Duplex({ readable: false, write(chunk, encoding, callback) { callback(); } });
+[readable.readable, readable.writable, writable.readable, writable.writable]`)
+
+	// Assert
+	assert.Equal(t, []any{true, false, false, true}, res.Export())
+}
+
+func TestTransform(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var transform = new stream.Transform({
+	transform(chunk, encoding, callback) { callback(null, String(chunk).toUpperCase()); },
+	flush(callback) { this.push('!'); callback(); },
+});
+transform.on('data', function(data) { received.push(String(data)); });
+transform.on('end', function() { received.push('end'); });
+transform.write('ab');
+transform.end('cd');
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"AB", "CD", "!", "end"}, res.Export())
+}
+
+func TestTransform_Backpressure(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var transformed = 0;
+var transform = new stream.Transform({
+	readableHighWaterMark: 1,
+	writableHighWaterMark: 1,
+	transform(chunk, encoding, callback) { transformed++; callback(null, chunk); },
+});
+var writes = [transform.write('a'), transform.write('b'), transform.write('c')];
+[writes, transformed, transform.readableLength]`)
+
+	// Assert
+	assert.Equal(t, []any{[]any{false, false, false}, int64(1), int64(1)}, res.Export())
+}
+
+func TestTransform_Errors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"transform error": {
+			script: `
+var transform = new stream.Transform({ transform(chunk, encoding, callback) { callback(new Error('failed')); } });
+transform.on('error', function(err) { received.push(err.message); });
+transform.write('a');`,
+			expected: []any{"failed"},
+		},
+		"transform not implemented": {
+			script: `
+var transform = new stream.Transform();
+transform.on('error', function(err) { received.push(err.code + ': ' + err.message); });
+try { transform.write('a'); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_METHOD_NOT_IMPLEMENTED: The _transform() method is not implemented"},
+		},
+		"flush error": {
+			script: `
+var transform = new stream.Transform({
+	transform(chunk, encoding, callback) { callback(); },
+	flush(callback) { callback(new Error('flush failed')); },
+});
+transform.on('error', function(err) { received.push(err.message); });
+transform.end();`,
+			expected: []any{"flush failed"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
+
+func TestPassThrough(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var passThrough = new stream.PassThrough({ objectMode: true });
+passThrough.on('data', function(data) { received.push(data); });
+passThrough.write({ a: 1 });
+passThrough.end(2);
+received`)
+
+	// Assert
+	assert.Equal(t, []any{map[string]any{"a": int64(1)}, int64(2)}, res.Export())
+}
@@ -0,0 +1,322 @@
+package stream
+
+import (
+	"github.com/Emptyless/go-spectral/node/internal/args"
+	"github.com/dop251/goja"
+)
+
+// Finished calls the callback once the stream is no longer readable or writable, or has errored or closed prematurely,
+// i.e. finished(stream[, options], callback). The returned function removes the listeners of the stream.
+func (s *Stream) Finished(call goja.FunctionCall) goja.Value {
+	stream, options, callback := call.Argument(0), call.Argument(1), call.Argument(2)
+	if len(call.Arguments) == 2 { //nolint:mnd // finished(stream, callback)
+		options, callback = goja.Undefined(), call.Argument(1)
+	} else if _, ok := options.(*goja.Object); !ok && !goja.IsUndefined(options) && !goja.IsNull(options) {
+		panic(args.InvalidArgType(s.r, "options", "of type object", options))
+	}
+
+	fn, ok := goja.AssertFunction(callback)
+	if !ok {
+		panic(args.InvalidArgType(s.r, "callback", "of type function", callback))
+	}
+
+	cleanup := s.eos(s.nodeStream(stream, "stream"), s.options(options), func(this goja.Value, err goja.Value) {
+		if _, err := fn(this, err); err != nil {
+			panic(err)
+		}
+	})
+
+	return s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		cleanup()
+
+		return goja.Undefined()
+	})
+}
+
+// nodeStream of the argument, panicking with an ERR_INVALID_ARG_TYPE TypeError if it is not a stream
+func (s *Stream) nodeStream(value goja.Value, name string) *goja.Object {
+	if object, ok := value.(*goja.Object); ok && s.isNodeStream(object) {
+		return object
+	}
+
+	panic(args.InvalidArgType(s.r, name, "an instance of Stream", value))
+}
+
+// eos (end of stream) calls the callback once with the error of the stream, which is undefined if the stream ended and
+// finished. The options may limit the sides of the stream that are waited for (i.e. readable and writable) and whether
+// an 'error' event is handled. The returned cleanup removes the listeners of the stream.
+func (s *Stream) eos(stream *goja.Object, options *goja.Object, callback func(this goja.Value, err goja.Value)) func() {
+	called := false
+	done := func(err goja.Value) {
+		if called {
+			return
+		}
+		called = true
+		callback(stream, err)
+	}
+
+	readable := boolOption(options, "readable", s.isReadableNodeStream(stream))
+	writable := boolOption(options, "writable", s.isWritableNodeStream(stream))
+	r, w := s.readableStateOf(stream), s.writableStateOf(stream)
+
+	willEmitClose := s.willEmitClose(stream) && s.isReadableNodeStream(stream) == readable && s.isWritableNodeStream(stream) == writable
+	writableFinished := s.isWritableFinished(stream, false)
+	readableFinished := s.isReadableFinished(stream, false)
+
+	onfinish := s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		writableFinished = true
+		if s.isDestroyed(stream) {
+			willEmitClose = false
+		}
+
+		if willEmitClose && (!get(stream, "readable").ToBoolean() || readable) {
+			return goja.Undefined()
+		}
+
+		if !readable || readableFinished {
+			done(goja.Undefined())
+		}
+
+		return goja.Undefined()
+	})
+
+	onend := s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		readableFinished = true
+		if s.isDestroyed(stream) {
+			willEmitClose = false
+		}
+
+		if willEmitClose && (!get(stream, "writable").ToBoolean() || writable) {
+			return goja.Undefined()
+		}
+
+		if !writable || writableFinished {
+			done(goja.Undefined())
+		}
+
+		return goja.Undefined()
+	})
+
+	onlegacyfinish := s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		if !get(stream, "writable").ToBoolean() {
+			s.invoke(onfinish)
+		}
+
+		return goja.Undefined()
+	})
+
+	onerror := s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		done(call.Argument(0))
+
+		return goja.Undefined()
+	})
+
+	closed := s.isClosed(stream)
+	onclose := s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		closed = true
+		if errored := s.errored(stream); errored != nil {
+			done(errored)
+
+			return goja.Undefined()
+		}
+
+		if (readable && !readableFinished && s.isReadableNodeStream(stream) && !s.isReadableFinished(stream, false)) ||
+			(writable && !writableFinished && !s.isWritableFinished(stream, false)) {
+			done(s.newError("ERR_STREAM_PREMATURE_CLOSE", "Premature close"))
+
+			return goja.Undefined()
+		}
+
+		done(goja.Undefined())
+
+		return goja.Undefined()
+	})
+
+	onclosed := func() {
+		closed = true
+		if errored := s.errored(stream); errored != nil {
+			done(errored)
+
+			return
+		}
+
+		done(goja.Undefined())
+	}
+
+	legacy := writable && w == nil
+	if legacy {
+		s.call(stream, "on", s.r.ToValue("end"), onlegacyfinish)
+		s.call(stream, "on", s.r.ToValue("close"), onlegacyfinish)
+	}
+
+	s.call(stream, "on", s.r.ToValue("end"), onend)
+	s.call(stream, "on", s.r.ToValue("finish"), onfinish)
+	if v := options.Get("error"); v == nil || !v.StrictEquals(s.r.ToValue(false)) {
+		s.call(stream, "on", s.r.ToValue("error"), onerror)
+	}
+	s.call(stream, "on", s.r.ToValue("close"), onclose)
+
+	readableNow, readableKnown := s.isReadable(stream)
+	writableNow, writableKnown := s.isWritable(stream)
+	switch {
+	case closed:
+		s.nextTick(func() { s.invoke(onclose) })
+	case (w != nil && w.errorEmitted) || (r != nil && r.errorEmitted):
+		if !willEmitClose {
+			s.nextTick(onclosed)
+		}
+	case !readable && (!willEmitClose || readableNow) && (writableFinished || (writableKnown && !writableNow)) && (w == nil || w.pendingcb == 0):
+		s.nextTick(onclosed)
+	case !writable && (!willEmitClose || writableNow) && (readableFinished || (readableKnown && !readableNow)):
+		s.nextTick(onclosed)
+	}
+
+	return func() {
+		called = true
+		if legacy {
+			s.removeListener(stream, "end", onlegacyfinish)
+			s.removeListener(stream, "close", onlegacyfinish)
+		}
+		s.removeListener(stream, "finish", onfinish)
+		s.removeListener(stream, "end", onend)
+		s.removeListener(stream, "error", onerror)
+		s.removeListener(stream, "close", onclose)
+	}
+}
+
+// isFunction if the property of the object is a function
+func isFunction(object *goja.Object, name string) bool {
+	_, ok := goja.AssertFunction(object.Get(name))
+
+	return ok
+}
+
+// isNodeStream if the object is a readable or writable stream, which may be a stream that is not created by this
+// package as long as it is an EventEmitter with a pipe or write method
+func (s *Stream) isNodeStream(object *goja.Object) bool {
+	return s.readableStateOf(object) != nil || s.writableStateOf(object) != nil ||
+		(isFunction(object, "on") && (isFunction(object, "pipe") || isFunction(object, "write")))
+}
+
+// isReadableNodeStream if the stream is readable, i.e. it is not a Duplex whose readable side is disabled
+func (s *Stream) isReadableNodeStream(stream *goja.Object) bool {
+	r, w := s.readableStateOf(stream), s.writableStateOf(stream)
+
+	return isFunction(stream, "pipe") && isFunction(stream, "on") && (w == nil || (r != nil && !r.disabled))
+}
+
+// isWritableNodeStream if the stream is writable, i.e. it is not a Duplex whose writable side is disabled
+func (s *Stream) isWritableNodeStream(stream *goja.Object) bool {
+	r, w := s.readableStateOf(stream), s.writableStateOf(stream)
+
+	return isFunction(stream, "write") && isFunction(stream, "on") && (r == nil || w == nil || !w.disabled)
+}
+
+// willEmitClose if the stream emits 'close' once it is destroyed automatically
+func (s *Stream) willEmitClose(stream *goja.Object) bool {
+	if w := s.writableStateOf(stream); w != nil {
+		return w.autoDestroy && w.emitClose && !w.closed
+	}
+
+	if r := s.readableStateOf(stream); r != nil {
+		return r.autoDestroy && r.emitClose && !r.closed
+	}
+
+	return false
+}
+
+// isDestroyed if the stream is destroyed
+func (s *Stream) isDestroyed(stream *goja.Object) bool {
+	r, w := s.readableStateOf(stream), s.writableStateOf(stream)
+
+	return get(stream, "destroyed").ToBoolean() || (w != nil && w.destroyed) || (w == nil && r != nil && r.destroyed)
+}
+
+// isClosed if the stream emitted or is about to emit 'close'
+func (s *Stream) isClosed(stream *goja.Object) bool {
+	r, w := s.readableStateOf(stream), s.writableStateOf(stream)
+	if r == nil && w == nil {
+		return get(stream, "closed").ToBoolean()
+	}
+
+	return (w != nil && w.closed) || (r != nil && r.closed)
+}
+
+// isWritableFinished if the stream emitted 'finish', or (unless strict) ended and wrote every chunk
+func (s *Stream) isWritableFinished(stream *goja.Object, strict bool) bool {
+	if !s.isWritableNodeStream(stream) {
+		return false
+	}
+
+	if get(stream, "writableFinished").StrictEquals(s.r.ToValue(true)) {
+		return true
+	}
+
+	w := s.writableStateOf(stream)
+	if w == nil || w.errored != nil {
+		return false
+	}
+
+	return w.finished || (!strict && w.ended && w.length == 0)
+}
+
+// isReadableFinished if the stream emitted 'end', or (unless strict) pushed null and has no chunks left
+func (s *Stream) isReadableFinished(stream *goja.Object, strict bool) bool {
+	if !s.isReadableNodeStream(stream) {
+		return false
+	}
+
+	r := s.readableStateOf(stream)
+	if r == nil || r.errored != nil {
+		return false
+	}
+
+	return r.endEmitted || (!strict && r.ended && r.length == 0)
+}
+
+// isReadable if the stream can still be read, where known is false if the stream has no readable property
+func (s *Stream) isReadable(stream *goja.Object) (readable bool, known bool) {
+	v, ok := get(stream, "readable").Export().(bool)
+	if !ok {
+		return false, false
+	}
+
+	return !s.isDestroyed(stream) && s.isReadableNodeStream(stream) && v && !s.isReadableFinished(stream, true), true
+}
+
+// isWritable if the stream can still be written, where known is false if the stream has no writable property
+func (s *Stream) isWritable(stream *goja.Object) (writable bool, known bool) {
+	v, ok := get(stream, "writable").Export().(bool)
+	if !ok {
+		return false, false
+	}
+
+	ended := get(stream, "writableEnded").ToBoolean()
+	if w := s.writableStateOf(stream); w != nil && w.errored == nil {
+		ended = ended || w.ended
+	}
+
+	return !s.isDestroyed(stream) && s.isWritableNodeStream(stream) && v && !ended, true
+}
+
+// errored is the error of the writable or readable side of the stream, or nil if the stream did not error
+func (s *Stream) errored(stream *goja.Object) goja.Value {
+	if w := s.writableStateOf(stream); w != nil && truthy(w.errored) {
+		return w.errored
+	}
+
+	if r := s.readableStateOf(stream); r != nil && truthy(r.errored) {
+		return r.errored
+	}
+
+	return nil
+}
+
+// get the property of the object, which is undefined if the object does not have the property
+func get(object *goja.Object, name string) goja.Value {
+	if v := object.Get(name); v != nil {
+		return v
+	}
+
+	return goja.Undefined()
+}
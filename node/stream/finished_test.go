@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinished(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"readable ended": {
+			script: `
+var readable = new stream.Readable({ read() { this.push(null); } });
+stream.finished(readable, function(err) { received.push('finished:' + err); });
+readable.resume();`,
+			expected: []any{"finished:undefined"},
+		},
+		"writable finished": {
+			script: `
+var writable = new stream.Writable({ write(chunk, encoding, callback) { callback(); } });
+stream.finished(writable, function(err) { received.push('finished:' + err + ':' + (this === writable)); });
+writable.end('a');`,
+			expected: []any{"finished:undefined:true"},
+		},
+		"premature close": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+stream.finished(readable, function(err) { received.push(err.code + ': ' + err.message); });
+readable.destroy();`,
+			expected: []any{"ERR_STREAM_PREMATURE_CLOSE: Premature close"},
+		},
+		"error": {
+			script: `
+var writable = new stream.Writable();
+stream.finished(writable, function(err) { received.push(err.message); });
+writable.destroy(new Error('failed'));`,
+			expected: []any{"failed"},
+		},
+		"already finished": {
+			script: `
+var readable = new stream.Readable({ read() { this.push(null); } });
+readable.resume();
+Promise.resolve().then(function() {}).then(function() {
+	stream.finished(readable, function(err) { received.push('finished:' + err); });
+});`,
+			expected: []any{"finished:undefined"},
+		},
+		"readable side only": {
+			script: `
+var duplex = new stream.Duplex({ read() { this.push(null); }, write(chunk, encoding, callback) { callback(); } });
+stream.finished(duplex, { writable: false }, function(err) { received.push('finished:' + err); });
+duplex.resume();`,
+			expected: []any{"finished:undefined"},
+		},
+		"cleanup": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+var cleanup = stream.finished(readable, function() { received.push('called'); });
+cleanup();
+readable.destroy();`,
+			expected: []any{},
+		},
+		"not a stream": {
+			script: `
+try { stream.finished({}, function() {}); } catch (err) { received.push(err.code); }`,
+			expected: []any{"ERR_INVALID_ARG_TYPE"},
+		},
+		"promise": {
+			script: `
+var writable = new stream.PassThrough();
+require('stream/promises').finished(writable).then(function() { received.push('resolved'); });
+writable.resume();
+writable.end('a');`,
+			expected: []any{"resolved"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package stream
+
+import (
+	"github.com/Emptyless/go-spectral/node/internal/args"
+	"github.com/dop251/goja"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
+)
+
+// asyncIteratorSymbol is Symbol.asyncIterator, or Symbol.for('Symbol.asyncIterator') if the runtime does not define it,
+// which is the symbol that transpilers (e.g. esbuild) fall back to as well
+func (s *Stream) asyncIteratorSymbol() *goja.Symbol {
+	symbol := s.r.Get("Symbol").ToObject(s.r)
+	if sym, ok := symbol.Get("asyncIterator").(*goja.Symbol); ok {
+		return sym
+	}
+
+	sym, _ := s.call(symbol, "for", s.r.ToValue("Symbol.asyncIterator")).(*goja.Symbol)
+
+	return sym
+}
+
+// isIterable if the object is an iterable or an async iterable
+func (s *Stream) isIterable(object *goja.Object) bool {
+	_, async := goja.AssertFunction(object.GetSymbol(s.asyncIteratorSymbol()))
+	_, sync := goja.AssertFunction(object.GetSymbol(goja.SymIterator))
+
+	return async || sync
+}
+
+// iteration is a pending call of next of the async iterator of a readable stream
+type iteration struct {
+	resolve func(any) error
+	reject  func(any) error
+}
+
+// AsyncIterator is readable[Symbol.asyncIterator](), i.e. an async iterator of the chunks of the stream. The stream is
+// destroyed once the iterator is done, unless the destroyOnReturn option of readable.iterator is false.
+func (s *Stream) AsyncIterator(call goja.FunctionCall) goja.Value {
+	stream := call.This.ToObject(s.r)
+	state := s.readableState(stream)
+	destroyOnReturn := true
+
+	var (
+		err      goja.Value
+		errSet   bool
+		done     bool
+		callback func()
+		pending  []*iteration
+		pump     func()
+	)
+
+	next := s.on(stream, "readable", func(goja.FunctionCall) goja.Value {
+		if callback != nil {
+			fn := callback
+			callback = nil
+			fn()
+		}
+
+		return goja.Undefined()
+	})
+
+	options := s.r.NewObject()
+	_ = options.Set("writable", false)
+	cleanup := s.eos(stream, options, func(_ goja.Value, e goja.Value) {
+		if truthy(e) {
+			if err == nil {
+				err = e
+			}
+		}
+		errSet = true
+		if callback != nil {
+			fn := callback
+			callback = nil
+			fn()
+		}
+	})
+
+	finally := func() {
+		done = true
+		if (truthy(err) || destroyOnReturn) && (!errSet || state.autoDestroy) {
+			s.destroyer(stream, goja.Null())
+		} else {
+			s.removeListener(stream, "readable", next)
+			cleanup()
+		}
+	}
+
+	result := func(value goja.Value, done bool) *goja.Object {
+		res := s.r.NewObject()
+		_ = res.Set("value", value)
+		_ = res.Set("done", done)
+
+		return res
+	}
+
+	pump = func() {
+		for len(pending) > 0 {
+			it := pending[0]
+			if done {
+				pending = pending[1:]
+				_ = it.resolve(result(goja.Undefined(), true))
+
+				continue
+			}
+
+			chunk := goja.Null()
+			if thrown := s.try(func() {
+				if !state.destroyed {
+					chunk = s.call(stream, "read")
+				}
+			}); thrown != nil {
+				if err == nil {
+					err = thrown
+				}
+				errSet = true
+				pending = pending[1:]
+				finally()
+				_ = it.reject(err)
+
+				continue
+			}
+
+			switch {
+			case !goja.IsNull(chunk):
+				pending = pending[1:]
+				_ = it.resolve(result(chunk, false))
+			case errSet && err != nil:
+				pending = pending[1:]
+				finally()
+				_ = it.reject(err)
+			case errSet:
+				pending = pending[1:]
+				finally()
+				_ = it.resolve(result(goja.Undefined(), true))
+			default:
+				callback = pump
+
+				return
+			}
+		}
+	}
+
+	iterator := s.r.NewObject()
+	_ = iterator.Set("stream", stream)
+	_ = iterator.Set("next", func(goja.FunctionCall) goja.Value {
+		promise, resolve, reject := s.r.NewPromise()
+		pending = append(pending, &iteration{resolve: resolve, reject: reject})
+		if len(pending) == 1 {
+			pump()
+		}
+
+		return s.r.ToValue(promise)
+	})
+	_ = iterator.Set("return", func(call goja.FunctionCall) goja.Value {
+		promise, resolve, _ := s.r.NewPromise()
+		if !done {
+			callback = nil
+			finally()
+			pump()
+		}
+		_ = resolve(result(call.Argument(0), true))
+
+		return s.r.ToValue(promise)
+	})
+	_ = iterator.SetSymbol(s.asyncIteratorSymbol(), func(call goja.FunctionCall) goja.Value {
+		return call.This
+	})
+
+	return iterator
+}
+
+// From is Readable.from(iterable[, options]), i.e. a readable stream of the values of an iterable or async iterable. A
+// string or Buffer is a single chunk. The stream is in object mode with a highWaterMark of 1 unless the options say
+// otherwise.
+func (s *Stream) From(call goja.FunctionCall) goja.Value {
+	return s.from(call.Argument(0), call.Argument(1))
+}
+
+// from creates the readable stream of the iterable
+func (s *Stream) from(iterable goja.Value, opts goja.Value) *goja.Object {
+	options := s.r.NewObject()
+	_ = options.Set("objectMode", true)
+
+	if _, ok := iterable.(goja.String); ok || s.isBytes(iterable) {
+		s.assign(options, opts)
+		_ = options.Set("read", func(call goja.FunctionCall) goja.Value {
+			s.call(call.This, "push", iterable)
+			s.call(call.This, "push", goja.Null())
+
+			return goja.Undefined()
+		})
+
+		return s.newReadable(options)
+	}
+
+	object, ok := iterable.(*goja.Object)
+	if !ok || !s.isIterable(object) {
+		panic(args.InvalidArgType(s.r, "iterable", "an instance of Iterable", iterable))
+	}
+
+	var iterator *goja.Object
+	_, isAsync := goja.AssertFunction(object.GetSymbol(s.asyncIteratorSymbol()))
+	if isAsync {
+		iterator = s.callSymbol(object, s.asyncIteratorSymbol()).ToObject(s.r)
+	} else {
+		iterator = s.callSymbol(object, goja.SymIterator).ToObject(s.r)
+	}
+
+	_ = options.Set("highWaterMark", 1)
+	s.assign(options, opts)
+	readable := s.newReadable(options)
+
+	reading := false
+	var next func()
+
+	// push the value, returning whether the next value is wanted
+	push := func(value goja.Value) bool {
+		if goja.IsNull(value) {
+			reading = false
+			s.call(readable, "destroy", nodeerrors.NewTypeError(s.r, "ERR_STREAM_NULL_VALUES", "May not write null values to stream"))
+
+			return false
+		}
+
+		if s.call(readable, "push", value).ToBoolean() {
+			return true
+		}
+		reading = false
+
+		return false
+	}
+	destroy := func(err goja.Value) {
+		s.call(readable, "destroy", err)
+	}
+
+	// step handles a result of the iterator, returning whether the next result is wanted synchronously
+	step := func(res goja.Value) bool {
+		result := res.ToObject(s.r)
+		if get(result, "done").ToBoolean() {
+			s.call(readable, "push", goja.Null())
+
+			return false
+		}
+
+		value := get(result, "value")
+		if s.isThenable(value) {
+			s.await(value, func(value goja.Value) {
+				if push(value) {
+					next()
+				}
+			}, destroy)
+
+			return false
+		}
+
+		return push(value)
+	}
+
+	next = func() {
+		for {
+			var res goja.Value
+			if thrown := s.try(func() { res = s.call(iterator, "next") }); thrown != nil {
+				destroy(thrown)
+
+				return
+			}
+
+			if isAsync {
+				s.await(res, func(res goja.Value) {
+					if thrown := s.try(func() {
+						if step(res) {
+							next()
+						}
+					}); thrown != nil {
+						destroy(thrown)
+					}
+				}, destroy)
+
+				return
+			}
+
+			var more bool
+			if thrown := s.try(func() { more = step(res) }); thrown != nil {
+				destroy(thrown)
+
+				return
+			}
+			if !more {
+				return
+			}
+		}
+	}
+
+	_ = readable.Set("_read", func(goja.FunctionCall) goja.Value {
+		if !reading {
+			reading = true
+			next()
+		}
+
+		return goja.Undefined()
+	})
+	_ = readable.Set("_destroy", func(call goja.FunctionCall) goja.Value {
+		err, cb := call.Argument(0), call.Argument(1)
+		s.closeIterator(iterator, err, func(e goja.Value) {
+			if !truthy(e) {
+				e = err
+			}
+			s.nextTick(func() { s.invoke(cb, e) })
+		})
+
+		return goja.Undefined()
+	})
+
+	return readable
+}
+
+// closeIterator of a destroyed stream, i.e. throw the error into the iterator (if any) and return it. The done
+// callback is called with the error of the iterator, which is undefined if it returned.
+func (s *Stream) closeIterator(iterator *goja.Object, err goja.Value, done func(e goja.Value)) {
+	doReturn := func() {
+		if !isFunction(iterator, "return") {
+			done(goja.Undefined())
+
+			return
+		}
+
+		var res goja.Value
+		if thrown := s.try(func() { res = s.call(iterator, "return") }); thrown != nil {
+			done(thrown)
+
+			return
+		}
+		s.await(res, func(res goja.Value) {
+			s.await(get(res.ToObject(s.r), "value"), func(goja.Value) { done(goja.Undefined()) }, done)
+		}, done)
+	}
+
+	if !goja.IsUndefined(err) && !goja.IsNull(err) && isFunction(iterator, "throw") {
+		var res goja.Value
+		if thrown := s.try(func() { res = s.call(iterator, "throw", err) }); thrown != nil {
+			done(thrown)
+
+			return
+		}
+		s.await(res, func(res goja.Value) {
+			result := res.ToObject(s.r)
+			s.await(get(result, "value"), func(goja.Value) {
+				if get(result, "done").ToBoolean() {
+					done(goja.Undefined())
+				} else {
+					doReturn()
+				}
+			}, done)
+		}, done)
+
+		return
+	}
+
+	doReturn()
+}
+
+// newReadable creates a Readable with the options
+func (s *Stream) newReadable(options *goja.Object) *goja.Object {
+	readable, err := s.r.New(s.readable, options)
+	if err != nil {
+		panic(err)
+	}
+
+	return readable
+}
+
+// assign the own properties of the value (if it is an object) to the object like Object.assign
+func (s *Stream) assign(object *goja.Object, value goja.Value) {
+	source, ok := value.(*goja.Object)
+	if !ok {
+		return
+	}
+
+	for _, key := range source.Keys() {
+		_ = object.Set(key, source.Get(key))
+	}
+}
+
+// callSymbol calls the method of the object that is keyed by the symbol
+func (s *Stream) callSymbol(object *goja.Object, symbol *goja.Symbol) goja.Value {
+	fn, _ := goja.AssertFunction(object.GetSymbol(symbol))
+	res, err := fn(object)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// isThenable if the value is a promise or another object with a then method
+func (s *Stream) isThenable(value goja.Value) bool {
+	object, ok := value.(*goja.Object)
+
+	return ok && isFunction(object, "then")
+}
+
+// await the value like the await operator, i.e. the fulfilled or rejected callback is called in a microtask once the
+// value (which may be a promise) settled
+func (s *Stream) await(value goja.Value, fulfilled func(goja.Value), rejected func(goja.Value)) {
+	promise, resolve, _ := s.r.NewPromise()
+	_ = resolve(value)
+	s.call(s.r.ToValue(promise), "then", s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		fulfilled(call.Argument(0))
+
+		return goja.Undefined()
+	}), s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		rejected(call.Argument(0))
+
+		return goja.Undefined()
+	}))
+}
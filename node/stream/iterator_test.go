@@ -0,0 +1,140 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// iterate is a script that collects the values of an async iterator into received, since goja has no for await
+const iterate = `
+var asyncIterator = typeof Symbol.asyncIterator === 'symbol' ? Symbol.asyncIterator : Symbol.for('Symbol.asyncIterator');
+function iterate(iterable, limit) {
+	var iterator = iterable[asyncIterator]();
+	function next() {
+		return iterator.next().then(function(res) {
+			if (res.done) { received.push('done'); return; }
+			received.push(res.value);
+			if (received.length === limit) { return iterator.return().then(function() { received.push('returned'); }); }
+			return next();
+		}, function(err) { received.push('error:' + err.message); });
+	}
+	return next();
+}
+`
+
+func TestAsyncIterator(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"chunks": {
+			script: `
+var readable = new stream.Readable({ objectMode: true, read() {} });
+iterate(readable).then(function() { received.push(readable.destroyed); });
+readable.push('a');
+Promise.resolve().then(function() { readable.push('b'); readable.push(null); });`,
+			expected: []any{"a", "b", "done", true},
+		},
+		"error": {
+			script: `
+var readable = new stream.Readable({ read() { this.destroy(new Error('failed')); } });
+iterate(readable);`,
+			expected: []any{"error:failed"},
+		},
+		"return destroys": {
+			script: `
+var readable = stream.Readable.from([1, 2, 3]);
+iterate(readable, 1).then(function() { received.push(readable.destroyed); });`,
+			expected: []any{int64(1), "returned", true},
+		},
+		"iterator of the iterator": {
+			script: `
+var iterator = new stream.PassThrough()[asyncIterator]();
+received.push(iterator[asyncIterator]() === iterator, typeof iterator.stream);`,
+			expected: []any{true, "object"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+iterate+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
+
+func TestFrom(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"array": {
+			script: `
+var readable = stream.Readable.from(['a', 'b']);
+received.push(readable.readableObjectMode, readable.readableHighWaterMark);
+readable.on('data', function(data) { received.push(data); });`,
+			expected: []any{true, int64(1), "a", "b"},
+		},
+		"string": {
+			script: `
+stream.Readable.from('abc').on('data', function(data) { received.push(data); });`,
+			expected: []any{"abc"},
+		},
+		"options": {
+			script: `
+var readable = stream.Readable.from(['a'], { objectMode: false });
+readable.on('data', function(data) { received.push(typeof data, String(data)); });`,
+			expected: []any{"object", "a"},
+		},
+		"promises": {
+			script: `
+stream.Readable.from([Promise.resolve(1), 2]).on('data', function(data) { received.push(data); });`,
+			expected: []any{int64(1), int64(2)},
+		},
+		"async iterable": {
+			script: `
+var values = [1, 2];
+var iterable = {};
+iterable[asyncIterator] = function() {
+	return { next: function() { return Promise.resolve(values.length ? { value: values.shift(), done: false } : { done: true }); } };
+};
+stream.Readable.from(iterable).on('data', function(data) { received.push(data); }).on('end', function() { received.push('end'); });`,
+			expected: []any{int64(1), int64(2), "end"},
+		},
+		"iterator throws": {
+			script: `
+var iterable = {};
+iterable[Symbol.iterator] = function() { return { next: function() { throw new Error('failed'); } }; };
+stream.Readable.from(iterable).on('error', function(err) { received.push(err.message); }).resume();`,
+			expected: []any{"failed"},
+		},
+		"null value": {
+			script: `
+stream.Readable.from([null]).on('error', function(err) { received.push(err.code); }).resume();`,
+			expected: []any{"ERR_STREAM_NULL_VALUES"},
+		},
+		"not iterable": {
+			script: `
+try { stream.Readable.from(5); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_INVALID_ARG_TYPE: The \"iterable\" argument must be an instance of Iterable. Received type number (5)"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+iterate+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
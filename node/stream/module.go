@@ -1,233 +1,346 @@
 package stream
 
 import (
+	"math"
+
+	"github.com/Emptyless/go-spectral/internal/task"
+	"github.com/Emptyless/go-spectral/node/events"
+	"github.com/Emptyless/go-spectral/node/internal/args"
 	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
 	"github.com/dop251/goja_nodejs/require"
 )
 
 // ModuleName of the stream package
 const ModuleName = "stream"
 
-// Callback to handle a stream
-type Callback struct {
-	// This object
-	This goja.Value
-	// Function to call
-	Function func(goja.FunctionCall) goja.Value
-}
+// defaultHighWaterMark of a stream in bytes, or in objects if the stream is in object mode
+const (
+	defaultHighWaterMark           = 16384
+	defaultObjectModeHighWaterMark = 16
+)
 
-// Stream holds the goja.Runtime and the various methods handled by the Node stream package
+// Stream holds the goja.Runtime and the classes of the stream package. Like Node, every class inherits from the
+// legacy Stream class, which inherits from the EventEmitter of the events package.
 type Stream struct {
-	r                *goja.Runtime
-	data             []goja.Value
-	onData           []*Callback
-	onDataOffset     map[*Callback]int
-	onceError        []*Callback
-	onceErrorHandled map[*Callback]bool
-	onceEnd          []*Callback
-	onceEndHandled   map[*Callback]bool
-	ended            bool
-	error            goja.Value
-}
-
-// PassThrough returns an object implementing Write/Read methods
-func (s *Stream) PassThrough(_ goja.ConstructorCall) *goja.Object {
-	stream := s.r.NewObject()
-	_ = stream.Set("once", s.Once)
-	_ = stream.Set("on", s.On)
-	_ = stream.Set("write", s.Write)
-	_ = stream.Set("end", s.End)
-	_ = stream.Set("push", s.Push)
-
-	s.onceErrorHandled = make(map[*Callback]bool)
-	s.onceEndHandled = make(map[*Callback]bool)
-	s.onDataOffset = make(map[*Callback]int)
-
-	return stream
-}
-
-// Write calls _write function on the callee with the _writecall callback
-func (s *Stream) Write(call goja.FunctionCall) goja.Value {
-	exp := call.This.Export().(map[string]any)
-	_write := exp["_write"].(func(goja.FunctionCall) goja.Value)
-	_writecall := goja.FunctionCall{
-		This:      call.This,
-		Arguments: []goja.Value{call.Arguments[0], s.r.ToValue("utf8"), s.r.ToValue(s.OnWrite)},
-	}
-
-	return _write(_writecall)
-}
-
-// OnWrite handle using Stream.Handle
-func (s *Stream) OnWrite(call goja.FunctionCall) goja.Value {
-	if len(call.Arguments) > 0 {
-		s.error = call.Argument(0)
-		s.Handle()
-	}
-
-	return goja.Null()
-}
-
-// Push data onto Stream
-func (s *Stream) Push(call goja.FunctionCall) goja.Value {
-	s.data = append(s.data, call.Argument(0))
-	return goja.Null()
-}
-
-// End Stream
-func (s *Stream) End(_ goja.FunctionCall) goja.Value {
-	s.ended = true
-	s.Handle()
-	return goja.Null()
-}
-
-// Handle Stream by checking if there is an error and returning if-so. Handle tracks the
-// callbacks registered on the Stream to ensure that they are only called Once.
-func (s *Stream) Handle() { //nolint:cyclop // accepted complexity
-	if s.error != nil {
-		for _, cb := range s.onceError {
-			_, ok := s.onceErrorHandled[cb]
-			if !ok {
-				call := goja.FunctionCall{
-					This:      cb.This,
-					Arguments: []goja.Value{s.error},
-				}
-				cb.Function(call)
-				s.onceErrorHandled[cb] = true
-			}
-		}
+	r *goja.Runtime
+
+	// constructors of the classes
+	stream      *goja.Object
+	readable    *goja.Object
+	writable    *goja.Object
+	duplex      *goja.Object
+	transform   *goja.Object
+	passThrough *goja.Object
+
+	// symbols of the state of a readable and writable stream
+	readableSymbol *goja.Symbol
+	writableSymbol *goja.Symbol
+
+	// symbol of the pending write callback of a transform stream
+	transformSymbol *goja.Symbol
+
+	// final is the _final of a transform stream, which flushes the stream unless a subclass overrides it
+	final *goja.Object
+
+	// emitterPrototype has the methods of the EventEmitter that a stream overrides (e.g. on)
+	emitterPrototype *goja.Object
 
-		return
+	// uint8Array constructor to check the chunks of a stream that is not in object mode
+	uint8Array *goja.Object
+}
+
+// method of a prototype
+type method struct {
+	name string
+	fn   func(goja.FunctionCall) goja.Value
+}
+
+// newStream creates the classes of the stream package in the runtime, extending the EventEmitter of the events package
+func newStream(runtime *goja.Runtime) *Stream {
+	s := &Stream{
+		r:               runtime,
+		readableSymbol:  goja.NewSymbol("readableState"),
+		writableSymbol:  goja.NewSymbol("writableState"),
+		transformSymbol: goja.NewSymbol("transformCallback"),
+		uint8Array:      runtime.Get("Uint8Array").ToObject(runtime),
 	}
 
-	for _, cb := range s.onData {
-		offset, ok := s.onDataOffset[cb]
-		if !ok {
-			offset = -1
-		}
-		for i, data := range s.data {
-			if i <= offset {
-				continue
-			}
-
-			call := goja.FunctionCall{
-				This:      cb.This,
-				Arguments: []goja.Value{data},
-			}
-
-			cb.Function(call)
-			s.onDataOffset[cb] = i
-		}
+	emitter := require.Require(runtime, events.ModuleName).ToObject(runtime)
+	s.emitterPrototype = s.prototype(emitter)
+	s.stream = s.class(s.Constructor, emitter)
+	s.readable = s.class(s.Readable, s.stream)
+	s.writable = s.class(s.Writable, s.stream)
+	s.duplex = s.class(s.Duplex, s.readable)
+	s.transform = s.class(s.Transform, s.duplex)
+	s.passThrough = s.class(s.PassThrough, s.transform)
+
+	s.setReadable(s.prototype(s.readable))
+	s.setWritable(s.prototype(s.writable))
+	s.final = runtime.ToValue(s.Final).ToObject(runtime)
+	s.setWritable(s.prototype(s.duplex))
+	s.setTransform(s.prototype(s.transform))
+	s.setPassThrough(s.prototype(s.passThrough))
+	_ = s.readable.Set("from", s.From)
+	_ = s.writable.DefineDataPropertySymbol(goja.SymHasInstance, runtime.ToValue(s.HasWritableInstance), goja.FLAG_FALSE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+
+	return s
+}
+
+// class of the constructor, which inherits the prototype and the static properties of the parent
+func (s *Stream) class(constructor func(goja.ConstructorCall) *goja.Object, parent *goja.Object) *goja.Object {
+	class := s.r.ToValue(constructor).ToObject(s.r)
+	_ = class.SetPrototype(parent)
+	_ = s.prototype(class).SetPrototype(s.prototype(parent))
+
+	return class
+}
+
+// prototype of the constructor
+func (s *Stream) prototype(constructor *goja.Object) *goja.Object {
+	return constructor.Get("prototype").ToObject(s.r)
+}
+
+// set the methods on the prototype
+func (s *Stream) set(prototype *goja.Object, methods []method) {
+	for _, m := range methods {
+		_ = prototype.Set(m.name, m.fn)
 	}
+}
 
-	if s.ended {
-		for _, cb := range s.onceEnd {
-			_, ok := s.onceEndHandled[cb]
-			if !ok {
-				call := goja.FunctionCall{
-					This:      cb.This,
-					Arguments: []goja.Value{s.error},
-				}
-				cb.Function(call)
-				s.onceEndHandled[cb] = true
-			}
-		}
+// getter of the property on the prototype
+func (s *Stream) getter(prototype *goja.Object, name string, fn func(this goja.Value) goja.Value) {
+	_ = prototype.DefineAccessorProperty(name, s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		return fn(call.This)
+	}), nil, goja.FLAG_TRUE, goja.FLAG_FALSE)
+}
+
+// Constructor of the legacy Stream class, which is the base class of every stream
+func (s *Stream) Constructor(_ goja.ConstructorCall) *goja.Object {
+	return nil
+}
+
+// super calls the method of the EventEmitter that the stream overrides
+func (s *Stream) super(call goja.FunctionCall, name string) goja.Value {
+	fn, ok := goja.AssertFunction(s.emitterPrototype.Get(name))
+	if !ok {
+		panic(s.r.NewTypeError("%s is not a function", name))
+	}
 
-		return
+	res, err := fn(call.This, call.Arguments...)
+	if err != nil {
+		panic(err)
 	}
+
+	return res
 }
 
-// On event (e.g. 'data') invoke a callback
-func (s *Stream) On(call goja.FunctionCall) goja.Value {
-	if call.Argument(0).String() == "data" {
-		s.onData = append(s.onData, &Callback{
-			This:     call.This,
-			Function: call.Argument(1).Export().(func(functionCall goja.FunctionCall) goja.Value),
-		})
-		s.Handle()
+// nextTick queues the function on the nextTick queue, which is how Node defers the events of a stream
+func (s *Stream) nextTick(fn func()) {
+	task.NextTick(s.r, fn)
+}
 
-		return goja.Null()
+// call the method of the object, panicking if the method throws
+func (s *Stream) call(this goja.Value, name string, args ...goja.Value) goja.Value {
+	fn, ok := goja.AssertFunction(this.ToObject(s.r).Get(name))
+	if !ok {
+		panic(s.r.NewTypeError("%s is not a function", name))
 	}
 
-	panic("stream.on called with unknown value")
+	res, err := fn(this, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	return res
 }
 
-// Once some event occurs (e.g. error, end) invoke a callback
-func (s *Stream) Once(call goja.FunctionCall) goja.Value {
-	arg := call.Argument(0).String()
-	if arg == "error" {
-		s.onceError = append(s.onceError, &Callback{
-			This:     call.This,
-			Function: call.Argument(1).Export().(func(functionCall goja.FunctionCall) goja.Value),
-		})
-		s.Handle()
+// try to call the function, returning the thrown value (or nil if nothing was thrown) instead of panicking
+func (s *Stream) try(fn func()) (thrown goja.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			thrown = s.thrown(r)
+		}
+	}()
+	fn()
 
-		return goja.Null()
-	} else if arg == "end" {
-		s.onceEnd = append(s.onceEnd, &Callback{
-			This:     call.This,
-			Function: call.Argument(1).Export().(func(functionCall goja.FunctionCall) goja.Value),
-		})
-		s.Handle()
+	return nil
+}
 
-		return goja.Null()
+// thrown value of the recovered panic, which is panicked again if it is not a JavaScript exception
+func (s *Stream) thrown(r any) goja.Value {
+	switch v := r.(type) {
+	case *goja.Exception:
+		return v.Value()
+	case *goja.Object:
+		return v
+	case goja.Value:
+		return v
+	default:
+		panic(r)
 	}
+}
 
-	panic("stream.once called with unknown value")
+// emit the event on the stream with the emit method of the stream, such that an overridden emit is used as well
+func (s *Stream) emit(this goja.Value, name string, args ...goja.Value) bool {
+	return s.call(this, "emit", append([]goja.Value{s.r.ToValue(name)}, args...)...).ToBoolean()
 }
 
-// Readable implementation
-type Readable struct {
-	r *goja.Runtime
+// listenerCount of the event of the stream
+func (s *Stream) listenerCount(this goja.Value, name string) int64 {
+	return s.call(this, "listenerCount", s.r.ToValue(name)).ToInteger()
+}
+
+// on adds the listener of the event of the stream
+func (s *Stream) on(this goja.Value, name string, fn func(goja.FunctionCall) goja.Value) goja.Value {
+	listener := s.r.ToValue(fn)
+	s.call(this, "on", s.r.ToValue(name), listener)
+
+	return listener
 }
 
-// Constructor of Readable
-func (r *Readable) Constructor(_ goja.ConstructorCall) *goja.Object {
-	return r.r.NewObject()
+// once adds the listener of the event of the stream, which is removed before it is called
+func (s *Stream) once(this goja.Value, name string, fn func(goja.FunctionCall) goja.Value) goja.Value {
+	listener := s.r.ToValue(fn)
+	s.call(this, "once", s.r.ToValue(name), listener)
+
+	return listener
 }
 
-// Equals is always true
-func (r *Readable) Equals(_ goja.FunctionCall) goja.Value {
-	return r.r.ToValue(true)
+// removeListener of the event of the stream
+func (s *Stream) removeListener(this goja.Value, name string, listener goja.Value) {
+	s.call(this, "removeListener", s.r.ToValue(name), listener)
 }
 
-// ToString of Readable
-func (r *Readable) ToString(_ goja.FunctionCall) goja.Value {
-	return r.r.ToValue("toString of Stream")
+// isChunk if the value is a string, Buffer or Uint8Array, i.e. a chunk of a stream that is not in object mode
+func (s *Stream) isChunk(value goja.Value) bool {
+	if _, ok := value.(goja.String); ok {
+		return true
+	}
+
+	return s.isBytes(value)
 }
 
-// readable always returns true
-func (r *Readable) readable(_ goja.FunctionCall) goja.Value {
-	return r.r.ToValue(true)
+// isBytes if the value is a Buffer or Uint8Array
+func (s *Stream) isBytes(value goja.Value) bool {
+	object, ok := value.(*goja.Object)
+
+	return ok && s.r.InstanceOf(object, s.uint8Array)
 }
 
-// Require Stream
-// @See https://nodejs.org/api/stream.html#class-streamreadable
-func Require(runtime *goja.Runtime, module *goja.Object) {
-	s := &Stream{r: runtime}
-	runtime.ToValue(s)
+// chunkLength of the chunk, i.e. the number of bytes of a Buffer or the number of code units of a string
+func (s *Stream) chunkLength(chunk goja.Value) int {
+	if str, ok := chunk.(goja.String); ok {
+		return str.Length()
+	}
+
+	return len(buffer.Bytes(s.r, chunk))
+}
 
-	r := &Readable{r: runtime}
+// isEncoding if the encoding is supported by the Buffer
+func isEncoding(encoding string) bool {
+	return buffer.StringCodecByName(encoding) != nil
+}
+
+// options of a stream, where undefined options are an empty object
+func (s *Stream) options(value goja.Value) *goja.Object {
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		return s.r.NewObject()
+	}
+
+	return value.ToObject(s.r)
+}
 
-	readable := runtime.NewObject()
+// option of the options, returning nil if the option is undefined or null
+func option(options *goja.Object, name string) goja.Value {
+	if v := options.Get(name); v != nil && !goja.IsUndefined(v) && !goja.IsNull(v) {
+		return v
+	}
 
-	proto := runtime.NewObject()
-	_ = proto.SetPrototype(runtime.NewObject())
-	_ = proto.DefineDataProperty("constructor", runtime.ToValue(r.Constructor), goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
-	_ = proto.Set("equals", r.Equals)
-	_ = proto.Set("toString", r.ToString)
+	return nil
+}
 
-	_ = readable.SetPrototype(proto)
-	_ = readable.Set("readable", r.readable)
-	_ = readable.Set("prototype", proto)
+// boolOption of the options, returning the fallback if the option is undefined or null
+func boolOption(options *goja.Object, name string, fallback bool) bool {
+	if v := option(options, name); v != nil {
+		return v.ToBoolean()
+	}
+
+	return fallback
+}
+
+// highWaterMark of the options, where the duplexKey (e.g. readableHighWaterMark) is used by a Duplex if the
+// highWaterMark is not set. A highWaterMark must be a non-negative integer.
+func (s *Stream) highWaterMark(options *goja.Object, duplexKey string, isDuplex bool, objectMode bool) int {
+	name := "options.highWaterMark"
+	hwm := option(options, "highWaterMark")
+	if hwm == nil && isDuplex {
+		name = "options." + duplexKey
+		hwm = option(options, duplexKey)
+	}
+
+	if hwm == nil {
+		if objectMode {
+			return defaultObjectModeHighWaterMark
+		}
+
+		return defaultHighWaterMark
+	}
+
+	n := hwm.ToFloat()
+	if args.TypeOf(hwm) != "number" || math.IsNaN(n) || math.IsInf(n, 0) || n < 0 || n != math.Floor(n) {
+		panic(nodeerrors.NewTypeError(s.r, nodeerrors.ErrCodeInvalidArgValue, "The property '%s' is invalid. Received %s", name, hwm))
+	}
+
+	return int(n)
+}
+
+// function of the options, which is set on the stream as the implementation of the method (e.g. _read)
+func (s *Stream) function(this *goja.Object, options *goja.Object, name string, method string) {
+	if fn := option(options, name); fn != nil {
+		if _, ok := goja.AssertFunction(fn); ok {
+			_ = this.Set(method, fn)
+		}
+	}
+}
+
+// newError of the Node error code
+func (s *Stream) newError(code string, format string, args ...any) *goja.Object {
+	return nodeerrors.NewError(s.r, nil, code, append([]any{format}, args...)...)
+}
+
+// Require the stream package, exporting the legacy Stream class with the other classes and functions as its
+// properties like Node does
+func Require(runtime *goja.Runtime, module *goja.Object) {
+	s := newStream(runtime)
+	exports := s.stream
+	_ = exports.Set("Stream", s.stream)
+	_ = exports.Set("Readable", s.readable)
+	_ = exports.Set("Writable", s.writable)
+	_ = exports.Set("Duplex", s.duplex)
+	_ = exports.Set("Transform", s.transform)
+	_ = exports.Set("PassThrough", s.passThrough)
+	_ = exports.Set("pipeline", s.Pipeline)
+	_ = exports.Set("finished", s.Finished)
+	_ = exports.Set("promises", s.promises())
+
+	_ = module.Set("exports", exports)
+}
 
-	exports := module.Get("exports").(*goja.Object)
-	_ = exports.Set("Readable", readable)
-	_ = exports.Set("PassThrough", s.PassThrough)
+// RequirePromises is the stream/promises package, i.e. the promise versions of pipeline and finished
+func RequirePromises(runtime *goja.Runtime, module *goja.Object) {
+	_ = module.Set("exports", require.Require(runtime, ModuleName).ToObject(runtime).Get("promises"))
 }
 
+// Enable the stream package, which relies on the events package to be enabled. The node:stream package exports the
+// same classes, such that a stream of either is an instance of both.
 func Enable(runtime *goja.Runtime, registry *require.Registry, _ *require.RequireModule) {
-	registry.RegisterNativeModule("node:"+ModuleName, Require)
 	registry.RegisterNativeModule(ModuleName, Require)
+	registry.RegisterNativeModule("node:"+ModuleName, func(runtime *goja.Runtime, module *goja.Object) {
+		_ = module.Set("exports", require.Require(runtime, ModuleName))
+	})
+	registry.RegisterNativeModule("node:"+ModuleName+"/promises", RequirePromises)
+	registry.RegisterNativeModule(ModuleName+"/promises", RequirePromises)
 	_ = runtime.Set("Stream", require.Require(runtime, ModuleName))
 }
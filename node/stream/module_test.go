@@ -0,0 +1,153 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/Emptyless/go-spectral/node/events"
+	"github.com/dop251/goja"
+	noderequire "github.com/dop251/goja_nodejs/require"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// run the script in a runtime with the events and stream package enabled. The events of a stream are emitted in
+// microtasks, which have run once the result is returned.
+func run(t *testing.T, script string) goja.Value {
+	t.Helper()
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	requireModule := registry.Enable(runtime)
+	events.Enable(runtime, registry, requireModule)
+	Enable(runtime, registry, requireModule)
+
+	res, err := runtime.RunString(script)
+	require.NoError(t, err)
+
+	return res
+}
+
+func TestStream_PassThrough_InstancesDoNotShareState(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var first = new Stream.PassThrough();
+var second = new Stream.PassThrough();
+var received = [];
+first.on('data', function(data) { received.push('first:' + data); });
+second.on('data', function(data) { received.push('second:' + data); });
+first.write('a');
+first.end();
+second.write('b');
+second.end();
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"first:a", "second:b"}, res.Export())
+}
+
+func TestStream_Classes(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var duplex = new stream.Duplex();
+var transform = new stream.Transform();
+[
+	stream === stream.Stream,
+	duplex instanceof stream.Readable,
+	duplex instanceof stream.Writable,
+	transform instanceof stream.Duplex,
+	new stream.PassThrough() instanceof stream.Transform,
+	new stream.Readable() instanceof stream.Writable,
+	new stream.Writable() instanceof require('events'),
+]`)
+
+	// Assert
+	assert.Equal(t, []any{true, true, true, true, true, false, true}, res.Export())
+}
+
+func TestStream_ClassExtends(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+class Upper extends stream.Transform {
+	_transform(chunk, encoding, callback) { callback(null, String(chunk).toUpperCase()); }
+}
+function Counter() { stream.Readable.call(this, { objectMode: true }); this.n = 0; }
+Object.setPrototypeOf(Counter.prototype, stream.Readable.prototype);
+Counter.prototype._read = function() { this.n++; this.push(this.n > 2 ? null : this.n); };
+
+var received = [];
+var upper = new Upper();
+upper.on('data', function(data) { received.push(String(data)); });
+upper.end('spectral');
+new Counter().on('data', function(data) { received.push(data); });
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"SPECTRAL", int64(1), int64(2)}, res.Export())
+}
+
+func TestRequire(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	events.Enable(runtime, registry, registry.Enable(runtime))
+	module := runtime.NewObject()
+
+	// Act
+	Require(runtime, module)
+
+	// Assert
+	exports := module.Get("exports").ToObject(runtime)
+	for _, name := range []string{"Stream", "Readable", "Writable", "Duplex", "Transform", "PassThrough", "pipeline", "finished", "promises"} {
+		assert.NotNil(t, exports.Get(name), name)
+	}
+}
+
+func TestRequirePromises(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var promises = require('stream/promises');
+[promises === require('stream').promises, promises === require('node:stream/promises'), typeof promises.pipeline, typeof promises.finished]`)
+
+	// Assert
+	assert.Equal(t, []any{true, true, "function", "function"}, res.Export())
+}
+
+func TestEnable(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	requireModule := registry.Enable(runtime)
+	events.Enable(runtime, registry, requireModule)
+
+	// Act
+	Enable(runtime, registry, requireModule)
+
+	// Assert
+	res, err := requireModule.Require(ModuleName)
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+
+	res, err = requireModule.Require("node:" + ModuleName)
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+	assert.NotNil(t, runtime.Get("Stream"))
+}
+
+func TestEnable_SharesClassesWithNodePrefix(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `var stream = require('node:stream');
+var readable = new stream.Readable();
+[stream === require('stream'), stream === Stream, readable instanceof require('stream').Readable,
+	readable instanceof require('node:events'), stream.promises === require('stream/promises')]`)
+
+	// Assert
+	assert.Equal(t, []any{true, true, true, true, true}, res.Export())
+}
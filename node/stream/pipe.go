@@ -0,0 +1,202 @@
+package stream
+
+import (
+	"slices"
+
+	"github.com/dop251/goja"
+)
+
+// Pipe the readable stream into the destination, i.e. write every chunk to the destination and pause while the
+// destination is full. The destination is ended once the stream ended, unless the end option is false or the
+// destination is process.stdout or process.stderr.
+func (s *Stream) Pipe(call goja.FunctionCall) goja.Value {
+	src, dest, options := call.This, call.Argument(0).ToObject(s.r), call.Argument(1)
+	state := s.readableState(src)
+	state.pipes = append(state.pipes, dest)
+
+	doEnd := !s.isStdio(dest)
+	if object, ok := options.(*goja.Object); ok && get(object, "end").StrictEquals(s.r.ToValue(false)) {
+		doEnd = false
+	}
+
+	var onend, unpipe, onunpipe, ondata, onerror, onclose, onfinish, ondrain goja.Value
+	cleanedUp := false
+	cleanup := func() {
+		s.removeListener(dest, "close", onclose)
+		s.removeListener(dest, "finish", onfinish)
+		if ondrain != nil {
+			s.removeListener(dest, "drain", ondrain)
+		}
+		s.removeListener(dest, "error", onerror)
+		s.removeListener(dest, "unpipe", onunpipe)
+		s.removeListener(src, "end", onend)
+		s.removeListener(src, "end", unpipe)
+		s.removeListener(src, "data", ondata)
+		cleanedUp = true
+
+		if w := s.writableStateOf(dest); ondrain != nil && len(state.awaitDrainWriters) > 0 && (w == nil || w.needDrain) {
+			s.invoke(ondrain)
+		}
+	}
+
+	onend = s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		s.call(dest, "end")
+
+		return goja.Undefined()
+	})
+	unpipe = s.r.ToValue(func(goja.FunctionCall) goja.Value {
+		s.call(src, "unpipe", dest)
+
+		return goja.Undefined()
+	})
+
+	endFn := unpipe
+	if doEnd {
+		endFn = onend
+	}
+	if state.endEmitted {
+		s.nextTick(func() { s.invoke(endFn) })
+	} else {
+		s.call(src, "once", s.r.ToValue("end"), endFn)
+	}
+
+	onunpipe = s.on(dest, "unpipe", func(c goja.FunctionCall) goja.Value {
+		if info, ok := c.Argument(1).(*goja.Object); ok && c.Argument(0).SameAs(src) && get(info, "hasUnpiped").StrictEquals(s.r.ToValue(false)) {
+			_ = info.Set("hasUnpiped", true)
+			cleanup()
+		}
+
+		return goja.Undefined()
+	})
+
+	pause := func() {
+		if !cleanedUp {
+			if slices.ContainsFunc(state.pipes, same(dest)) && !slices.ContainsFunc(state.awaitDrainWriters, same(dest)) {
+				state.awaitDrainWriters = append(state.awaitDrainWriters, dest)
+			}
+			s.call(src, "pause")
+		}
+
+		if ondrain == nil {
+			ondrain = s.on(dest, "drain", func(goja.FunctionCall) goja.Value {
+				state.awaitDrainWriters = slices.DeleteFunc(state.awaitDrainWriters, same(dest))
+				if len(state.awaitDrainWriters) == 0 && state.dataListening {
+					s.call(src, "resume")
+				}
+
+				return goja.Undefined()
+			})
+		}
+	}
+
+	ondata = s.on(src, "data", func(c goja.FunctionCall) goja.Value {
+		if ret := s.call(dest, "write", c.Argument(0)); ret.StrictEquals(s.r.ToValue(false)) {
+			pause()
+		}
+
+		return goja.Undefined()
+	})
+
+	onerror = s.r.ToValue(func(c goja.FunctionCall) goja.Value {
+		s.invoke(unpipe)
+		s.removeListener(dest, "error", onerror)
+		if s.listenerCount(dest, "error") == 0 {
+			if r, w := s.readableStateOf(dest), s.writableStateOf(dest); (w != nil && !w.errorEmitted) || (w == nil && r != nil && !r.errorEmitted) {
+				s.errorOrDestroy(dest, c.Argument(0), false)
+			} else {
+				s.emit(dest, "error", c.Argument(0))
+			}
+		}
+
+		return goja.Undefined()
+	})
+	s.call(dest, "prependListener", s.r.ToValue("error"), onerror)
+
+	onclose = s.once(dest, "close", func(goja.FunctionCall) goja.Value {
+		s.removeListener(dest, "finish", onfinish)
+		s.invoke(unpipe)
+
+		return goja.Undefined()
+	})
+	onfinish = s.once(dest, "finish", func(goja.FunctionCall) goja.Value {
+		s.removeListener(dest, "close", onclose)
+		s.invoke(unpipe)
+
+		return goja.Undefined()
+	})
+
+	s.emit(dest, "pipe", src)
+
+	if get(dest, "writableNeedDrain").StrictEquals(s.r.ToValue(true)) {
+		pause()
+	} else if !isTrue(state.flowing) {
+		s.call(src, "resume")
+	}
+
+	return dest
+}
+
+// isStdio if the destination is process.stdout or process.stderr, which are never ended by a pipe
+func (s *Stream) isStdio(dest *goja.Object) bool {
+	process, ok := s.r.Get("process").(*goja.Object)
+	if !ok {
+		return false
+	}
+
+	for _, name := range []string{"stdout", "stderr"} {
+		if stdio, ok := process.Get(name).(*goja.Object); ok && stdio.SameAs(dest) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unpipe the destination, or every destination if no destination is supplied, pausing the stream if it has no
+// destinations left
+func (s *Stream) Unpipe(call goja.FunctionCall) goja.Value {
+	state := s.readableState(call.This)
+	if len(state.pipes) == 0 {
+		return call.This
+	}
+
+	dest, ok := call.Argument(0).(*goja.Object)
+	if !ok || !call.Argument(0).ToBoolean() {
+		dests := state.pipes
+		state.pipes = nil
+		s.call(call.This, "pause")
+		for _, d := range dests {
+			s.emit(d, "unpipe", call.This, s.unpipeInfo())
+		}
+
+		return call.This
+	}
+
+	index := slices.IndexFunc(state.pipes, same(dest))
+	if index == -1 {
+		return call.This
+	}
+
+	state.pipes = slices.Delete(state.pipes, index, index+1)
+	if len(state.pipes) == 0 {
+		s.call(call.This, "pause")
+	}
+	s.emit(dest, "unpipe", call.This, s.unpipeInfo())
+
+	return call.This
+}
+
+// unpipeInfo is the second argument of the 'unpipe' event, which a pipe sets once it cleaned up
+func (s *Stream) unpipeInfo() *goja.Object {
+	info := s.r.NewObject()
+	_ = info.Set("hasUnpiped", false)
+
+	return info
+}
+
+// same returns a predicate whether an object is the object
+func same(object *goja.Object) func(*goja.Object) bool {
+	return func(o *goja.Object) bool {
+		return o.SameAs(object)
+	}
+}
@@ -0,0 +1,90 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipe(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var readable = new stream.Readable({ read() {} });
+var writable = new stream.Writable({ write(chunk, encoding, callback) { received.push(String(chunk)); callback(); } });
+writable.on('pipe', function(src) { received.push('pipe:' + (src === readable)); });
+writable.on('finish', function() { received.push('finish'); });
+received.push(readable.pipe(writable) === writable);
+readable.push('a');
+readable.push('b');
+readable.push(null);
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"pipe:true", true, "a", "b", "finish"}, res.Export())
+}
+
+func TestPipe_Backpressure(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var readable = new stream.Readable({ read() {} });
+var callbacks = [];
+var writable = new stream.Writable({
+	highWaterMark: 2,
+	write(chunk, encoding, callback) { received.push('write:' + chunk); callbacks.push(callback); },
+});
+writable.on('drain', function() { received.push('drain'); });
+readable.pipe(writable);
+readable.push('ab');
+readable.push('cd');
+Promise.resolve().then(function() {}).then(function() {
+	received.push('paused:' + readable.isPaused() + ':' + readable.readableLength);
+	callbacks.shift()();
+});
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"write:ab", "paused:true:2", "drain", "write:cd"}, res.Export())
+}
+
+func TestPipe_EndFalse(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var readable = new stream.Readable({ read() { this.push(null); } });
+var writable = new stream.PassThrough();
+readable.pipe(writable, { end: false });
+writable`)
+
+	// Assert
+	assert.False(t, res.ToObject(nil).Get("writableEnded").ToBoolean())
+}
+
+func TestUnpipe(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var readable = new stream.PassThrough();
+var first = new stream.PassThrough();
+var second = new stream.PassThrough();
+first.on('unpipe', function(src) { received.push('unpipe first:' + (src === readable)); });
+second.on('unpipe', function() { received.push('unpipe second'); });
+readable.pipe(first);
+readable.pipe(second);
+readable.unpipe(first);
+received.push(readable.readableFlowing);
+readable.unpipe();
+received.push(readable.readableFlowing, readable.listenerCount('data'));
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"unpipe first:true", true, "unpipe second", false, int64(0)}, res.Export())
+}
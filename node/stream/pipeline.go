@@ -0,0 +1,303 @@
+package stream
+
+import (
+	"fmt"
+
+	"github.com/Emptyless/go-spectral/node/internal/args"
+	"github.com/dop251/goja"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
+)
+
+// Pipeline pipes the streams into each other, i.e. pipeline(source, ...transforms, destination, callback), and
+// destroys every stream once one of them errors. The callback is called with the error (if any) once the last stream
+// finished. The source may be an iterable, which is read with Readable.from.
+func (s *Stream) Pipeline(call goja.FunctionCall) goja.Value {
+	streams := call.Arguments
+	if len(streams) == 0 {
+		panic(args.InvalidArgType(s.r, "streams[stream.length - 1]", "of type function", goja.Undefined()))
+	}
+
+	callback, ok := goja.AssertFunction(streams[len(streams)-1])
+	if !ok {
+		panic(args.InvalidArgType(s.r, "streams[stream.length - 1]", "of type function", streams[len(streams)-1]))
+	}
+
+	return s.pipeline(s.streams(streams[:len(streams)-1]), true, func(err goja.Value) {
+		if _, err := callback(goja.Undefined(), err); err != nil {
+			panic(err)
+		}
+	})
+}
+
+// streams of the arguments of a pipeline, which may be a single array of streams
+func (s *Stream) streams(args []goja.Value) []goja.Value {
+	if len(args) == 1 {
+		if array, ok := args[0].(*goja.Object); ok && array.ClassName() == "Array" {
+			var streams []goja.Value
+			_ = s.r.ExportTo(array, &streams)
+			args = streams
+		}
+	}
+
+	if len(args) < 2 { //nolint:mnd // a source and a destination
+		panic(nodeerrors.NewTypeError(s.r, nodeerrors.ErrCodeMissingArgs, "The \"streams\" argument must be specified"))
+	}
+
+	return args
+}
+
+// pipeline of the streams, calling the callback on the next tick once every stream finished or one of them errored.
+// Unless end is false, the destination is ended once the source ended.
+func (s *Stream) pipeline(streams []goja.Value, end bool, callback func(err goja.Value)) goja.Value {
+	var (
+		err               goja.Value
+		finishCount       int
+		destroys          []func(err goja.Value)
+		lastStreamCleanup []func()
+	)
+
+	finishImpl := func(e goja.Value, final bool) {
+		if truthy(e) && (err == nil || s.code(err) == "ERR_STREAM_PREMATURE_CLOSE") {
+			err = e
+		}
+
+		if err == nil && !final {
+			return
+		}
+
+		for len(destroys) > 0 {
+			destroy := destroys[0]
+			destroys = destroys[1:]
+			destroy(err)
+		}
+
+		if final {
+			if err == nil {
+				for _, cleanup := range lastStreamCleanup {
+					cleanup()
+				}
+			}
+
+			result := err
+			if result == nil {
+				result = goja.Undefined()
+			}
+			s.nextTick(func() { callback(result) })
+		}
+	}
+	finish := func(e goja.Value) {
+		finishCount--
+		finishImpl(e, finishCount == 0)
+	}
+
+	var ret *goja.Object
+	for i, value := range streams {
+		reading, writing, isLastStream := i < len(streams)-1, i > 0, i == len(streams)-1
+		end := reading || end
+
+		stream, ok := value.(*goja.Object)
+		if !ok || !s.isNodeStream(stream) {
+			if i > 0 {
+				panic(args.InvalidArgType(s.r, fmt.Sprintf("streams[%d]", i), "an instance of Stream", value))
+			}
+
+			stream = s.from(value, goja.Undefined())
+		}
+
+		readable, _ := s.isReadable(stream)
+		if end {
+			destroy, cleanup := s.pipelineDestroyer(stream, reading, writing)
+			destroys = append(destroys, destroy)
+			if readable && isLastStream {
+				lastStreamCleanup = append(lastStreamCleanup, cleanup)
+			}
+		}
+
+		onError := s.on(stream, "error", func(call goja.FunctionCall) goja.Value {
+			if e := call.Argument(0); truthy(e) && s.name(e) != "AbortError" && s.code(e) != "ERR_STREAM_PREMATURE_CLOSE" {
+				finish(e)
+			}
+
+			return goja.Undefined()
+		})
+		if readable && isLastStream {
+			lastStreamCleanup = append(lastStreamCleanup, func() { s.removeListener(stream, "error", onError) })
+		}
+
+		if i > 0 {
+			finishCount += 2
+			cleanup := s.pipe(ret, stream, finish, end)
+			if readable && isLastStream {
+				lastStreamCleanup = append(lastStreamCleanup, cleanup)
+			}
+		}
+		ret = stream
+	}
+
+	return ret
+}
+
+// pipelineDestroyer of the stream, which destroys the stream with the error of the pipeline unless it finished. The
+// cleanup removes the listeners that track whether the stream finished.
+func (s *Stream) pipelineDestroyer(stream *goja.Object, reading bool, writing bool) (destroy func(err goja.Value), cleanup func()) {
+	finished := false
+	cleanup = s.eos(stream, s.sides(reading, writing), func(_ goja.Value, err goja.Value) {
+		finished = !truthy(err)
+	})
+
+	return func(err goja.Value) {
+		if finished {
+			return
+		}
+		finished = true
+
+		if !truthy(err) {
+			err = s.newError("ERR_STREAM_DESTROYED", "Cannot call pipe after a stream was destroyed")
+		}
+		s.destroyer(stream, err)
+	}, cleanup
+}
+
+// pipe the source into the destination of a pipeline, which ends the destination itself such that it can tell a
+// premature close of the destination from its end. The finish is called twice, i.e. once the source ended (or right
+// away if the destination is not ended) and once the destination finished. The returned cleanup removes the listeners
+// of the destination.
+func (s *Stream) pipe(src *goja.Object, dst *goja.Object, finish func(err goja.Value), end bool) func() {
+	ended := false
+	s.on(dst, "close", func(goja.FunctionCall) goja.Value {
+		if !ended {
+			finish(s.newError("ERR_STREAM_PREMATURE_CLOSE", "Premature close"))
+		}
+
+		return goja.Undefined()
+	})
+
+	options := s.r.NewObject()
+	_ = options.Set("end", false)
+	s.call(src, "pipe", dst, options)
+
+	if end {
+		endFn := s.r.ToValue(func(goja.FunctionCall) goja.Value {
+			ended = true
+			s.call(dst, "end")
+
+			return goja.Undefined()
+		})
+
+		if s.isReadableFinished(src, false) {
+			s.nextTick(func() { s.invoke(endFn) })
+		} else {
+			s.call(src, "once", s.r.ToValue("end"), endFn)
+		}
+	} else {
+		finish(goja.Undefined())
+	}
+
+	s.eos(src, s.sides(true, false), func(_ goja.Value, err goja.Value) {
+		r := s.readableStateOf(src)
+		if truthy(err) && s.code(err) == "ERR_STREAM_PREMATURE_CLOSE" && r != nil && r.ended && r.errored == nil && !r.errorEmitted {
+			onFinish := func(call goja.FunctionCall) goja.Value {
+				finish(call.Argument(0))
+
+				return goja.Undefined()
+			}
+			s.once(src, "end", onFinish)
+			s.once(src, "error", onFinish)
+
+			return
+		}
+
+		finish(err)
+	})
+
+	return s.eos(dst, s.sides(false, true), func(_ goja.Value, err goja.Value) {
+		finish(err)
+	})
+}
+
+// sides are the options of eos to wait for the readable and/or writable side of a stream
+func (s *Stream) sides(readable bool, writable bool) *goja.Object {
+	options := s.r.NewObject()
+	_ = options.Set("readable", readable)
+	_ = options.Set("writable", writable)
+
+	return options
+}
+
+// code of the error, which is empty if the error has no code
+func (s *Stream) code(err goja.Value) string {
+	if object, ok := err.(*goja.Object); ok {
+		if code := object.Get("code"); code != nil {
+			return code.String()
+		}
+	}
+
+	return ""
+}
+
+// name of the error, which is empty if the error has no name
+func (s *Stream) name(err goja.Value) string {
+	if object, ok := err.(*goja.Object); ok {
+		if name := object.Get("name"); name != nil {
+			return name.String()
+		}
+	}
+
+	return ""
+}
+
+// promises of the stream package, i.e. pipeline and finished returning a promise instead of calling a callback
+func (s *Stream) promises() *goja.Object {
+	promises := s.r.NewObject()
+	_ = promises.Set("pipeline", s.PipelinePromise)
+	_ = promises.Set("finished", s.FinishedPromise)
+
+	return promises
+}
+
+// PipelinePromise is pipeline(source, ...transforms, destination[, options]), returning a promise that is resolved
+// once the destination finished or rejected with the error of the pipeline. The end option may keep the destination
+// open.
+func (s *Stream) PipelinePromise(call goja.FunctionCall) goja.Value {
+	promise, resolve, reject := s.r.NewPromise()
+	args, end := call.Arguments, true
+	if n := len(args); n > 0 {
+		if options, ok := args[n-1].(*goja.Object); ok && options.ClassName() != "Array" && !s.isNodeStream(options) && !s.isIterable(options) {
+			end = boolOption(options, "end", true)
+			args = args[:n-1]
+		}
+	}
+
+	if thrown := s.try(func() {
+		s.pipeline(s.streams(args), end, func(err goja.Value) {
+			if truthy(err) {
+				_ = reject(err)
+			} else {
+				_ = resolve(goja.Undefined())
+			}
+		})
+	}); thrown != nil {
+		_ = reject(thrown)
+	}
+
+	return s.r.ToValue(promise)
+}
+
+// FinishedPromise is finished(stream[, options]), returning a promise that is resolved once the stream finished or
+// rejected with the error of the stream
+func (s *Stream) FinishedPromise(call goja.FunctionCall) goja.Value {
+	promise, resolve, reject := s.r.NewPromise()
+	if thrown := s.try(func() {
+		s.eos(s.nodeStream(call.Argument(0), "stream"), s.options(call.Argument(1)), func(_ goja.Value, err goja.Value) {
+			if truthy(err) {
+				_ = reject(err)
+			} else {
+				_ = resolve(goja.Undefined())
+			}
+		})
+	}); thrown != nil {
+		_ = reject(thrown)
+	}
+
+	return s.r.ToValue(promise)
+}
@@ -0,0 +1,95 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipeline(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"streams": {
+			script: `
+var transform = new stream.Transform({ transform(chunk, encoding, callback) { callback(null, String(chunk).toUpperCase()); } });
+var writable = new stream.Writable({ write(chunk, encoding, callback) { received.push(String(chunk)); callback(); } });
+var res = stream.pipeline(stream.Readable.from(['a', 'b']), transform, writable, function(err) { received.push('done:' + err); });
+received.push(res === writable);`,
+			expected: []any{true, "A", "B", "done:undefined"},
+		},
+		"array of streams": {
+			script: `
+var writable = new stream.Writable({ objectMode: true, write(chunk, encoding, callback) { received.push(chunk); callback(); } });
+stream.pipeline([stream.Readable.from([1]), writable], function(err) { received.push('done:' + err); });`,
+			expected: []any{int64(1), "done:undefined"},
+		},
+		"iterable source": {
+			script: `
+var writable = new stream.Writable({ objectMode: true, write(chunk, encoding, callback) { received.push(chunk); callback(); } });
+stream.pipeline(['a', 'b'], writable, function(err) { received.push('done:' + err); });`,
+			expected: []any{"a", "b", "done:undefined"},
+		},
+		"error destroys every stream": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+var passThrough = new stream.PassThrough();
+var writable = new stream.Writable({ write(chunk, encoding, callback) { callback(new Error('failed')); } });
+stream.pipeline(readable, passThrough, writable, function(err) {
+	received.push(err.message, readable.destroyed, passThrough.destroyed, writable.destroyed);
+});
+readable.push('a');`,
+			expected: []any{"failed", true, true, true},
+		},
+		"premature close": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+stream.pipeline(readable, new stream.PassThrough(), function(err) { received.push(err.code); });
+readable.destroy();`,
+			expected: []any{"ERR_STREAM_PREMATURE_CLOSE"},
+		},
+		"missing streams": {
+			script: `
+try { stream.pipeline(new stream.PassThrough(), function() {}); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_MISSING_ARGS: The \"streams\" argument must be specified"},
+		},
+		"missing callback": {
+			script: `
+try { stream.pipeline(new stream.PassThrough(), new stream.PassThrough()); } catch (err) { received.push(err.code); }`,
+			expected: []any{"ERR_INVALID_ARG_TYPE"},
+		},
+		"promise": {
+			script: `
+var writable = new stream.Writable({ write(chunk, encoding, callback) { received.push(String(chunk)); callback(); } });
+require('stream/promises').pipeline(stream.Readable.from('ab'), writable).then(function() { received.push('resolved'); });`,
+			expected: []any{"ab", "resolved"},
+		},
+		"promise rejected": {
+			script: `
+var readable = new stream.Readable({ read() { this.destroy(new Error('failed')); } });
+stream.promises.pipeline(readable, new stream.PassThrough()).catch(function(err) { received.push(err.message); });`,
+			expected: []any{"failed"},
+		},
+		"promise end false": {
+			script: `
+var writable = new stream.PassThrough();
+stream.promises.pipeline(stream.Readable.from('ab'), writable, { end: false }).then(function() {
+	received.push('resolved:' + writable.writableEnded);
+});`,
+			expected: []any{"resolved:false"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
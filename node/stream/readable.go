@@ -0,0 +1,808 @@
+package stream
+
+import (
+	"math"
+
+	"github.com/Emptyless/go-spectral/node/internal/args"
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
+)
+
+// maxHighWaterMark a read(n) may raise the highWaterMark to
+const maxHighWaterMark = 0x40000000
+
+// common state of a readable and a writable stream
+type common struct {
+	owner         *goja.Object
+	objectMode    bool
+	highWaterMark int
+	length        int
+	sync          bool
+	autoDestroy   bool
+	emitClose     bool
+	destroyed     bool
+	errored       goja.Value
+	errorEmitted  bool
+	closed        bool
+	closeEmitted  bool
+}
+
+// newCommon state of the stream with the shared options
+func newCommon(owner *goja.Object, options *goja.Object) common {
+	return common{
+		owner:       owner,
+		sync:        true,
+		autoDestroy: boolOption(options, "autoDestroy", true),
+		emitClose:   boolOption(options, "emitClose", true),
+	}
+}
+
+// readableState of a readable stream, i.e. the buffered chunks and whether the stream is flowing. A flowing stream
+// emits its chunks as 'data' events, a paused stream is read with read().
+type readableState struct {
+	common
+	buffer            []goja.Value
+	pipes             []*goja.Object
+	awaitDrainWriters []*goja.Object
+	flowing           *bool
+	paused            *bool
+	ended             bool
+	endEmitted        bool
+	reading           bool
+	needReadable      bool
+	emittedReadable   bool
+	readableListening bool
+	dataListening     bool
+	resumeScheduled   bool
+	readingMore       bool
+	dataEmitted       bool
+	disabled          bool
+	defaultEncoding   string
+	encoding          string
+	decoder           *decoder
+}
+
+// readableStateOf the stream, which is nil if the stream is not readable
+func (s *Stream) readableStateOf(this goja.Value) *readableState {
+	object, ok := this.(*goja.Object)
+	if !ok {
+		return nil
+	}
+
+	if v := object.GetSymbol(s.readableSymbol); v != nil {
+		if state, ok := v.Export().(*readableState); ok && state.owner.SameAs(object) {
+			return state
+		}
+	}
+
+	return nil
+}
+
+// readableState of the stream, which is initialized with the default options if the constructor was not called
+func (s *Stream) readableState(this goja.Value) *readableState {
+	if state := s.readableStateOf(this); state != nil {
+		return state
+	}
+
+	return s.initReadable(this.ToObject(s.r), s.r.NewObject(), false)
+}
+
+// initReadable state of the stream with the options, where a Duplex may set the options of its readable side
+func (s *Stream) initReadable(this *goja.Object, options *goja.Object, isDuplex bool) *readableState {
+	state := &readableState{common: newCommon(this, options), defaultEncoding: "utf8"}
+	state.objectMode = boolOption(options, "objectMode", false) || (isDuplex && boolOption(options, "readableObjectMode", false))
+	state.highWaterMark = s.highWaterMark(options, "readableHighWaterMark", isDuplex, state.objectMode)
+	if encoding := option(options, "defaultEncoding"); encoding != nil {
+		state.defaultEncoding = s.encoding(encoding)
+	}
+
+	if encoding := option(options, "encoding"); encoding != nil && encoding.ToBoolean() {
+		state.decoder = s.decoder(encoding)
+		state.encoding = state.decoder.encoding
+	}
+
+	_ = this.DefineDataPropertySymbol(s.readableSymbol, s.r.ToValue(state), goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+
+	return state
+}
+
+// encoding of the value, panicking with an ERR_UNKNOWN_ENCODING TypeError if the encoding is not supported
+func (s *Stream) encoding(value goja.Value) string {
+	return s.decoder(value).encoding
+}
+
+// decoder of the encoding, panicking with an ERR_UNKNOWN_ENCODING TypeError if the encoding is not supported
+func (s *Stream) decoder(value goja.Value) *decoder {
+	d := newDecoder(value.String())
+	if d == nil {
+		panic(nodeerrors.NewTypeError(s.r, "ERR_UNKNOWN_ENCODING", "Unknown encoding: %s", value))
+	}
+
+	return d
+}
+
+// setReadable sets the methods of a readable stream on the prototype
+func (s *Stream) setReadable(prototype *goja.Object) {
+	s.set(prototype, []method{
+		{name: "push", fn: s.Push},
+		{name: "unshift", fn: s.Unshift},
+		{name: "read", fn: s.Read},
+		{name: "_read", fn: s.NotImplemented("_read()")},
+		{name: "setEncoding", fn: s.SetEncoding},
+		{name: "pause", fn: s.Pause},
+		{name: "resume", fn: s.Resume},
+		{name: "isPaused", fn: s.IsPaused},
+		{name: "pipe", fn: s.Pipe},
+		{name: "unpipe", fn: s.Unpipe},
+		{name: "on", fn: s.ReadableOn},
+		{name: "addListener", fn: s.ReadableOn},
+		{name: "removeListener", fn: s.ReadableRemoveListener},
+		{name: "off", fn: s.ReadableRemoveListener},
+		{name: "removeAllListeners", fn: s.ReadableRemoveAllListeners},
+		{name: "destroy", fn: s.Destroy},
+		{name: "_destroy", fn: s.DefaultDestroy},
+	})
+	_ = prototype.SetSymbol(s.asyncIteratorSymbol(), s.AsyncIterator)
+
+	s.getter(prototype, "readable", func(this goja.Value) goja.Value {
+		state := s.readableState(this)
+
+		return s.r.ToValue(!state.disabled && !state.destroyed && !state.errorEmitted && !state.endEmitted)
+	})
+	s.getter(prototype, "readableEnded", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.readableState(this).endEmitted)
+	})
+	s.getter(prototype, "readableFlowing", func(this goja.Value) goja.Value {
+		if flowing := s.readableState(this).flowing; flowing != nil {
+			return s.r.ToValue(*flowing)
+		}
+
+		return goja.Null()
+	})
+	s.getter(prototype, "readableLength", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.readableState(this).length)
+	})
+	s.getter(prototype, "readableHighWaterMark", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.readableState(this).highWaterMark)
+	})
+	s.getter(prototype, "readableObjectMode", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.readableState(this).objectMode)
+	})
+	s.getter(prototype, "readableEncoding", func(this goja.Value) goja.Value {
+		if encoding := s.readableState(this).encoding; encoding != "" {
+			return s.r.ToValue(encoding)
+		}
+
+		return goja.Null()
+	})
+	s.getter(prototype, "destroyed", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.readableState(this).destroyed)
+	})
+	s.getter(prototype, "closed", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.readableState(this).closed)
+	})
+	s.getter(prototype, "errored", func(this goja.Value) goja.Value {
+		if errored := s.readableState(this).errored; errored != nil {
+			return errored
+		}
+
+		return goja.Null()
+	})
+}
+
+// NotImplemented is the default implementation of a method that must be implemented by a stream, e.g. _read
+func (s *Stream) NotImplemented(name string) func(goja.FunctionCall) goja.Value {
+	return func(goja.FunctionCall) goja.Value {
+		panic(s.newError("ERR_METHOD_NOT_IMPLEMENTED", "The %s method is not implemented", name))
+	}
+}
+
+// Readable is the constructor of a readable stream, i.e. new Readable({ read() {} })
+func (s *Stream) Readable(call goja.ConstructorCall) *goja.Object {
+	options := s.options(call.Argument(0))
+	s.initReadable(call.This, options, false)
+	s.function(call.This, options, "read", "_read")
+	s.function(call.This, options, "destroy", "_destroy")
+
+	return nil
+}
+
+// Push the chunk onto the stream, where a null chunk ends the stream. Returns whether more chunks can be pushed before
+// the highWaterMark is reached.
+func (s *Stream) Push(call goja.FunctionCall) goja.Value {
+	return s.r.ToValue(s.addChunk(call.This, call.Argument(0), call.Argument(1), false))
+}
+
+// Unshift the chunk back onto the front of the stream
+func (s *Stream) Unshift(call goja.FunctionCall) goja.Value {
+	return s.r.ToValue(s.addChunk(call.This, call.Argument(0), call.Argument(1), true))
+}
+
+// addChunk to the stream, converting a string chunk into a Buffer unless it is decoded already
+func (s *Stream) addChunk(this goja.Value, chunk goja.Value, encoding goja.Value, addToFront bool) bool {
+	state := s.readableState(this)
+	if goja.IsNull(chunk) {
+		state.reading = false
+		s.onEOFChunk(this, state)
+
+		return false
+	}
+
+	decoded := false
+	if !state.objectMode {
+		if str, ok := chunk.(goja.String); ok {
+			enc := state.defaultEncoding
+			if encoding != nil && encoding.ToBoolean() {
+				enc = encoding.String()
+			}
+
+			switch {
+			case state.encoding == enc:
+				decoded = true
+			case addToFront && state.encoding != "":
+				chunk, decoded = s.r.ToValue(buffer.EncodeBytes(s.r, buffer.DecodeBytes(s.r, str, s.r.ToValue(enc)), s.r.ToValue(state.encoding))), true
+			default:
+				chunk = buffer.WrapBytes(s.r, buffer.DecodeBytes(s.r, str, s.r.ToValue(enc)))
+			}
+		} else if s.isBytes(chunk) {
+			chunk = s.toBuffer(chunk)
+		} else if !goja.IsUndefined(chunk) {
+			s.errorOrDestroy(this, args.InvalidArgType(s.r, "chunk", "of type string or an instance of Buffer, TypedArray, or DataView", chunk), false)
+
+			return false
+		}
+
+		if goja.IsUndefined(chunk) || s.chunkLength(chunk) == 0 {
+			if !addToFront {
+				state.reading = false
+				s.maybeReadMore(this, state)
+			}
+
+			return s.canPushMore(state)
+		}
+	}
+
+	if addToFront {
+		switch {
+		case state.endEmitted:
+			s.errorOrDestroy(this, s.newError("ERR_STREAM_UNSHIFT_AFTER_END_EVENT", "stream.unshift() after end event"), false)
+		case state.destroyed || state.errored != nil:
+			return false
+		default:
+			s.pushChunk(this, state, chunk, true)
+		}
+
+		return s.canPushMore(state)
+	}
+
+	if state.ended {
+		s.errorOrDestroy(this, s.newError("ERR_STREAM_PUSH_AFTER_EOF", "stream.push() after EOF"), false)
+
+		return false
+	}
+
+	if state.destroyed || state.errored != nil {
+		return false
+	}
+
+	state.reading = false
+	if state.decoder != nil && !decoded && s.isBytes(chunk) {
+		chunk = s.r.ToValue(state.decoder.write(buffer.Bytes(s.r, chunk)))
+		if !state.objectMode && s.chunkLength(chunk) == 0 {
+			s.maybeReadMore(this, state)
+
+			return s.canPushMore(state)
+		}
+	}
+
+	s.pushChunk(this, state, chunk, false)
+
+	return s.canPushMore(state)
+}
+
+// canPushMore chunks onto the stream before the highWaterMark is reached
+func (s *Stream) canPushMore(state *readableState) bool {
+	return !state.ended && (state.length < state.highWaterMark || state.length == 0)
+}
+
+// toBuffer of the Uint8Array, which shares its memory with the Uint8Array
+func (s *Stream) toBuffer(chunk goja.Value) goja.Value {
+	return buffer.WrapBytes(s.r, buffer.Bytes(s.r, chunk))
+}
+
+// pushChunk emits the chunk as a 'data' event if the stream is flowing and empty, or else buffers the chunk
+func (s *Stream) pushChunk(this goja.Value, state *readableState, chunk goja.Value, addToFront bool) {
+	if isTrue(state.flowing) && !state.sync && state.dataListening && state.length == 0 {
+		state.awaitDrainWriters = nil
+		state.dataEmitted = true
+		s.emit(this, "data", chunk)
+	} else {
+		state.length += s.size(state.objectMode, chunk)
+		if addToFront {
+			state.buffer = append([]goja.Value{chunk}, state.buffer...)
+		} else {
+			state.buffer = append(state.buffer, chunk)
+		}
+
+		if state.needReadable {
+			s.emitReadable(this, state)
+		}
+	}
+
+	s.maybeReadMore(this, state)
+}
+
+// size of the chunk in the length of the stream, which is one in object mode
+func (s *Stream) size(objectMode bool, chunk goja.Value) int {
+	if objectMode {
+		return 1
+	}
+
+	return s.chunkLength(chunk)
+}
+
+// isTrue if the optional bool is set and true
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+// isFalse if the optional bool is set and false
+func isFalse(b *bool) bool {
+	return b != nil && !*b
+}
+
+// ptr to the bool
+func ptr(b bool) *bool {
+	return &b
+}
+
+// onEOFChunk ends the stream, flushing the decoder
+func (s *Stream) onEOFChunk(this goja.Value, state *readableState) {
+	if state.ended {
+		return
+	}
+
+	if state.decoder != nil {
+		if chunk := state.decoder.end(); chunk != "" {
+			state.buffer = append(state.buffer, s.r.ToValue(chunk))
+			state.length += s.size(state.objectMode, s.r.ToValue(chunk))
+		}
+	}
+	state.ended = true
+
+	if state.sync {
+		s.emitReadable(this, state)
+	} else {
+		state.needReadable = false
+		state.emittedReadable = true
+		s.emitReadableNT(this, state)
+	}
+}
+
+// emitReadable emits the 'readable' event on the next tick
+func (s *Stream) emitReadable(this goja.Value, state *readableState) {
+	state.needReadable = false
+	if !state.emittedReadable {
+		state.emittedReadable = true
+		s.nextTick(func() {
+			s.emitReadableNT(this, state)
+		})
+	}
+}
+
+// emitReadableNT emits the 'readable' event and flows the stream
+func (s *Stream) emitReadableNT(this goja.Value, state *readableState) {
+	if !state.destroyed && state.errored == nil && (state.length > 0 || state.ended) {
+		s.emit(this, "readable")
+		state.emittedReadable = false
+	}
+
+	state.needReadable = !isTrue(state.flowing) && !state.ended && state.length <= state.highWaterMark
+	s.flow(this, state)
+}
+
+// flow reads the stream while it is flowing, i.e. emits its chunks as 'data' events
+func (s *Stream) flow(this goja.Value, state *readableState) {
+	for isTrue(state.flowing) && !goja.IsNull(s.call(this, "read")) { //nolint:revive // read emits the chunks
+	}
+}
+
+// maybeReadMore reads the stream on the next tick until the highWaterMark is reached
+func (s *Stream) maybeReadMore(this goja.Value, state *readableState) {
+	if state.readingMore {
+		return
+	}
+
+	state.readingMore = true
+	s.nextTick(func() {
+		for !state.reading && !state.ended && (state.length < state.highWaterMark || (isTrue(state.flowing) && state.length == 0)) {
+			length := state.length
+			s.call(this, "read", s.r.ToValue(0))
+			if length == state.length {
+				break
+			}
+		}
+		state.readingMore = false
+	})
+}
+
+// Read up to n bytes (or one object in object mode) from the buffer, or all of the buffer if n is omitted. Returns
+// null if there is not enough data, in which case _read is called to fill the buffer.
+func (s *Stream) Read(call goja.FunctionCall) goja.Value {
+	return s.read(call.This, call.Argument(0))
+}
+
+// read the stream
+func (s *Stream) read(this goja.Value, arg goja.Value) goja.Value {
+	state := s.readableState(this)
+	n := math.NaN()
+	if !goja.IsUndefined(arg) {
+		n = math.Trunc(arg.ToFloat())
+	}
+	nOrig := n
+
+	if n > float64(state.highWaterMark) {
+		state.highWaterMark = s.computeNewHighWaterMark(n)
+	}
+
+	if n != 0 {
+		state.emittedReadable = false
+	}
+
+	if n == 0 && state.needReadable && ((state.highWaterMark != 0 && state.length >= state.highWaterMark) || (state.highWaterMark == 0 && state.length > 0) || state.ended) {
+		if state.length == 0 && state.ended {
+			s.endReadable(this, state)
+		} else {
+			s.emitReadable(this, state)
+		}
+
+		return goja.Null()
+	}
+
+	n = s.howMuchToRead(n, state)
+	if n == 0 && state.ended {
+		if state.length == 0 {
+			s.endReadable(this, state)
+		}
+
+		return goja.Null()
+	}
+
+	doRead := state.needReadable
+	if state.length == 0 || float64(state.length)-n < float64(state.highWaterMark) {
+		doRead = true
+	}
+
+	if state.ended || state.reading || state.destroyed || state.errored != nil {
+		doRead = false
+	} else if doRead {
+		state.reading = true
+		state.sync = true
+		if state.length == 0 {
+			state.needReadable = true
+		}
+
+		if thrown := s.try(func() { s.call(this, "_read", s.r.ToValue(state.highWaterMark)) }); thrown != nil {
+			s.errorOrDestroy(this, thrown, false)
+		}
+		state.sync = false
+
+		if !state.reading {
+			n = s.howMuchToRead(nOrig, state)
+		}
+	}
+
+	var ret goja.Value = goja.Null()
+	if n > 0 {
+		ret = s.fromList(int(n), state)
+	}
+
+	if goja.IsNull(ret) {
+		state.needReadable = state.needReadable || state.length <= state.highWaterMark
+		n = 0
+	} else {
+		state.length -= int(n)
+		state.awaitDrainWriters = nil
+	}
+
+	if state.length == 0 {
+		if !state.ended {
+			state.needReadable = true
+		}
+
+		if nOrig != n && state.ended {
+			s.endReadable(this, state)
+		}
+	}
+
+	if !goja.IsNull(ret) && !state.errorEmitted && !state.closeEmitted {
+		state.dataEmitted = true
+		s.emit(this, "data", ret)
+	}
+
+	return ret
+}
+
+// computeNewHighWaterMark of a read(n) exceeding the highWaterMark, i.e. the next power of two of n
+func (s *Stream) computeNewHighWaterMark(n float64) int {
+	if n > maxHighWaterMark {
+		panic(nodeerrors.NewError(s.r, s.r.Get("RangeError").ToObject(s.r), "ERR_OUT_OF_RANGE", "The value of \"size\" is out of range. It must be <= 1GiB. Received %v", n))
+	}
+
+	return int(math.Pow(2, math.Ceil(math.Log2(n))))
+}
+
+// howMuchToRead of the buffer for a read(n), where NaN reads the first chunk of a flowing stream or else the buffer
+func (s *Stream) howMuchToRead(n float64, state *readableState) float64 {
+	switch {
+	case n <= 0 || (state.length == 0 && state.ended):
+		return 0
+	case state.objectMode:
+		return 1
+	case math.IsNaN(n):
+		if isTrue(state.flowing) && state.length > 0 {
+			return float64(s.chunkLength(state.buffer[0]))
+		}
+
+		return float64(state.length)
+	case n <= float64(state.length):
+		return n
+	case state.ended:
+		return float64(state.length)
+	default:
+		return 0
+	}
+}
+
+// fromList takes n bytes (or an object) from the buffer, concatenating or splitting the buffered chunks
+func (s *Stream) fromList(n int, state *readableState) goja.Value {
+	if state.length == 0 {
+		return goja.Null()
+	}
+
+	if state.objectMode {
+		ret := state.buffer[0]
+		state.buffer = state.buffer[1:]
+
+		return ret
+	}
+
+	if n >= state.length {
+		chunks := state.buffer
+		state.buffer = nil
+
+		return s.concat(state, chunks)
+	}
+
+	var chunks []goja.Value
+	for n > 0 {
+		first := state.buffer[0]
+		size := s.chunkLength(first)
+		if n >= size {
+			chunks = append(chunks, first)
+			state.buffer = state.buffer[1:]
+			n -= size
+
+			continue
+		}
+
+		head, tail := s.split(first, n)
+		chunks = append(chunks, head)
+		state.buffer[0] = tail
+		n = 0
+	}
+
+	return s.concat(state, chunks)
+}
+
+// concat the chunks into a single string if the stream is decoded or else into a single Buffer
+func (s *Stream) concat(state *readableState, chunks []goja.Value) goja.Value {
+	if len(chunks) == 1 {
+		return chunks[0]
+	}
+
+	if state.decoder != nil {
+		var ret goja.String = s.r.ToValue("").(goja.String) //nolint:forcetypeassert // a string value
+		for _, chunk := range chunks {
+			ret = ret.Concat(chunk.(goja.String)) //nolint:forcetypeassert // a decoded chunk is a string
+		}
+
+		return ret
+	}
+
+	var data []byte
+	for _, chunk := range chunks {
+		data = append(data, buffer.Bytes(s.r, chunk)...)
+	}
+
+	return buffer.WrapBytes(s.r, data)
+}
+
+// split the chunk into its first n bytes (or code units if it is a string) and the rest
+func (s *Stream) split(chunk goja.Value, n int) (goja.Value, goja.Value) {
+	if str, ok := chunk.(goja.String); ok {
+		return str.Substring(0, n), str.Substring(n, str.Length())
+	}
+
+	data := buffer.Bytes(s.r, chunk)
+
+	return buffer.WrapBytes(s.r, data[:n:n]), buffer.WrapBytes(s.r, data[n:])
+}
+
+// endReadable emits the 'end' event on the next tick once the buffer is empty
+func (s *Stream) endReadable(this goja.Value, state *readableState) {
+	if state.endEmitted {
+		return
+	}
+
+	state.ended = true
+	s.nextTick(func() {
+		if state.errored != nil || state.closeEmitted || state.endEmitted || state.length != 0 {
+			return
+		}
+
+		state.endEmitted = true
+		s.emit(this, "end")
+
+		object := this.ToObject(s.r)
+		if get(object, "writable").ToBoolean() && get(object, "allowHalfOpen").StrictEquals(s.r.ToValue(false)) {
+			s.nextTick(func() {
+				s.call(this, "end")
+			})
+		} else if state.autoDestroy {
+			if w := s.writableStateOf(this); w == nil || (w.autoDestroy && (w.finished || w.disabled)) {
+				s.call(this, "destroy")
+			}
+		}
+	})
+}
+
+// SetEncoding of the stream such that its chunks are decoded into strings
+func (s *Stream) SetEncoding(call goja.FunctionCall) goja.Value {
+	state := s.readableState(call.This)
+	state.decoder = s.decoder(call.Argument(0))
+	state.encoding = state.decoder.encoding
+
+	content := ""
+	for _, chunk := range state.buffer {
+		if str, ok := chunk.(goja.String); ok {
+			content += str.String()
+		} else {
+			content += state.decoder.write(buffer.Bytes(s.r, chunk))
+		}
+	}
+
+	state.buffer = nil
+	state.length = 0
+	if content != "" {
+		value := s.r.ToValue(content)
+		state.buffer = []goja.Value{value}
+		state.length = s.chunkLength(value)
+	}
+
+	return call.This
+}
+
+// Pause a flowing stream, i.e. stop emitting 'data' events
+func (s *Stream) Pause(call goja.FunctionCall) goja.Value {
+	state := s.readableState(call.This)
+	if !isFalse(state.flowing) {
+		state.flowing = ptr(false)
+		s.emit(call.This, "pause")
+	}
+	state.paused = ptr(true)
+
+	return call.This
+}
+
+// Resume a paused stream, i.e. switch it into flowing mode and emit its chunks as 'data' events
+func (s *Stream) Resume(call goja.FunctionCall) goja.Value {
+	state := s.readableState(call.This)
+	if !isTrue(state.flowing) {
+		state.flowing = ptr(!state.readableListening)
+		if !state.resumeScheduled {
+			state.resumeScheduled = true
+			s.nextTick(func() {
+				s.resume(call.This, state)
+			})
+		}
+	}
+	state.paused = ptr(false)
+
+	return call.This
+}
+
+// resume the stream by flowing it
+func (s *Stream) resume(this goja.Value, state *readableState) {
+	if !state.reading {
+		s.call(this, "read", s.r.ToValue(0))
+	}
+
+	state.resumeScheduled = false
+	s.emit(this, "resume")
+	s.flow(this, state)
+	if isTrue(state.flowing) && !state.reading {
+		s.call(this, "read", s.r.ToValue(0))
+	}
+}
+
+// IsPaused if the stream was paused or is read with 'readable' events
+func (s *Stream) IsPaused(call goja.FunctionCall) goja.Value {
+	state := s.readableState(call.This)
+
+	return s.r.ToValue(isTrue(state.paused) || isFalse(state.flowing))
+}
+
+// ReadableOn adds the listener of the event, where a 'data' listener resumes the stream and a 'readable' listener
+// switches the stream to be read with read()
+func (s *Stream) ReadableOn(call goja.FunctionCall) goja.Value {
+	res := s.super(call, "on")
+	state := s.readableState(call.This)
+	switch call.Argument(0).String() {
+	case "data":
+		state.dataListening = true
+		state.readableListening = state.readableListening || s.listenerCount(call.This, "readable") > 0
+		if !isFalse(state.flowing) {
+			s.call(call.This, "resume")
+		}
+	case "readable":
+		if !state.endEmitted && !state.readableListening {
+			state.readableListening = true
+			state.needReadable = true
+			state.flowing = ptr(false)
+			state.emittedReadable = false
+			if state.length > 0 {
+				s.emitReadable(call.This, state)
+			} else if !state.reading {
+				s.nextTick(func() {
+					s.call(call.This, "read", s.r.ToValue(0))
+				})
+			}
+		}
+	}
+
+	return res
+}
+
+// ReadableRemoveListener removes the listener of the event, where removing a 'readable' listener may resume the stream
+func (s *Stream) ReadableRemoveListener(call goja.FunctionCall) goja.Value {
+	res := s.super(call, "removeListener")
+	state := s.readableState(call.This)
+	switch call.Argument(0).String() {
+	case "readable":
+		s.nextTick(func() {
+			s.updateReadableListening(call.This, state)
+		})
+	case "data":
+		state.dataListening = s.listenerCount(call.This, "data") > 0
+	}
+
+	return res
+}
+
+// ReadableRemoveAllListeners of the event, or of every event if no event is supplied
+func (s *Stream) ReadableRemoveAllListeners(call goja.FunctionCall) goja.Value {
+	res := s.super(call, "removeAllListeners")
+	state := s.readableState(call.This)
+	if name := call.Argument(0); goja.IsUndefined(name) || name.String() == "readable" {
+		s.nextTick(func() {
+			s.updateReadableListening(call.This, state)
+		})
+	}
+
+	return res
+}
+
+// updateReadableListening after a 'readable' listener was removed, resuming the stream if it has 'data' listeners
+func (s *Stream) updateReadableListening(this goja.Value, state *readableState) {
+	state.readableListening = s.listenerCount(this, "readable") > 0
+	switch {
+	case state.resumeScheduled && isFalse(state.paused):
+		state.flowing = ptr(true)
+	case state.dataListening:
+		s.call(this, "resume")
+	case !state.readableListening:
+		state.flowing = nil
+	}
+}
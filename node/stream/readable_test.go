@@ -0,0 +1,186 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadable_Flowing(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var readable = new stream.Readable({ read() {} });
+readable.on('data', function(data) { received.push('data:' + data); });
+readable.on('end', function() { received.push('end:' + readable.readableEnded); });
+readable.on('close', function() { received.push('close:' + readable.destroyed); });
+readable.push('a');
+readable.push('b');
+readable.push(null);
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"data:a", "data:b", "end:true", "close:true"}, res.Export())
+}
+
+func TestReadable_Paused(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var readable = new stream.Readable({ read() {} });
+readable.push('ab');
+readable.push('cd');
+readable.push(null);
+readable.on('readable', function() {
+	var chunk;
+	while ((chunk = readable.read(3)) !== null) { received.push(String(chunk)); }
+});
+readable.on('end', function() { received.push('end'); });
+[readable.isPaused(), readable.readableLength, received]`)
+
+	// Assert
+	assert.Equal(t, []any{true, int64(4), []any{"abc", "d", "end"}}, res.Export())
+}
+
+func TestReadable_PauseResume(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var readable = new stream.Readable({ objectMode: true, read() {} });
+readable.on('data', function(data) {
+	received.push(data);
+	if (data === 1) {
+		readable.pause();
+		received.push('paused:' + readable.isPaused());
+		Promise.resolve().then(function() { readable.resume(); });
+	}
+});
+readable.push(1);
+readable.push(2);
+readable.push(null);
+received`)
+
+	// Assert
+	assert.Equal(t, []any{int64(1), "paused:true", int64(2)}, res.Export())
+}
+
+func TestReadable_Read_CallsRead(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var calls = 0;
+var readable = new stream.Readable({ highWaterMark: 4, read() { calls++; this.push('ab'); } });
+readable.read(0);
+[calls, readable.readableLength, readable.readableHighWaterMark]`)
+
+	// Assert
+	assert.Equal(t, []any{int64(1), int64(2), int64(4)}, res.Export())
+}
+
+func TestReadable_SetEncoding(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var Buffer = require('buffer').Buffer;
+var received = [];
+var readable = new stream.Readable({ read() {} });
+readable.setEncoding('utf8');
+readable.on('data', function(data) { received.push(data); });
+readable.push(Buffer.from([0xe2]));
+readable.push(Buffer.from([0x82, 0xac]));
+readable.push('x');
+readable.push(null);
+[readable.readableEncoding, received]`)
+
+	// Assert
+	assert.Equal(t, []any{"utf8", []any{"€", "x"}}, res.Export())
+}
+
+func TestReadable_Unshift(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var readable = new stream.Readable({ objectMode: true, read() {} });
+readable.push('b');
+readable.unshift('a');
+[readable.read(), readable.read(), readable.read()]`)
+
+	// Assert
+	assert.Equal(t, []any{"a", "b", nil}, res.Export())
+}
+
+func TestReadable_Errors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"read not implemented": {
+			script: `
+var readable = new stream.Readable();
+readable.on('error', function(err) { received.push(err.code + ': ' + err.message); });
+readable.read();`,
+			expected: []any{"ERR_METHOD_NOT_IMPLEMENTED: The _read() method is not implemented"},
+		},
+		"invalid chunk": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+readable.on('error', function(err) { received.push(err.code); });
+readable.push(1);`,
+			expected: []any{"ERR_INVALID_ARG_TYPE"},
+		},
+		"push after EOF": {
+			script: `
+var readable = new stream.Readable({ read() {} });
+readable.on('error', function(err) { received.push(err.code + ': ' + err.message); });
+readable.push(null);
+readable.push('a');`,
+			expected: []any{"ERR_STREAM_PUSH_AFTER_EOF: stream.push() after EOF"},
+		},
+		"invalid highWaterMark": {
+			script: `
+try { new stream.Readable({ highWaterMark: -1 }); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_INVALID_ARG_VALUE: The property 'options.highWaterMark' is invalid. Received -1"},
+		},
+		"unknown encoding": {
+			script: `
+try { new stream.Readable().setEncoding('utf7'); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_UNKNOWN_ENCODING: Unknown encoding: utf7"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
+
+func TestReadable_Properties(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var readable = new stream.Readable({ objectMode: true, read() {} });
+var before = [readable.readable, readable.readableFlowing, readable.readableObjectMode, readable.readableHighWaterMark];
+readable.on('data', function() {});
+var after = [readable.readableFlowing, readable.destroyed, readable.closed, readable.errored];
+[before, after]`)
+
+	// Assert
+	assert.Equal(t, []any{[]any{true, nil, true, int64(16)}, []any{true, false, false, nil}}, res.Export())
+}
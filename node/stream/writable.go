@@ -0,0 +1,645 @@
+package stream
+
+import (
+	"github.com/Emptyless/go-spectral/node/internal/args"
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/buffer"
+	nodeerrors "github.com/dop251/goja_nodejs/errors"
+)
+
+// bufferedWrite of a chunk that is buffered while another chunk is written or the stream is corked
+type bufferedWrite struct {
+	chunk    goja.Value
+	encoding string
+	callback goja.Value
+}
+
+// writableState of a writable stream, i.e. the chunk being written by _write and the chunks buffered behind it. The
+// length is compared to the highWaterMark to apply backpressure, i.e. write returns false and 'drain' is emitted once
+// the buffered chunks are written.
+type writableState struct {
+	common
+	buffered          []*bufferedWrite
+	onFinished        []goja.Value
+	onwrite           goja.Value
+	writecb           goja.Value
+	writelen          int
+	corked            int
+	pendingcb         int
+	writing           bool
+	expectWriteCb     bool
+	bufferProcessing  bool
+	afterWritePending bool
+	needDrain         bool
+	ending            bool
+	ended             bool
+	finished          bool
+	finalCalled       bool
+	prefinished       bool
+	decodeStrings     bool
+	disabled          bool
+	defaultEncoding   string
+}
+
+// writableStateOf the stream, which is nil if the stream is not writable
+func (s *Stream) writableStateOf(this goja.Value) *writableState {
+	object, ok := this.(*goja.Object)
+	if !ok {
+		return nil
+	}
+
+	if v := object.GetSymbol(s.writableSymbol); v != nil {
+		if state, ok := v.Export().(*writableState); ok && state.owner.SameAs(object) {
+			return state
+		}
+	}
+
+	return nil
+}
+
+// writableState of the stream, which is initialized with the default options if the constructor was not called
+func (s *Stream) writableState(this goja.Value) *writableState {
+	if state := s.writableStateOf(this); state != nil {
+		return state
+	}
+
+	return s.initWritable(this.ToObject(s.r), s.r.NewObject(), false)
+}
+
+// initWritable state of the stream with the options, where a Duplex may set the options of its writable side
+func (s *Stream) initWritable(this *goja.Object, options *goja.Object, isDuplex bool) *writableState {
+	state := &writableState{common: newCommon(this, options), defaultEncoding: "utf8"}
+	state.objectMode = boolOption(options, "objectMode", false) || (isDuplex && boolOption(options, "writableObjectMode", false))
+	state.highWaterMark = s.highWaterMark(options, "writableHighWaterMark", isDuplex, state.objectMode)
+	state.decodeStrings = boolOption(options, "decodeStrings", true)
+	if encoding := option(options, "defaultEncoding"); encoding != nil {
+		state.defaultEncoding = s.encoding(encoding)
+	}
+	state.onwrite = s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+		s.onwrite(this, state, call.Argument(0))
+
+		return goja.Undefined()
+	})
+
+	_ = this.DefineDataPropertySymbol(s.writableSymbol, s.r.ToValue(state), goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+
+	return state
+}
+
+// setWritable sets the methods of a writable stream on the prototype
+func (s *Stream) setWritable(prototype *goja.Object) {
+	s.set(prototype, []method{
+		{name: "write", fn: s.Write},
+		{name: "_write", fn: s.DefaultWrite},
+		{name: "end", fn: s.End},
+		{name: "cork", fn: s.Cork},
+		{name: "uncork", fn: s.Uncork},
+		{name: "setDefaultEncoding", fn: s.SetDefaultEncoding},
+		{name: "destroy", fn: s.Destroy},
+		{name: "_destroy", fn: s.DefaultDestroy},
+	})
+
+	s.getter(prototype, "writable", func(this goja.Value) goja.Value {
+		state := s.writableState(this)
+
+		return s.r.ToValue(!state.disabled && !state.destroyed && state.errored == nil && !state.ending && !state.ended)
+	})
+	s.getter(prototype, "writableEnded", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).ending)
+	})
+	s.getter(prototype, "writableFinished", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).finished)
+	})
+	s.getter(prototype, "writableLength", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).length)
+	})
+	s.getter(prototype, "writableHighWaterMark", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).highWaterMark)
+	})
+	s.getter(prototype, "writableObjectMode", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).objectMode)
+	})
+	s.getter(prototype, "writableNeedDrain", func(this goja.Value) goja.Value {
+		state := s.writableState(this)
+
+		return s.r.ToValue(!state.destroyed && !state.ending && state.needDrain)
+	})
+	s.getter(prototype, "writableCorked", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).corked)
+	})
+	s.getter(prototype, "destroyed", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).destroyed)
+	})
+	s.getter(prototype, "closed", func(this goja.Value) goja.Value {
+		return s.r.ToValue(s.writableState(this).closed)
+	})
+	s.getter(prototype, "errored", func(this goja.Value) goja.Value {
+		if errored := s.writableState(this).errored; errored != nil {
+			return errored
+		}
+
+		return goja.Null()
+	})
+}
+
+// Writable is the constructor of a writable stream, i.e. new Writable({ write(chunk, encoding, callback) {} })
+func (s *Stream) Writable(call goja.ConstructorCall) *goja.Object {
+	options := s.options(call.Argument(0))
+	s.initWritable(call.This, options, false)
+	s.function(call.This, options, "write", "_write")
+	s.function(call.This, options, "writev", "_writev")
+	s.function(call.This, options, "destroy", "_destroy")
+	s.function(call.This, options, "final", "_final")
+
+	return nil
+}
+
+// HasWritableInstance is Writable[Symbol.hasInstance], such that a Duplex is an instance of Writable as well even though
+// it only inherits from Readable
+func (s *Stream) HasWritableInstance(call goja.FunctionCall) goja.Value {
+	object, ok := call.Argument(0).(*goja.Object)
+	if !ok {
+		return s.r.ToValue(false)
+	}
+
+	constructor := call.This.ToObject(s.r)
+	if prototype, ok := constructor.Get("prototype").(*goja.Object); ok {
+		for p := object.Prototype(); p != nil; p = p.Prototype() {
+			if p.SameAs(prototype) {
+				return s.r.ToValue(true)
+			}
+		}
+	}
+
+	return s.r.ToValue(constructor.SameAs(s.writable) && s.writableStateOf(object) != nil)
+}
+
+// DefaultWrite is the default _write, which writes the chunk with _writev if it is implemented
+func (s *Stream) DefaultWrite(call goja.FunctionCall) goja.Value {
+	if _, ok := goja.AssertFunction(call.This.ToObject(s.r).Get("_writev")); !ok {
+		panic(s.newError("ERR_METHOD_NOT_IMPLEMENTED", "The _write() method is not implemented"))
+	}
+
+	chunk := s.r.NewObject()
+	_ = chunk.Set("chunk", call.Argument(0))
+	_ = chunk.Set("encoding", call.Argument(1))
+
+	return s.call(call.This, "_writev", s.r.NewArray(chunk), call.Argument(2))
+}
+
+// Write the chunk with the optional encoding and callback. Returns false if the buffered length reached the
+// highWaterMark, in which case the caller should wait for the 'drain' event before writing more chunks.
+func (s *Stream) Write(call goja.FunctionCall) goja.Value {
+	ret, _ := s.write(call.This, call.Argument(0), call.Argument(1), call.Argument(2))
+
+	return s.r.ToValue(ret)
+}
+
+// write the chunk, returning the error if the stream is ended or destroyed
+func (s *Stream) write(this goja.Value, chunk goja.Value, encoding goja.Value, cb goja.Value) (bool, goja.Value) {
+	state := s.writableState(this)
+	if _, ok := goja.AssertFunction(encoding); ok {
+		cb, encoding = encoding, goja.Undefined()
+	}
+
+	if _, ok := goja.AssertFunction(cb); !ok {
+		cb = nil
+	}
+
+	if goja.IsNull(chunk) {
+		panic(nodeerrors.NewTypeError(s.r, "ERR_STREAM_NULL_VALUES", "May not write null values to stream"))
+	}
+
+	enc := state.defaultEncoding
+	if !state.objectMode {
+		if encoding != nil && encoding.ToBoolean() {
+			enc = encoding.String()
+			if enc != "buffer" && !isEncoding(enc) {
+				panic(nodeerrors.NewTypeError(s.r, "ERR_UNKNOWN_ENCODING", "Unknown encoding: %s", enc))
+			}
+		}
+
+		switch {
+		case s.isBytes(chunk):
+			chunk, enc = s.toBuffer(chunk), "buffer"
+		case !s.isChunk(chunk):
+			panic(args.InvalidArgType(s.r, "chunk", "of type string or an instance of Buffer, TypedArray, or DataView", chunk))
+		case state.decodeStrings:
+			chunk, enc = buffer.WrapBytes(s.r, buffer.DecodeBytes(s.r, chunk, s.r.ToValue(enc))), "buffer"
+		}
+	}
+
+	var err goja.Value
+	if state.ending {
+		err = s.newError("ERR_STREAM_WRITE_AFTER_END", "write after end")
+	} else if state.destroyed {
+		err = s.newError("ERR_STREAM_DESTROYED", "Cannot call write after a stream was destroyed")
+	}
+
+	if err != nil {
+		s.nextTick(func() {
+			s.invoke(cb, err)
+		})
+		s.errorOrDestroy(this, err, true)
+
+		return false, err
+	}
+
+	state.pendingcb++
+
+	return s.writeOrBuffer(this, state, chunk, enc, cb), nil
+}
+
+// invoke the callback with the arguments unless the callback is nil
+func (s *Stream) invoke(cb goja.Value, args ...goja.Value) {
+	if fn, ok := goja.AssertFunction(cb); ok {
+		if _, err := fn(goja.Undefined(), args...); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// writeOrBuffer writes the chunk with _write or buffers it while another chunk is written or the stream is corked
+func (s *Stream) writeOrBuffer(this goja.Value, state *writableState, chunk goja.Value, encoding string, cb goja.Value) bool {
+	length := s.size(state.objectMode, chunk)
+	state.length += length
+
+	if state.writing || state.corked > 0 || state.errored != nil {
+		state.buffered = append(state.buffered, &bufferedWrite{chunk: chunk, encoding: encoding, callback: cb})
+	} else {
+		state.writelen = length
+		state.writecb = cb
+		state.writing = true
+		state.sync = true
+		state.expectWriteCb = true
+		s.call(this, "_write", chunk, s.r.ToValue(encoding), state.onwrite)
+		state.sync = false
+	}
+
+	ret := state.length < state.highWaterMark || state.length == 0
+	if !ret {
+		state.needDrain = true
+	}
+
+	return ret && !state.destroyed && state.errored == nil
+}
+
+// onwrite is the callback of _write, which writes the next buffered chunk and calls the callback of the write
+func (s *Stream) onwrite(this goja.Value, state *writableState, er goja.Value) {
+	if !state.expectWriteCb {
+		s.errorOrDestroy(this, s.newError("ERR_MULTIPLE_CALLBACK", "Callback called multiple times"), false)
+
+		return
+	}
+
+	sync := state.sync
+	cb := state.writecb
+	state.writecb = nil
+	state.writing = false
+	state.expectWriteCb = false
+	state.length -= state.writelen
+	state.writelen = 0
+
+	if truthy(er) {
+		if state.errored == nil {
+			state.errored = er
+		}
+
+		if r := s.readableStateOf(this); r != nil && r.errored == nil {
+			r.errored = er
+		}
+
+		onwriteError := func() {
+			state.pendingcb--
+			s.invoke(cb, er)
+			s.errorBuffer(state)
+			s.errorOrDestroy(this, er, false)
+		}
+		if sync {
+			s.nextTick(onwriteError)
+		} else {
+			onwriteError()
+		}
+
+		return
+	}
+
+	if len(state.buffered) > 0 {
+		s.clearBuffer(this, state)
+	}
+
+	if !sync {
+		s.afterWrite(this, state, cb)
+
+		return
+	}
+
+	needTick := cb != nil || (state.needDrain && state.length == 0) || state.destroyed
+	if needTick && (cb != nil || !state.afterWritePending) {
+		state.afterWritePending = true
+		s.nextTick(func() {
+			s.afterWrite(this, state, cb)
+		})
+
+		return
+	}
+
+	state.pendingcb--
+	if state.ending {
+		s.finishMaybe(this, state, true)
+	}
+}
+
+// truthy if the value is set and truthy
+func truthy(value goja.Value) bool {
+	return value != nil && value.ToBoolean()
+}
+
+// afterWrite emits 'drain' if the buffer was full and is now empty, then calls the callback of the write
+func (s *Stream) afterWrite(this goja.Value, state *writableState, cb goja.Value) {
+	state.afterWritePending = false
+	if state.needDrain && !state.ending && !state.destroyed && state.length == 0 {
+		state.needDrain = false
+		s.emit(this, "drain")
+	}
+
+	state.pendingcb--
+	s.invoke(cb, goja.Null())
+
+	if state.destroyed {
+		s.errorBuffer(state)
+	}
+
+	if state.ending {
+		s.finishMaybe(this, state, true)
+	}
+}
+
+// errorBuffer calls the callbacks of the buffered chunks and of end with the error of the stream
+func (s *Stream) errorBuffer(state *writableState) {
+	if state.writing {
+		return
+	}
+
+	for _, w := range state.buffered {
+		state.length -= s.size(state.objectMode, w.chunk)
+		s.invoke(w.callback, s.erroredOr(state.errored, "write"))
+	}
+	state.buffered = nil
+
+	s.callFinished(state, s.erroredOr(state.errored, "end"))
+}
+
+// erroredOr is the error of the stream, or an ERR_STREAM_DESTROYED error of the method if the stream has no error
+func (s *Stream) erroredOr(errored goja.Value, method string) goja.Value {
+	if errored != nil {
+		return errored
+	}
+
+	return s.newError("ERR_STREAM_DESTROYED", "Cannot call %s after a stream was destroyed", method)
+}
+
+// callFinished calls the callbacks of end with the error, which is null if the stream finished
+func (s *Stream) callFinished(state *writableState, err goja.Value) {
+	callbacks := state.onFinished
+	state.onFinished = nil
+	for _, cb := range callbacks {
+		s.invoke(cb, err)
+	}
+}
+
+// clearBuffer writes the buffered chunks, with _writev if it is implemented and more than one chunk is buffered
+func (s *Stream) clearBuffer(this goja.Value, state *writableState) {
+	if state.corked > 0 || state.bufferProcessing || state.destroyed || len(state.buffered) == 0 {
+		return
+	}
+
+	state.bufferProcessing = true
+	if _, ok := goja.AssertFunction(this.ToObject(s.r).Get("_writev")); ok && len(state.buffered) > 1 {
+		buffered := state.buffered
+		state.buffered = nil
+		state.pendingcb -= len(buffered) - 1
+
+		chunks := make([]any, len(buffered))
+		for i, w := range buffered {
+			chunk := s.r.NewObject()
+			_ = chunk.Set("chunk", w.chunk)
+			_ = chunk.Set("encoding", w.encoding)
+			chunks[i] = chunk
+		}
+
+		callback := s.r.ToValue(func(call goja.FunctionCall) goja.Value {
+			for _, w := range buffered {
+				s.invoke(w.callback, call.Argument(0))
+			}
+
+			return goja.Undefined()
+		})
+		s.doWrite(this, state, state.length, callback, func() {
+			s.call(this, "_writev", s.r.NewArray(chunks...), state.onwrite)
+		})
+	} else {
+		for len(state.buffered) > 0 && !state.writing {
+			w := state.buffered[0]
+			state.buffered = state.buffered[1:]
+			s.doWrite(this, state, s.size(state.objectMode, w.chunk), w.callback, func() {
+				s.call(this, "_write", w.chunk, s.r.ToValue(w.encoding), state.onwrite)
+			})
+		}
+	}
+	state.bufferProcessing = false
+}
+
+// doWrite of a buffered chunk with the write function, or fail the write if the stream is destroyed
+func (s *Stream) doWrite(this goja.Value, state *writableState, length int, cb goja.Value, write func()) {
+	state.writelen = length
+	state.writecb = cb
+	state.writing = true
+	state.sync = true
+	state.expectWriteCb = true
+	if state.destroyed {
+		s.onwrite(this, state, s.newError("ERR_STREAM_DESTROYED", "Cannot call write after a stream was destroyed"))
+	} else {
+		write()
+	}
+	state.sync = false
+}
+
+// End the stream after writing the optional final chunk, calling the optional callback once the stream finished
+func (s *Stream) End(call goja.FunctionCall) goja.Value {
+	state := s.writableState(call.This)
+	chunk, encoding, cb := call.Argument(0), call.Argument(1), call.Argument(2)
+	if _, ok := goja.AssertFunction(chunk); ok {
+		chunk, encoding, cb = goja.Null(), goja.Null(), chunk
+	} else if _, ok := goja.AssertFunction(encoding); ok {
+		encoding, cb = goja.Null(), encoding
+	}
+
+	var err goja.Value
+	if !goja.IsNull(chunk) && !goja.IsUndefined(chunk) {
+		_, err = s.write(call.This, chunk, encoding, goja.Undefined())
+	}
+
+	if state.corked > 0 {
+		state.corked = 1
+		s.call(call.This, "uncork")
+	}
+
+	switch {
+	case err != nil:
+	case !state.ending && state.errored == nil:
+		state.ending = true
+		s.finishMaybe(call.This, state, true)
+		state.ended = true
+	case state.finished:
+		err = s.newError("ERR_STREAM_ALREADY_FINISHED", "Cannot call end after a stream was finished")
+	case state.destroyed:
+		err = s.newError("ERR_STREAM_DESTROYED", "Cannot call end after a stream was destroyed")
+	}
+
+	if _, ok := goja.AssertFunction(cb); ok {
+		switch {
+		case err != nil:
+			s.nextTick(func() { s.invoke(cb, err) })
+		case state.errored != nil:
+			s.nextTick(func() { s.invoke(cb, state.errored) })
+		case state.finished:
+			s.nextTick(func() { s.invoke(cb, goja.Null()) })
+		default:
+			state.onFinished = append(state.onFinished, cb)
+		}
+	}
+
+	return call.This
+}
+
+// needFinish if the stream is ending and every chunk was written
+func (s *Stream) needFinish(state *writableState) bool {
+	return state.ending && !state.destroyed && state.length == 0 && state.errored == nil && len(state.buffered) == 0 &&
+		!state.finished && !state.writing && !state.errorEmitted && !state.closeEmitted
+}
+
+// prefinish calls _final if it is implemented or else emits 'prefinish'
+func (s *Stream) prefinish(this goja.Value, state *writableState) {
+	if state.prefinished || state.finalCalled {
+		return
+	}
+
+	final, ok := goja.AssertFunction(this.ToObject(s.r).Get("_final"))
+	if !ok || state.destroyed {
+		state.finalCalled = true
+		state.prefinished = true
+		s.emit(this, "prefinish")
+
+		return
+	}
+
+	state.finalCalled = true
+	state.sync = true
+	state.pendingcb++
+	onFinish := func(call goja.FunctionCall) goja.Value {
+		s.onFinish(this, state, call.Argument(0))
+
+		return goja.Undefined()
+	}
+	if thrown := s.try(func() {
+		if _, err := final(this, s.r.ToValue(onFinish)); err != nil {
+			panic(err)
+		}
+	}); thrown != nil {
+		s.onFinish(this, state, thrown)
+	}
+	state.sync = false
+}
+
+// onFinish is the callback of _final, which finishes the stream on the next tick unless _final failed
+func (s *Stream) onFinish(this goja.Value, state *writableState, err goja.Value) {
+	if state.prefinished {
+		if !truthy(err) {
+			err = s.newError("ERR_MULTIPLE_CALLBACK", "Callback called multiple times")
+		}
+		s.errorOrDestroy(this, err, false)
+
+		return
+	}
+
+	state.pendingcb--
+	if truthy(err) {
+		s.callFinished(state, err)
+		s.errorOrDestroy(this, err, state.sync)
+	} else if s.needFinish(state) {
+		state.prefinished = true
+		s.emit(this, "prefinish")
+		state.pendingcb++
+		s.nextTick(func() {
+			s.finish(this, state)
+		})
+	}
+}
+
+// finishMaybe finishes the stream if it is ending and every chunk was written
+func (s *Stream) finishMaybe(this goja.Value, state *writableState, sync bool) {
+	if !s.needFinish(state) {
+		return
+	}
+
+	s.prefinish(this, state)
+	if state.pendingcb != 0 {
+		return
+	}
+
+	if sync {
+		state.pendingcb++
+		s.nextTick(func() {
+			if s.needFinish(state) {
+				s.finish(this, state)
+			} else {
+				state.pendingcb--
+			}
+		})
+	} else if s.needFinish(state) {
+		state.pendingcb++
+		s.finish(this, state)
+	}
+}
+
+// finish the stream, i.e. call the callbacks of end and emit 'finish'
+func (s *Stream) finish(this goja.Value, state *writableState) {
+	state.pendingcb--
+	state.finished = true
+	s.callFinished(state, goja.Null())
+	s.emit(this, "finish")
+
+	if state.autoDestroy {
+		if r := s.readableStateOf(this); r == nil || (r.autoDestroy && (r.endEmitted || r.disabled)) {
+			s.call(this, "destroy")
+		}
+	}
+}
+
+// Cork the stream, i.e. buffer the written chunks until uncork is called
+func (s *Stream) Cork(call goja.FunctionCall) goja.Value {
+	s.writableState(call.This).corked++
+
+	return goja.Undefined()
+}
+
+// Uncork the stream, writing the buffered chunks once uncork was called as many times as cork
+func (s *Stream) Uncork(call goja.FunctionCall) goja.Value {
+	state := s.writableState(call.This)
+	if state.corked > 0 {
+		state.corked--
+		if !state.writing {
+			s.clearBuffer(call.This, state)
+		}
+	}
+
+	return goja.Undefined()
+}
+
+// SetDefaultEncoding of the string chunks that are written without an encoding
+func (s *Stream) SetDefaultEncoding(call goja.FunctionCall) goja.Value {
+	s.writableState(call.This).defaultEncoding = s.encoding(call.Argument(0))
+
+	return call.This
+}
@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritable_Write(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var writable = new stream.Writable({
+	write(chunk, encoding, callback) { received.push(String(chunk) + ':' + encoding); callback(); },
+});
+writable.on('finish', function() { received.push('finish:' + writable.writableFinished); });
+writable.on('close', function() { received.push('close'); });
+writable.write('a', function() { received.push('callback'); });
+writable.end('b');
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"a:buffer", "b:buffer", "callback", "finish:true", "close"}, res.Export())
+}
+
+func TestWritable_Backpressure(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var writable = new stream.Writable({
+	highWaterMark: 3,
+	write(chunk, encoding, callback) { received.push('write:' + chunk); Promise.resolve().then(callback); },
+});
+writable.on('drain', function() { received.push('drain'); });
+received.push(writable.write('ab'), writable.write('cd'), writable.writableNeedDrain, writable.writableLength);
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"write:ab", true, false, true, int64(4), "write:cd", "drain"}, res.Export())
+}
+
+func TestWritable_DecodeStrings(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var writable = new stream.Writable({
+	decodeStrings: false,
+	write(chunk, encoding, callback) { received.push(typeof chunk + ':' + encoding); callback(); },
+});
+writable.write('a', 'hex');
+writable.setDefaultEncoding('base64');
+writable.write('b');
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"string:hex", "string:base64"}, res.Export())
+}
+
+func TestWritable_CorkWritev(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var writable = new stream.Writable({
+	writev(chunks, callback) { received.push(chunks.map(function(c) { return String(c.chunk); }).join('|')); callback(); },
+});
+writable.cork();
+writable.write('a');
+writable.write('b');
+received.push(writable.writableCorked);
+writable.uncork();
+received`)
+
+	// Assert
+	assert.Equal(t, []any{int64(1), "a|b"}, res.Export())
+}
+
+func TestWritable_Final(t *testing.T) {
+	t.Parallel()
+	// Act
+	res := run(t, `
+var stream = require('stream');
+var received = [];
+var writable = new stream.Writable({
+	write(chunk, encoding, callback) { callback(); },
+	final(callback) { received.push('final'); Promise.resolve().then(callback); },
+});
+writable.on('prefinish', function() { received.push('prefinish'); });
+writable.on('finish', function() { received.push('finish'); });
+writable.end(function() { received.push('end callback'); });
+received`)
+
+	// Assert
+	assert.Equal(t, []any{"final", "prefinish", "end callback", "finish"}, res.Export())
+}
+
+func TestWritable_Errors(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		script   string
+		expected any
+	}{
+		"write after end": {
+			script: `
+var writable = new stream.Writable({ write(chunk, encoding, callback) { callback(); } });
+writable.on('error', function(err) { received.push('error: ' + err.message); });
+writable.end();
+writable.write('a', function(err) { received.push('callback: ' + err.code); });`,
+			expected: []any{"callback: ERR_STREAM_WRITE_AFTER_END", "error: write after end"},
+		},
+		"write after destroy": {
+			script: `
+var writable = new stream.Writable({ write(chunk, encoding, callback) { callback(); } });
+writable.destroy();
+received.push(writable.write('a', function(err) { received.push(err.code); }));`,
+			expected: []any{false, "ERR_STREAM_DESTROYED"},
+		},
+		"null chunk": {
+			script: `
+try { new stream.Writable().write(null); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_STREAM_NULL_VALUES: May not write null values to stream"},
+		},
+		"invalid chunk": {
+			script: `
+try { new stream.Writable().write(5); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_INVALID_ARG_TYPE: The \"chunk\" argument must be of type string or an instance of Buffer, TypedArray, or DataView. Received type number (5)"},
+		},
+		"write not implemented": {
+			script: `
+try { new stream.Writable().write('a'); } catch (err) { received.push(err.code + ': ' + err.message); }`,
+			expected: []any{"ERR_METHOD_NOT_IMPLEMENTED: The _write() method is not implemented"},
+		},
+		"callback error": {
+			script: `
+var writable = new stream.Writable({ write(chunk, encoding, callback) { callback(new Error('failed')); } });
+writable.on('error', function(err) { received.push('error: ' + err.message + ':' + writable.destroyed); });
+writable.write('a');`,
+			expected: []any{"error: failed:true"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			res := run(t, "var stream = require('stream');\nvar received = [];\n"+tt.script+"\nreceived")
+
+			// Assert
+			assert.Equal(t, tt.expected, res.Export())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"github.com/Emptyless/go-spectral/node/internal/args"
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	gojautil "github.com/dop251/goja_nodejs/util" // init global registry import
@@ -19,6 +20,32 @@ func (util *Util) Inspect(_ goja.FunctionCall) goja.Value {
 	panic("not implemented")
 }
 
+// Inherits sets the prototype of the prototype of the constructor to the prototype of the super constructor, i.e.
+// util.inherits(constructor, superConstructor). The super constructor is available as constructor.super_.
+func (util *Util) Inherits(call goja.FunctionCall) goja.Value {
+	constructor, ok := call.Argument(0).(*goja.Object)
+	if !ok {
+		panic(args.InvalidArgType(util.r, "ctor", "of type function", call.Argument(0)))
+	}
+
+	superConstructor, ok := call.Argument(1).(*goja.Object)
+	if !ok {
+		panic(args.InvalidArgType(util.r, "superCtor", "of type function", call.Argument(1)))
+	}
+
+	superPrototype, ok := superConstructor.Get("prototype").(*goja.Object)
+	if !ok {
+		panic(args.InvalidArgType(util.r, "superCtor.prototype", "of type object", superConstructor.Get("prototype")))
+	}
+
+	_ = constructor.DefineDataProperty("super_", superConstructor, goja.FLAG_TRUE, goja.FLAG_TRUE, goja.FLAG_FALSE)
+	if err := constructor.Get("prototype").ToObject(util.r).SetPrototype(superPrototype); err != nil {
+		panic(err)
+	}
+
+	return goja.Undefined()
+}
+
 // Require the util package (with a forward reference to the gojautil.Util package)
 func Require(runtime *goja.Runtime, module *goja.Object) {
 	gojautil.Require(runtime, module) // set the format method
@@ -28,6 +55,7 @@ func Require(runtime *goja.Runtime, module *goja.Object) {
 
 	exports := module.Get("exports").(*goja.Object) //nolint:forcetypeassert // based on library reference implementation
 	_ = exports.Set("inspect", s.Inspect)
+	_ = exports.Set("inherits", s.Inherits)
 }
 
 // Enable the util package
@@ -24,6 +24,42 @@ func TestUtil_Inspect_NotImplementedShouldPanic(t *testing.T) {
 	assert.Panics(t, f)
 }
 
+func TestUtil_Inherits(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(runtime, registry, registry.Enable(runtime))
+
+	// Act
+	res, err := runtime.RunString(`
+function Base() {}
+Base.prototype.kind = function() { return 'base'; };
+function Child() { Base.call(this); }
+util.inherits(Child, Base);
+var child = new Child();
+[child instanceof Base, child.kind(), Child.super_ === Base, Object.keys(Child).length]`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []any{true, "base", true, int64(0)}, res.Export())
+}
+
+func TestUtil_Inherits_NotAnObjectShouldThrowTypeError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	runtime := goja.New()
+	registry := noderequire.NewRegistry()
+	Enable(runtime, registry, registry.Enable(runtime))
+
+	// Act
+	res, err := runtime.RunString(`try { util.inherits(function() {}, null); } catch (err) { err.name + ' ' + err.code; }`)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "TypeError ERR_INVALID_ARG_TYPE", res.Export())
+}
+
 func TestRequire(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -37,6 +73,7 @@ func TestRequire(t *testing.T) {
 
 	// Assert
 	assert.NotNil(t, exports.Get("inspect"))
+	assert.NotNil(t, exports.Get("inherits"))
 }
 
 func TestEnable(t *testing.T) {
@@ -0,0 +1,75 @@
+package gospectral
+
+import (
+	"path"
+	"strings"
+)
+
+// MaxSeverity returns the most severe Severity in the Output, or false if the Output is empty
+func (o Output) MaxSeverity() (Severity, bool) {
+	if len(o) == 0 {
+		return 0, false
+	}
+
+	maxSeverity := o[0].Severity
+	for _, rule := range o[1:] {
+		if rule.Severity.AtLeast(maxSeverity) {
+			maxSeverity = rule.Severity
+		}
+	}
+
+	return maxSeverity, true
+}
+
+// Filter returns the Rule's with a Severity at least as severe as minSeverity, e.g. Filter(SeverityWarn) returns
+// both errors and warnings
+func (o Output) Filter(minSeverity Severity) Output {
+	filtered := Output{}
+	for _, rule := range o {
+		if rule.Severity.AtLeast(minSeverity) {
+			filtered = append(filtered, rule)
+		}
+	}
+
+	return filtered
+}
+
+// HasErrors reports whether the Output contains a Rule with SeverityError
+func (o Output) HasErrors() bool {
+	maxSeverity, ok := o.MaxSeverity()
+
+	return ok && maxSeverity == SeverityError
+}
+
+// attribute sets the Rule.Document of every Rule. A Rule with a Source that is one of the linted documents belongs to
+// that document and if a single document (not a glob) was linted, every Rule belongs to it. Otherwise, e.g. when
+// multiple documents reference the same file, the Rule.Document is ambiguous and left empty.
+func (o Output) attribute(documents []string, workingDirectory string) {
+	linted := make(map[string]bool, len(documents))
+	for _, document := range documents {
+		linted[absolutePath(document, workingDirectory)] = true
+	}
+
+	single := ""
+	if len(documents) == 1 && !strings.ContainsAny(documents[0], "*?[{") {
+		single = absolutePath(documents[0], workingDirectory)
+	}
+
+	for i := range o {
+		switch {
+		case linted[o[i].Source]:
+			o[i].Document = o[i].Source
+		case single != "":
+			o[i].Document = single
+		}
+	}
+}
+
+// absolutePath of name relative to the working directory
+func absolutePath(name string, workingDirectory string) string {
+	if path.IsAbs(name) {
+		return path.Clean(name)
+	}
+
+	return path.Join(workingDirectory, name)
+}
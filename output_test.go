@@ -0,0 +1,85 @@
+package gospectral
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput_MaxSeverity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	output := Output{{Severity: SeverityHint}, {Severity: SeverityWarn}, {Severity: SeverityInfo}}
+
+	// Act
+	severity, ok := output.MaxSeverity()
+
+	// Assert
+	assert.True(t, ok)
+	assert.Equal(t, SeverityWarn, severity)
+}
+
+func TestOutput_MaxSeverity_Empty(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, ok := Output{}.MaxSeverity()
+
+	// Assert
+	assert.False(t, ok)
+}
+
+func TestOutput_Filter(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	output := Output{{Code: "a", Severity: SeverityHint}, {Code: "b", Severity: SeverityError}, {Code: "c", Severity: SeverityWarn}}
+
+	// Act
+	filtered := output.Filter(SeverityWarn)
+
+	// Assert
+	assert.Equal(t, Output{{Code: "b", Severity: SeverityError}, {Code: "c", Severity: SeverityWarn}}, filtered)
+}
+
+func TestOutput_HasErrors(t *testing.T) {
+	t.Parallel()
+	// Assert
+	assert.True(t, Output{{Severity: SeverityWarn}, {Severity: SeverityError}}.HasErrors())
+	assert.False(t, Output{{Severity: SeverityWarn}}.HasErrors())
+	assert.False(t, Output{}.HasErrors())
+}
+
+func TestOutput_Attribute(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	output := Output{{Source: "/wd/a.yaml"}, {Source: "/wd/b.yaml"}, {Source: "/wd/common.yaml"}}
+
+	// Act
+	output.attribute([]string{"./a.yaml", "/wd/b.yaml"}, "/wd")
+
+	// Assert
+	assert.Equal(t, []string{"/wd/a.yaml", "/wd/b.yaml", ""}, []string{output[0].Document, output[1].Document, output[2].Document})
+}
+
+func TestOutput_Attribute_SingleDocument(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	output := Output{{Source: "/wd/a.yaml"}, {Source: "/wd/common.yaml"}}
+
+	// Act
+	output.attribute([]string{"a.yaml"}, "/wd")
+
+	// Assert
+	assert.Equal(t, []string{"/wd/a.yaml", "/wd/a.yaml"}, []string{output[0].Document, output[1].Document})
+}
+
+func TestOutput_Attribute_SingleGlob(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	output := Output{{Source: "/wd/specs/a.yaml"}}
+
+	// Act
+	output.attribute([]string{"specs/*.yaml"}, "/wd")
+
+	// Assert
+	assert.Empty(t, output[0].Document)
+}
@@ -0,0 +1,248 @@
+package gospectral
+
+import (
+	"bytes"
+	"io/fs"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	nodefs "github.com/Emptyless/go-spectral/node/fs"
+	"github.com/dop251/goja"
+	noderequire "github.com/dop251/goja_nodejs/require"
+)
+
+// RulesetContentName is the name under which the Config.RulesetContent is served if no ruleset is supplied to Lint
+const RulesetContentName = ".spectral.yaml"
+
+// Document to lint from memory instead of from the Config.FS or the system file system
+type Document struct {
+	// Name of the Document relative to the Config.WorkingDirectory. The Name is reported as the source of a Rule and
+	// relative $ref's in the Content are resolved against it.
+	Name string
+
+	// Content of the Document, e.g. an OpenAPI document in YAML or JSON
+	Content []byte
+}
+
+// withOverlay wraps the BeforeModule such that the overlay serves the in-memory documents, rulesets and functions
+// before the fs.FS and working directory the BeforeModule enables the fs module with. If the BeforeModule replaces the
+// fs module with one that is not enabled by the nodefs package, the in-memory files are not served.
+func withOverlay(before BeforeModule, o *overlay) BeforeModule {
+	return func(enable Enable, runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) (func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule), error) {
+		loader, err := before(enable, runtime, registry, requireModule)
+		if err != nil || enable.Name != nodefs.ModuleName {
+			return loader, err
+		}
+
+		return func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) {
+			loader(runtime, registry, requireModule)
+
+			f := nodefs.Of(runtime)
+			if f == nil {
+				return
+			}
+
+			if f.CurrentWorkingDirectory != "" {
+				o.workingDirectory = f.CurrentWorkingDirectory
+			}
+			o.base = f.FileSystem
+			f.FileSystem = o
+		}, nil
+	}
+}
+
+// overlay is a fs.FS serving in-memory files before falling back to the base fs.FS. The files are keyed by their
+// path relative to the working directory, matching the paths the node:fs module uses to open files from a fs.FS.
+type overlay struct {
+	workingDirectory string
+	base             fs.FS
+	files            map[string][]byte
+}
+
+// set the in-memory files of the overlay, replacing any previously set files
+func (o *overlay) set(files map[string][]byte) {
+	o.files = make(map[string][]byte, len(files))
+	for name, content := range files {
+		o.files[o.key(name)] = content
+	}
+}
+
+// key of a name relative to the working directory
+func (o *overlay) key(name string) string {
+	if filepath.IsAbs(name) {
+		if rel, err := filepath.Rel(o.workingDirectory, name); err == nil {
+			name = rel
+		}
+	}
+
+	return path.Clean(filepath.ToSlash(name))
+}
+
+// Open the in-memory file with name or else open name from the base fs.FS
+func (o *overlay) Open(name string) (fs.File, error) {
+	if content, ok := o.files[path.Clean(name)]; ok {
+		return &memFile{
+			Reader: bytes.NewReader(content),
+			info:   memFileInfo{name: path.Base(name), size: int64(len(content))},
+		}, nil
+	}
+
+	if o.base == nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return o.base.Open(name)
+}
+
+// Stat of the in-memory file or directory with name or else of name in the base fs.FS
+func (o *overlay) Stat(name string) (fs.FileInfo, error) {
+	name = path.Clean(name)
+	if content, ok := o.files[name]; ok {
+		return memFileInfo{name: path.Base(name), size: int64(len(content))}, nil
+	}
+
+	if o.isDir(name) {
+		return memFileInfo{name: path.Base(name), dir: true}, nil
+	}
+
+	if o.base == nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return fs.Stat(o.base, name)
+}
+
+// ReadDir of the in-memory files and directories in the directory with name merged with the entries of the directory
+// in the base fs.FS, sorted by name. If an entry is in both, the in-memory entry is listed.
+func (o *overlay) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = path.Clean(name)
+	entries := map[string]fs.DirEntry{}
+	for key, content := range o.files {
+		child, nested, ok := o.child(name, key)
+		if !ok {
+			continue
+		}
+
+		if nested {
+			entries[child] = fs.FileInfoToDirEntry(memFileInfo{name: child, dir: true})
+		} else {
+			entries[child] = fs.FileInfoToDirEntry(memFileInfo{name: child, size: int64(len(content))})
+		}
+	}
+
+	if o.base != nil {
+		baseEntries, err := fs.ReadDir(o.base, name)
+		if err != nil && len(entries) == 0 {
+			return nil, err
+		}
+
+		for _, entry := range baseEntries {
+			if _, ok := entries[entry.Name()]; !ok {
+				entries[entry.Name()] = entry
+			}
+		}
+	} else if len(entries) == 0 {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	names := slices.Sorted(maps.Keys(entries))
+	list := make([]fs.DirEntry, len(names))
+	for i, child := range names {
+		list[i] = entries[child]
+	}
+
+	return list, nil
+}
+
+// isDir if the name is a directory of an in-memory file
+func (o *overlay) isDir(name string) bool {
+	for key := range o.files {
+		if _, _, ok := o.child(name, key); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// child of the directory with name that leads to the in-memory file with key, which is nested if the child is a
+// directory containing the file. Returns false if the file is not in the directory.
+func (o *overlay) child(name string, key string) (string, bool, bool) {
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+
+	rest, ok := strings.CutPrefix(key, prefix)
+	if !ok {
+		return "", false, false
+	}
+
+	child, _, nested := strings.Cut(rest, "/")
+	if child == ".." {
+		return "", false, false
+	}
+
+	return child, nested, true
+}
+
+// memFile is an in-memory fs.File
+type memFile struct {
+	*bytes.Reader
+	info memFileInfo
+}
+
+// Stat of the memFile
+func (f *memFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+// Close is a no-op
+func (f *memFile) Close() error {
+	return nil
+}
+
+// memFileInfo is the fs.FileInfo of a memFile or of a directory containing a memFile
+type memFileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+// Name of the file
+func (i memFileInfo) Name() string { return i.name }
+
+// Size of the file content
+func (i memFileInfo) Size() int64 { return i.size }
+
+// Mode is a read-only regular file or directory
+func (i memFileInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555 //nolint:mnd // read-only directory mode
+	}
+
+	return 0o444 //nolint:mnd // read-only file mode
+}
+
+// ModTime is the zero time
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+
+// IsDir if the memFileInfo is of a directory
+func (i memFileInfo) IsDir() bool { return i.dir }
+
+// Sys is always nil
+func (i memFileInfo) Sys() any { return nil }
+
+// WithRulesetContent sets the Config.RulesetContent, a ruleset (e.g. YAML or JSON) served from memory. It is served
+// under the ruleset name supplied to Lint or RulesetContentName if that is empty.
+func WithRulesetContent(content []byte) Option {
+	return func(config *Config) error {
+		config.RulesetContent = content
+
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package gospectral
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverlay_Open_InMemoryFile(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{workingDirectory: "/wd"}
+	o.set(map[string][]byte{"./api/openapi.yaml": []byte("openapi: 3.1.0")})
+
+	// Act
+	file, err := o.Open("api/openapi.yaml")
+
+	// Assert
+	require.NoError(t, err)
+	b, readErr := io.ReadAll(file)
+	require.NoError(t, readErr)
+	assert.Equal(t, "openapi: 3.1.0", string(b))
+	info, statErr := file.Stat()
+	require.NoError(t, statErr)
+	assert.Equal(t, "openapi.yaml", info.Name())
+	assert.Equal(t, int64(14), info.Size())
+	assert.False(t, info.IsDir())
+}
+
+func TestOverlay_Open_AbsoluteNameRelativeToWorkingDirectory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{workingDirectory: "/wd"}
+	o.set(map[string][]byte{"/wd/openapi.yaml": []byte("openapi: 3.1.0")})
+
+	// Act
+	_, err := o.Open("openapi.yaml")
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func TestOverlay_Open_FallsBackToBase(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{base: bundle}
+	o.set(map[string][]byte{"openapi.yaml": []byte("openapi: 3.1.0")})
+
+	// Act
+	_, err := o.Open("testdata/.spectral.yaml")
+
+	// Assert
+	require.NoError(t, err)
+}
+
+func TestOverlay_Open_NotExistWithoutBase(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{}
+
+	// Act
+	_, err := o.Open("openapi.yaml")
+
+	// Assert
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestOverlay_Stat_InMemoryFileAndDirectory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{workingDirectory: "/wd"}
+	o.set(map[string][]byte{"api/v1/openapi.yaml": []byte("openapi: 3.1.0")})
+
+	// Act
+	file, fileErr := fs.Stat(o, "api/v1/openapi.yaml")
+	dir, dirErr := fs.Stat(o, "api")
+	_, notExistErr := fs.Stat(o, "docs")
+
+	// Assert
+	require.NoError(t, fileErr)
+	assert.False(t, file.IsDir())
+	assert.Equal(t, int64(14), file.Size())
+	require.NoError(t, dirErr)
+	assert.True(t, dir.IsDir())
+	assert.Equal(t, "api", dir.Name())
+	require.ErrorIs(t, notExistErr, fs.ErrNotExist)
+}
+
+func TestOverlay_ReadDir_MergesInMemoryFilesWithBase(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{base: bundle}
+	o.set(map[string][]byte{
+		"testdata/openapi.yaml":        []byte("openapi: 3.1.0"),
+		"testdata/api/v1/openapi.yaml": []byte("openapi: 3.1.0"),
+		"../outside.yaml":              []byte("openapi: 3.1.0"),
+	})
+
+	// Act
+	entries, err := fs.ReadDir(o, "testdata")
+	root, rootErr := fs.ReadDir(o, ".")
+
+	// Assert
+	require.NoError(t, err)
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	assert.Contains(t, names, ".spectral.yaml")
+	assert.Contains(t, names, "openapi.yaml")
+	assert.Contains(t, names, "api")
+	for _, entry := range entries {
+		if entry.Name() == "api" {
+			assert.True(t, entry.IsDir())
+		}
+	}
+	require.NoError(t, rootErr)
+	require.Len(t, root, 1)
+	assert.Equal(t, "testdata", root[0].Name())
+}
+
+func TestOverlay_ReadDir_NotExistWithoutBase(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	o := &overlay{}
+	o.set(map[string][]byte{"api/openapi.yaml": []byte("openapi: 3.1.0")})
+
+	// Act
+	_, err := fs.ReadDir(o, "docs")
+
+	// Assert
+	require.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestLintBytes_ResolvesDocumentsAndRulesetFromMemory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`Promise.all(lintDocuments.concat(["api/schema.yaml", lintRuleset]).map(function(document) {
+		return fs.promises.readFile(require('path').resolve(process.cwd(), document));
+	})).then(function(contents) {
+		return JSON.stringify(contents.map(function(content) { return { code: content }; }));
+	})`)
+	documents := []Document{
+		{Name: "api/openapi.yaml", Content: []byte("$ref: ./schema.yaml")},
+		{Name: "api/schema.yaml", Content: []byte("type: object")},
+	}
+
+	// Act
+	output, err := LintBytes(context.Background(), documents, "", WithDist([]byte("")), WithScript(script),
+		WithRulesetContent([]byte("extends: [spectral:oas]")), WithWorkingDirectory("/wd"))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 4)
+	assert.Equal(t, "$ref: ./schema.yaml", output[0].Code)
+	assert.Equal(t, "type: object", output[1].Code)
+	assert.Equal(t, "type: object", output[2].Code)
+	assert.Equal(t, "extends: [spectral:oas]", output[3].Code)
+}
+
+func TestLintBytes_ResolvesFromMemoryWithBeforeModule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`JSON.stringify([{ code: String(fs.readFileSync(require('path').resolve(process.cwd(), lintDocuments[0]))) }])`)
+	documents := []Document{{Name: "api/openapi.yaml", Content: []byte("openapi: 3.1.0")}}
+
+	// Act
+	output, err := LintBytes(context.Background(), documents, "", WithDist([]byte("")), WithScript(script),
+		WithWorkingDirectory("/wd"), WithBeforeModule(DefaultBeforeModule("/wd", nil)))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "openapi: 3.1.0", output[0].Code)
+}
+
+func TestLintBytes_ResolvesFromFSOfBeforeModule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`var path = require('path');
+var read = function(name) { return String(fs.readFileSync(path.resolve(process.cwd(), name))); };
+JSON.stringify([{ code: read(lintDocuments[0]) + "," + read("ruleset.yaml") }])`)
+	bundle := fstest.MapFS{"ruleset.yaml": {Data: []byte("extends: []")}, "openapi.yaml": {Data: []byte("openapi: 3.0.0")}}
+	options := []Option{WithDist([]byte("")), WithScript(script), WithBeforeModule(DefaultBeforeModule("/bundle", bundle))}
+
+	// Act
+	fromFS, fsErr := Lint([]string{"openapi.yaml"}, "", options...)
+	fromMemory, memoryErr := LintBytes(context.Background(), []Document{{Name: "api.yaml", Content: []byte("openapi: 3.1.0")}}, "", options...)
+
+	// Assert
+	require.NoError(t, fsErr)
+	require.NoError(t, memoryErr)
+	assert.Equal(t, "openapi: 3.0.0,extends: []", fromFS[0].Code)
+	assert.Equal(t, "openapi: 3.1.0,extends: []", fromMemory[0].Code)
+}
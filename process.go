@@ -0,0 +1,99 @@
+package gospectral
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"slices"
+
+	"github.com/Emptyless/go-spectral/node/process"
+	"github.com/dop251/goja"
+	noderequire "github.com/dop251/goja_nodejs/require"
+)
+
+// ErrNilWriter when WithStdout or WithStderr is supplied a nil io.Writer
+var ErrNilWriter = errors.New("writer must not be nil")
+
+// WithStdout sets the Config.Stdout receiving the writes to process.stdout, e.g. os.Stdout to stream the output of a
+// human-readable formatter to the terminal. The runtimes of a Linter share the writer, so it must be safe for
+// concurrent use if lints run concurrently. With a Stdout, the process module is always enabled with it, i.e. a
+// BeforeModule can not replace it.
+func WithStdout(w io.Writer) Option {
+	return func(config *Config) error {
+		if w == nil {
+			return ErrNilWriter
+		}
+
+		config.Stdout = w
+
+		return nil
+	}
+}
+
+// WithStderr sets the Config.Stderr receiving the writes to process.stderr. Like WithStdout, the writer is shared by the
+// runtimes of a Linter and the process module is always enabled with it.
+func WithStderr(w io.Writer) Option {
+	return func(config *Config) error {
+		if w == nil {
+			return ErrNilWriter
+		}
+
+		config.Stderr = w
+
+		return nil
+	}
+}
+
+// WithEnv sets the Config.Env, i.e. variables of process.env that rulesets read such as proxy settings. The variables
+// take precedence over the inherited environment, see WithInheritEnv. Like WithStdout, the process module is always
+// enabled with them.
+func WithEnv(env map[string]string) Option {
+	return func(config *Config) error {
+		config.Env = maps.Clone(env)
+
+		return nil
+	}
+}
+
+// WithInheritEnv sets the Config.InheritEnv. If false, process.env only has the Config.Env such that e.g. the secrets
+// of a CI do not leak into the rulesets. A Linter rebuilds process.env and process.argv from the Config before a
+// runtime is reused, but not the other state of the runtime, see Linter.Lint.
+func WithInheritEnv(inherit bool) Option {
+	return func(config *Config) error {
+		config.InheritEnv = inherit
+
+		return nil
+	}
+}
+
+// WithArgv sets the Config.Argv of process.argv, which defaults to process.DefaultArgv
+func WithArgv(argv ...string) Option {
+	return func(config *Config) error {
+		config.Argv = slices.Clone(argv)
+
+		return nil
+	}
+}
+
+// processOptions of the Config, nil if the process module does not need any, i.e. a custom BeforeModule enables it
+func (c *Config) processOptions() *process.Options {
+	if c.BeforeModule != nil && c.Stdout == nil && c.Stderr == nil && c.Env == nil && c.InheritEnv && c.Argv == nil {
+		return nil
+	}
+
+	return &process.Options{Stdout: c.Stdout, Stderr: c.Stderr, Env: c.Env, InheritEnv: c.InheritEnv, Argv: c.Argv}
+}
+
+// withProcess wraps the BeforeModule such that the process module is enabled with the options, passing the enabled
+// Process to the callback
+func withProcess(before BeforeModule, workingDirectory string, options process.Options, enabled func(p *process.Process)) BeforeModule {
+	return func(enable Enable, runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) (func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule), error) {
+		if enable.Name != process.ModuleName {
+			return before(enable, runtime, registry, requireModule)
+		}
+
+		return func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) {
+			enabled(process.EnableOptions(runtime, registry, requireModule, workingDirectory, options))
+		}, nil
+	}
+}
@@ -0,0 +1,115 @@
+package gospectral
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStdout_RejectsNilWriter(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithStdout(nil))
+
+	// Assert
+	require.ErrorIs(t, err, ErrNilWriter)
+	assert.Nil(t, linter)
+}
+
+func TestWithStderr_RejectsNilWriter(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithStderr(nil))
+
+	// Assert
+	require.ErrorIs(t, err, ErrNilWriter)
+	assert.Nil(t, linter)
+}
+
+func TestLint_WritesProcessStdio(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var stdout, stderr bytes.Buffer
+	script := []byte(`
+process.stdout.write('formatted output\n');
+process.stderr.write(String(process.stdout.isTTY));
+JSON.stringify([])`)
+
+	// Act
+	output, err := Lint(nil, "", WithDist([]byte("")), WithScript(script), WithStdout(&stdout), WithStderr(&stderr))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, output)
+	assert.Equal(t, "formatted output\n", stdout.String())
+	assert.Equal(t, "false", stderr.String())
+}
+
+func TestLint_DiscardsProcessStdioByDefault(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`JSON.stringify([{ code: String(process.stdout.write('ignored')), severity: 0 }])`)
+
+	// Act
+	output, err := Lint(nil, "", WithDist([]byte("")), WithScript(script))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "true", output[0].Code)
+}
+
+func TestLint_ProcessEnv(t *testing.T) {
+	t.Setenv("GO_SPECTRAL_SECRET", "secret")
+	script := []byte(`JSON.stringify([
+	{ code: String(process.env.GO_SPECTRAL_SECRET), severity: 0 },
+	{ code: String(process.env.SPECTRAL_DEBUG), severity: 0 },
+])`)
+	tests := map[string]struct {
+		options  []Option
+		expected []string
+	}{
+		"inherits the environment by default": {
+			options:  nil,
+			expected: []string{"secret", "undefined"},
+		},
+		"sets env": {
+			options:  []Option{WithEnv(map[string]string{"SPECTRAL_DEBUG": "1"})},
+			expected: []string{"secret", "1"},
+		},
+		"does not inherit the environment": {
+			options:  []Option{WithInheritEnv(false), WithEnv(map[string]string{"SPECTRAL_DEBUG": "1"})},
+			expected: []string{"undefined", "1"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			output, err := Lint(nil, "", append([]Option{WithDist([]byte("")), WithScript(script)}, tt.options...)...)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, output, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected, output[i].Code)
+			}
+		})
+	}
+}
+
+func TestLint_ProcessArgv(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`JSON.stringify([{ code: process.argv.join(' '), severity: 0 }])`)
+
+	// Act
+	output, err := Lint(nil, "", WithDist([]byte("")), WithScript(script), WithArgv("node", "spectral", "lint"))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "node spectral lint", output[0].Code)
+}
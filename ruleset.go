@@ -0,0 +1,212 @@
+package gospectral
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Ruleset is a spectral ruleset defined in Go, see https://docs.stoplight.io/docs/spectral/e5b9616d6d50c-rulesets
+type Ruleset struct {
+	// Description of the Ruleset
+	Description string `json:"description,omitempty"`
+
+	// DocumentationURL of the Ruleset, used for rules without a DocumentationURL
+	DocumentationURL string `json:"documentationUrl,omitempty"`
+
+	// Extends the Ruleset with other rulesets, e.g. spectral:oas
+	Extends []Extend `json:"extends,omitempty"`
+
+	// Formats the Ruleset applies to, e.g. oas3
+	Formats []string `json:"formats,omitempty"`
+
+	// Rules of the Ruleset by name
+	Rules map[string]RuleDefinition `json:"rules,omitempty"`
+
+	// Overrides of the Ruleset for specific files
+	Overrides []Override `json:"overrides,omitempty"`
+
+	// Aliases by name, used in the Given of a rule as #Name
+	Aliases map[string]Alias `json:"aliases,omitempty"`
+
+	// Functions are the names of the custom functions used by the Rules. The names of the Config.Functions are
+	// added when the Ruleset is serialized.
+	Functions []string `json:"functions,omitempty"`
+
+	// FunctionsDir is the directory relative to the Ruleset of the Functions, defaults to FunctionsDir if ""
+	FunctionsDir string `json:"functionsDir,omitempty"`
+}
+
+// Extend a Ruleset with the Ruleset (e.g. spectral:oas) in a Mode
+type Extend struct {
+	// Ruleset to extend, e.g. spectral:oas or a path/URL
+	Ruleset string
+
+	// Mode of the extended rules, i.e. "all", "recommended" or "off". Defaults to the recommended rules if ""
+	Mode string
+}
+
+// MarshalJSON implementation of Extend, either a string or a [ruleset, mode] tuple
+func (e Extend) MarshalJSON() ([]byte, error) {
+	if e.Mode == "" {
+		return json.Marshal(e.Ruleset)
+	}
+
+	return json.Marshal([]string{e.Ruleset, e.Mode})
+}
+
+// RuleDefinition of a rule in a Ruleset. A RuleDefinition with only a Severity or Off changes the severity of an
+// extended rule, e.g. `info-contact: off`.
+type RuleDefinition struct {
+	// Description of the rule
+	Description string `json:"description,omitempty"`
+
+	// Message of a failure, which can contain placeholders like {{error}} or {{path}}
+	Message string `json:"message,omitempty"`
+
+	// DocumentationURL of the rule
+	DocumentationURL string `json:"documentationUrl,omitempty"`
+
+	// Severity of a failure, defaults to SeverityWarn if nil
+	Severity *Severity `json:"severity,omitempty"`
+
+	// Off disables the rule
+	Off bool `json:"-"`
+
+	// Recommended if not nil sets whether the rule is enabled when extended in the recommended Mode
+	Recommended *bool `json:"recommended,omitempty"`
+
+	// Resolved if not nil sets whether the rule is applied to the resolved ($ref's replaced) document
+	Resolved *bool `json:"resolved,omitempty"`
+
+	// Formats the rule applies to, e.g. oas3
+	Formats []string `json:"formats,omitempty"`
+
+	// Given are the JSONPath expressions (or #Alias) of the nodes to apply Then to
+	Given []string `json:"given,omitempty"`
+
+	// Then are the functions to apply to the Given nodes
+	Then []Then `json:"then,omitempty"`
+}
+
+// MarshalJSON implementation of RuleDefinition, emitting the severity by name and a severity override as a string
+func (r RuleDefinition) MarshalJSON() ([]byte, error) {
+	if r.Severity != nil && !r.Severity.valid() {
+		return nil, fmt.Errorf("%d: %w", int(*r.Severity), ErrUnknownSeverity)
+	}
+
+	severity := ""
+	switch {
+	case r.Off:
+		severity = "off"
+	case r.Severity != nil:
+		severity = r.Severity.String()
+	}
+
+	// only the severity of an extended rule is changed
+	if severity != "" && r.Description == "" && r.Message == "" && r.DocumentationURL == "" && r.Recommended == nil &&
+		r.Resolved == nil && len(r.Formats) == 0 && len(r.Given) == 0 && len(r.Then) == 0 {
+		return json.Marshal(severity)
+	}
+
+	type ruleDefinition RuleDefinition // prevent recursion
+
+	return json.Marshal(struct {
+		ruleDefinition
+		Severity string `json:"severity,omitempty"`
+	}{
+		ruleDefinition: ruleDefinition(r),
+		Severity:       severity,
+	})
+}
+
+// Then applies a Function to the Field of the given nodes
+type Then struct {
+	// Field of the given node to apply the Function to, applies to the node itself if ""
+	Field string `json:"field,omitempty"`
+
+	// Function to apply, e.g. truthy, pattern or the name of a custom function
+	Function string `json:"function"`
+
+	// FunctionOptions passed to the Function
+	FunctionOptions map[string]any `json:"functionOptions,omitempty"`
+}
+
+// Override the Ruleset for the Files
+type Override struct {
+	// Files are globs of the files to apply the Override to, e.g. **/*.yaml or schemas/user.yaml#/properties
+	Files []string `json:"files"`
+
+	// Extends the Override with other rulesets
+	Extends []Extend `json:"extends,omitempty"`
+
+	// Formats the Override applies to
+	Formats []string `json:"formats,omitempty"`
+
+	// Rules of the Override by name
+	Rules map[string]RuleDefinition `json:"rules,omitempty"`
+
+	// Aliases of the Override by name
+	Aliases map[string]Alias `json:"aliases,omitempty"`
+}
+
+// Alias is a reusable Given, either plain JSONPath expressions or scoped to formats with Targets
+type Alias struct {
+	// Description of a scoped Alias
+	Description string
+
+	// Given are the JSONPath expressions of a plain Alias
+	Given []string
+
+	// Targets of a scoped Alias
+	Targets []AliasTarget
+}
+
+// AliasTarget are the Given JSONPath expressions for Formats
+type AliasTarget struct {
+	Formats []string `json:"formats"`
+	Given   []string `json:"given"`
+}
+
+// MarshalJSON implementation of Alias, either a list of expressions or a scoped object with targets
+func (a Alias) MarshalJSON() ([]byte, error) {
+	if len(a.Targets) == 0 {
+		return json.Marshal(a.Given)
+	}
+
+	return json.Marshal(struct {
+		Description string        `json:"description,omitempty"`
+		Targets     []AliasTarget `json:"targets"`
+	}{
+		Description: a.Description,
+		Targets:     a.Targets,
+	})
+}
+
+// ErrConflictingRuleset when both a Ruleset and RulesetContent are supplied
+var ErrConflictingRuleset = errors.New("ruleset and ruleset content are mutually exclusive")
+
+// WithRuleset sets the Config.Ruleset, which is serialized and served from memory like the Config.RulesetContent.
+// The names of the Config.Functions are added to the Ruleset.Functions.
+func WithRuleset(ruleset Ruleset) Option {
+	return func(config *Config) error {
+		config.Ruleset = &ruleset
+
+		return nil
+	}
+}
+
+// rulesetContent serializes the ruleset, adding the functions that are not yet declared in it
+func rulesetContent(ruleset Ruleset, functions map[string]Function) ([]byte, error) {
+	var undeclared []string
+	for name := range functions {
+		if !slices.Contains(ruleset.Functions, name) {
+			undeclared = append(undeclared, name)
+		}
+	}
+	slices.Sort(undeclared)
+	ruleset.Functions = slices.Concat(ruleset.Functions, undeclared)
+
+	return json.Marshal(ruleset)
+}
@@ -0,0 +1,134 @@
+package gospectral
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleset_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	severity := SeverityError
+	recommended := false
+	ruleset := Ruleset{
+		DocumentationURL: "https://example.com/rules",
+		Extends:          []Extend{{Ruleset: "spectral:oas"}, {Ruleset: "./shared.yaml", Mode: "all"}},
+		Formats:          []string{"oas3"},
+		Rules: map[string]RuleDefinition{
+			"info-contact":     {Off: true},
+			"info-description": {Severity: &severity},
+			"operation-id-kebab-case": {
+				Description: "operationId must be kebab-case",
+				Message:     "{{error}}",
+				Severity:    &severity,
+				Recommended: &recommended,
+				Given:       []string{"#Operation.operationId"},
+				Then:        []Then{{Function: "pattern", FunctionOptions: map[string]any{"match": "^[a-z-]+$"}}},
+			},
+		},
+		Overrides: []Override{{
+			Files: []string{"legacy/**/*.yaml"},
+			Rules: map[string]RuleDefinition{"operation-id-kebab-case": {Off: true}},
+		}},
+		Aliases: map[string]Alias{
+			"Operation": {Given: []string{"$.paths[*][get,put,post,delete]"}},
+			"Schema": {Description: "schemas", Targets: []AliasTarget{
+				{Formats: []string{"oas3"}, Given: []string{"$.components.schemas[*]"}},
+			}},
+		},
+		Functions: []string{"jsFunction"},
+	}
+
+	// Act
+	data, err := json.Marshal(ruleset)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"documentationUrl": "https://example.com/rules",
+		"extends": ["spectral:oas", ["./shared.yaml", "all"]],
+		"formats": ["oas3"],
+		"rules": {
+			"info-contact": "off",
+			"info-description": "error",
+			"operation-id-kebab-case": {
+				"description": "operationId must be kebab-case",
+				"message": "{{error}}",
+				"severity": "error",
+				"recommended": false,
+				"given": ["#Operation.operationId"],
+				"then": [{ "function": "pattern", "functionOptions": { "match": "^[a-z-]+$" } }]
+			}
+		},
+		"overrides": [{ "files": ["legacy/**/*.yaml"], "rules": { "operation-id-kebab-case": "off" } }],
+		"aliases": {
+			"Operation": ["$.paths[*][get,put,post,delete]"],
+			"Schema": { "description": "schemas", "targets": [{ "formats": ["oas3"], "given": ["$.components.schemas[*]"] }] }
+		},
+		"functions": ["jsFunction"]
+	}`, string(data))
+}
+
+func TestRuleDefinition_MarshalJSON_OffWithDefinition(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	rule := RuleDefinition{Off: true, Given: []string{"$.info"}, Then: []Then{{Field: "contact", Function: "truthy"}}}
+
+	// Act
+	data, err := json.Marshal(rule)
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"severity": "off", "given": ["$.info"], "then": [{ "field": "contact", "function": "truthy" }]}`, string(data))
+}
+
+func TestRuleDefinition_MarshalJSON_UnknownSeverity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	severity := Severity(7)
+
+	// Act
+	_, err := json.Marshal(RuleDefinition{Severity: &severity})
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownSeverity)
+}
+
+func TestLint_ServesRulesetWithFunctions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	script := []byte(`Promise.all([lintRuleset, "rules/fns/kebabCase.js"].map(function(file) {
+		return fs.promises.readFile(require('path').resolve(process.cwd(), file));
+	})).then(function(contents) {
+		return JSON.stringify([{ code: lintRuleset, message: String(contents[0]) }, { code: String(contents[1]) }]);
+	})`)
+	ruleset := Ruleset{
+		Extends:      []Extend{{Ruleset: "spectral:oas"}},
+		Functions:    []string{"jsFunction"},
+		FunctionsDir: "fns",
+	}
+
+	// Act
+	output, err := Lint([]string{"./openapi.yaml"}, "rules/.spectral.yaml", WithDist([]byte("")), WithScript(script),
+		WithRuleset(ruleset), WithFunction("kebabCase", kebabCase), WithWorkingDirectory("/wd"))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 2)
+	assert.Equal(t, "rules/.spectral.yaml", output[0].Code)
+	assert.JSONEq(t, `{"extends": ["spectral:oas"], "functions": ["jsFunction", "kebabCase"], "functionsDir": "fns"}`, output[0].Message)
+	assert.Contains(t, output[1].Code, `goSpectralFunctions["kebabCase"]`)
+}
+
+func TestNew_RejectsRulesetWithRulesetContent(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithRuleset(Ruleset{}), WithRulesetContent([]byte("extends: [spectral:oas]")))
+
+	// Assert
+	require.ErrorIs(t, err, ErrConflictingRuleset)
+	assert.Nil(t, linter)
+}
@@ -0,0 +1,164 @@
+package gospectral
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	nodefs "github.com/Emptyless/go-spectral/node/fs"
+	"github.com/dop251/goja"
+	noderequire "github.com/dop251/goja_nodejs/require"
+)
+
+// Sandbox confines the file access of a lint. The fs module has no write API's, so the files of every Sandbox are
+// read-only.
+type Sandbox int
+
+const (
+	// SandboxNone does not confine the file access, i.e. any path on the system file system can be read
+	SandboxNone Sandbox = iota
+
+	// SandboxWorkingDirectory confines the file access to the Config.FS, the in-memory documents and the
+	// WorkingDirectory subtree of the system file system. Symbolic links are followed before the path is checked, so
+	// a symbolic link pointing outside the WorkingDirectory can not be read either.
+	SandboxWorkingDirectory
+
+	// SandboxFS confines the file access to the Config.FS and the in-memory documents, i.e. the system file system is
+	// never accessed
+	SandboxFS
+)
+
+// ErrSandboxEscape when a file outside the Sandbox is accessed
+var ErrSandboxEscape = errors.New("file access outside of the sandbox denied")
+
+// ErrUnknownSandbox when an unknown Sandbox is supplied to WithSandbox
+var ErrUnknownSandbox = errors.New("unknown sandbox")
+
+// WithSandbox sets the Config.Sandbox confining the file access of a lint, e.g. to lint untrusted documents that could
+// $ref '/etc/passwd'. A file read outside the Sandbox is returned by Lint as a *SandboxError (along with the Output if
+// the lint completed). With a Sandbox, the fs module is always enabled by the Sandbox, i.e. a BeforeModule can not
+// replace it.
+func WithSandbox(sandbox Sandbox) Option {
+	return func(config *Config) error {
+		if sandbox < SandboxNone || sandbox > SandboxFS {
+			return fmt.Errorf("%d: %w", int(sandbox), ErrUnknownSandbox)
+		}
+
+		config.Sandbox = sandbox
+
+		return nil
+	}
+}
+
+// SandboxError when a file outside the Sandbox is read, e.g. to resolve a $ref
+type SandboxError struct {
+	// File that was denied
+	File string
+
+	// Source is the document with the $ref that referenced the File, empty if unknown
+	Source string
+
+	// Path to the $ref in the Source
+	Path Path
+}
+
+// Error implementation of SandboxError
+func (e SandboxError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("%s: '%s'", ErrSandboxEscape, e.File)
+	}
+
+	return fmt.Sprintf("%s: '%s' referenced at %s#/%s", ErrSandboxEscape, e.File, e.Source, strings.Join(e.Path, "/"))
+}
+
+// Unwrap returns ErrSandboxEscape and fs.ErrPermission, such that the fs module reports an EACCES error
+func (e SandboxError) Unwrap() []error {
+	return []error{ErrSandboxEscape, fs.ErrPermission}
+}
+
+// reference of the denied file is its path
+func (e *SandboxError) reference() string {
+	return e.File
+}
+
+// attribute the denied file to the $ref at the path in the source
+func (e *SandboxError) attribute(source string, path Path) {
+	e.Source, e.Path = source, path
+}
+
+// allowed if the Sandbox allows the access of the path on the system file system
+func (s Sandbox) allowed(workingDirectory string, path string) bool {
+	switch s {
+	case SandboxNone:
+		return true
+	case SandboxWorkingDirectory:
+		return within(workingDirectory, path)
+	default:
+		return false
+	}
+}
+
+// within if the path is the directory or in its subtree after following symbolic links
+func within(directory string, path string) bool {
+	directory, directoryErr := resolve(directory)
+	path, pathErr := resolve(path)
+	if directoryErr != nil || pathErr != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(directory, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// resolve the path to an absolute path, following its symbolic links if the path exists
+func resolve(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		return resolved, nil
+	}
+
+	return abs, nil
+}
+
+// guard of the Sandbox for the fs module and the require loader of the worker, nil if the Sandbox is SandboxNone. A
+// denied read (i.e. open) is recorded as a *SandboxError of the current lint. Other denied accesses (e.g. a stat while
+// expanding a glob) are not recorded since they are not the reads of a $ref.
+func (w *worker) guard() func(op string, path string) error {
+	if w.cfg.Sandbox == SandboxNone {
+		return nil
+	}
+
+	return func(op string, path string) error {
+		if w.cfg.Sandbox.allowed(w.cfg.WorkingDirectory, path) {
+			return nil
+		}
+
+		denied := &SandboxError{File: path}
+		if op == "open" {
+			denialsOf(w.ctx).add(denied)
+		}
+
+		return denied
+	}
+}
+
+// withSandbox wraps the BeforeModule such that the fs module is enabled with the guard of the Sandbox, serving files
+// from the file system before the system file system
+func withSandbox(before BeforeModule, workingDirectory string, fileSystem fs.FS, guard func(op string, path string) error) BeforeModule {
+	return func(enable Enable, runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) (func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule), error) {
+		if enable.Name != nodefs.ModuleName {
+			return before(enable, runtime, registry, requireModule)
+		}
+
+		return func(runtime *goja.Runtime, registry *noderequire.Registry, requireModule *noderequire.RequireModule) {
+			nodefs.EnableGuard(runtime, registry, requireModule, workingDirectory, fileSystem, guard)
+		}, nil
+	}
+}
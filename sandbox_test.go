@@ -0,0 +1,137 @@
+package gospectral
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readDist reads every document with the fs module and reports a failure as an invalid-ref Rule of a $ref in the
+// openapi.yaml
+var readDist = []byte(`exports.formatOutput = function(results) { return JSON.stringify(results); };
+exports.lint = function(documents) {
+    return Promise.all(documents.map(function(document) {
+        return require('fs').promises.readFile(document)
+            .then(function(content) { return { code: "read", message: content }; })
+            .catch(function(err) { return { code: "invalid-ref", message: err.message, source: "openapi.yaml", path: ["paths", "/users", "$ref"] }; });
+    })).then(function(results) { return { results: results }; });
+};`)
+
+// sandboxDirectory creates a working directory with an openapi.yaml, a secret.yaml outside of it and a symbolic link
+// to the secret.yaml inside of it
+func sandboxDirectory(t *testing.T) (workingDirectory string, secret string) {
+	t.Helper()
+
+	root := t.TempDir()
+	workingDirectory = filepath.Join(root, "wd")
+	secret = filepath.Join(root, "secret.yaml")
+	require.NoError(t, os.MkdirAll(workingDirectory, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDirectory, "openapi.yaml"), []byte("openapi: 3.1.0"), 0o600))
+	require.NoError(t, os.WriteFile(secret, []byte("secret"), 0o600))
+	require.NoError(t, os.Symlink(secret, filepath.Join(workingDirectory, "link.yaml")))
+
+	return workingDirectory, secret
+}
+
+func TestWithSandbox_RejectsUnknownSandbox(t *testing.T) {
+	t.Parallel()
+	// Act
+	linter, err := New(WithSandbox(Sandbox(42)))
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownSandbox)
+	assert.Nil(t, linter)
+}
+
+func TestWithin(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	workingDirectory, secret := sandboxDirectory(t)
+	tests := map[string]struct {
+		path   string
+		within bool
+	}{
+		"directory":                    {path: workingDirectory, within: true},
+		"file":                         {path: filepath.Join(workingDirectory, "openapi.yaml"), within: true},
+		"not existing file":            {path: filepath.Join(workingDirectory, "missing.yaml"), within: true},
+		"file outside":                 {path: secret, within: false},
+		"traversal":                    {path: filepath.Join(workingDirectory, "..", "secret.yaml"), within: false},
+		"symbolic link to outside":     {path: filepath.Join(workingDirectory, "link.yaml"), within: false},
+		"directory with shared prefix": {path: workingDirectory + "2", within: false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual := within(workingDirectory, tt.path)
+
+			// Assert
+			assert.Equal(t, tt.within, actual)
+		})
+	}
+}
+
+func TestLint_SandboxWorkingDirectory_DeniesFileOutsideWorkingDirectory(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	workingDirectory, secret := sandboxDirectory(t)
+	link := filepath.Join(workingDirectory, "link.yaml")
+	documents := []string{filepath.Join(workingDirectory, "openapi.yaml"), secret, link}
+
+	// Act
+	output, err := Lint(documents, "", WithDist(readDist), WithWorkingDirectory(workingDirectory), WithSandbox(SandboxWorkingDirectory))
+
+	// Assert
+	require.ErrorIs(t, err, ErrSandboxEscape)
+	require.Len(t, output, 3)
+	assert.Equal(t, "read", output[0].Code)
+	assert.Equal(t, "openapi: 3.1.0", output[0].Message)
+	assert.Equal(t, "invalid-ref", output[1].Code)
+	assert.Equal(t, "EACCES: permission denied, open '"+secret+"'", output[1].Message)
+	assert.Equal(t, "invalid-ref", output[2].Code)
+
+	var denied *SandboxError
+	require.ErrorAs(t, err, &denied)
+	assert.Equal(t, &SandboxError{File: secret, Source: "openapi.yaml", Path: Path{"paths", "/users", "$ref"}}, denied)
+	assert.Contains(t, err.Error(), "file access outside of the sandbox denied: '"+link+"'")
+}
+
+func TestLint_SandboxFS_OnlyReadsFS(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	workingDirectory, _ := sandboxDirectory(t)
+	fileSystem := fstest.MapFS{"bundled.yaml": {Data: []byte("openapi: 3.0.0")}}
+	documents := []string{filepath.Join(workingDirectory, "bundled.yaml"), filepath.Join(workingDirectory, "openapi.yaml")}
+
+	// Act
+	output, err := Lint(documents, "", WithDist(readDist), WithWorkingDirectory(workingDirectory), WithFS(fileSystem), WithSandbox(SandboxFS))
+
+	// Assert
+	var denied *SandboxError
+	require.ErrorAs(t, err, &denied)
+	require.ErrorIs(t, err, fs.ErrPermission)
+	assert.Equal(t, filepath.Join(workingDirectory, "openapi.yaml"), denied.File)
+	require.Len(t, output, 2)
+	assert.Equal(t, "openapi: 3.0.0", output[0].Message)
+	assert.Equal(t, "invalid-ref", output[1].Code)
+}
+
+func TestLint_SandboxNone_ReadsAnyFile(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	workingDirectory, secret := sandboxDirectory(t)
+
+	// Act
+	output, err := Lint([]string{secret}, "", WithDist(readDist), WithWorkingDirectory(workingDirectory))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, output, 1)
+	assert.Equal(t, "secret", output[0].Message)
+}
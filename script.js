@@ -1,10 +1,26 @@
 var spectral = require('./dist/built.js');
+
+// describe the results with the documentationUrl, description and ruleset source of the rule that produced them
+function describe(results, ruleset) {
+    var rules = ruleset && ruleset.rules ? ruleset.rules : {};
+    return results.map(function(result) {
+        var rule = rules[result.code];
+        if (rule) {
+            result.documentationUrl = result.documentationUrl || rule.documentationUrl || undefined;
+            result.description = rule.description || undefined;
+            result.ruleset = (rule.owner && rule.owner.source) || undefined;
+        }
+        return result;
+    });
+}
+
 new Promise(function(res, rej) {
-    spectral.lint
+    spectral.lint(lintDocuments, lintOptions)
         .then(function(output) {
-            res(spectral.formatOutput(output.results, "json", { failSeverity: -1 }, output.resolvedRuleset));
+            var results = JSON.parse(spectral.formatOutput(output.results, "json", { failSeverity: -1 }, output.resolvedRuleset));
+            res(JSON.stringify(describe(results, output.resolvedRuleset)));
         })
         .catch(function(e) {
             rej(e);
         })
-})
\ No newline at end of file
+})
@@ -0,0 +1,109 @@
+package gospectral
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Severity of a Rule as reported by Spectral. Lower values are more severe.
+type Severity int
+
+const (
+	// SeverityError is the most severe
+	SeverityError Severity = iota
+	// SeverityWarn is less severe than SeverityError
+	SeverityWarn
+	// SeverityInfo is less severe than SeverityWarn
+	SeverityInfo
+	// SeverityHint is the least severe
+	SeverityHint
+)
+
+// severityNames indexed by Severity
+var severityNames = [...]string{
+	SeverityError: "error",
+	SeverityWarn:  "warn",
+	SeverityInfo:  "info",
+	SeverityHint:  "hint",
+}
+
+// ErrUnknownSeverity when a Severity cannot be parsed
+var ErrUnknownSeverity = errors.New("unknown severity")
+
+// ParseSeverity parses "error", "warn", "info" or "hint" (case-insensitive) to a Severity
+func ParseSeverity(s string) (Severity, error) {
+	for severity, name := range severityNames {
+		if strings.EqualFold(s, name) {
+			return Severity(severity), nil
+		}
+	}
+
+	return 0, fmt.Errorf("'%s': %w", s, ErrUnknownSeverity)
+}
+
+// String returns the name of the Severity, e.g. "warn"
+func (s Severity) String() string {
+	if !s.valid() {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+
+	return severityNames[s]
+}
+
+// AtLeast reports whether s is as severe as or more severe than other, e.g. SeverityError.AtLeast(SeverityWarn)
+func (s Severity) AtLeast(other Severity) bool {
+	return s <= other
+}
+
+// valid if s is one of the Severity constants
+func (s Severity) valid() bool {
+	return s >= SeverityError && s <= SeverityHint
+}
+
+// MarshalText returns the name of the Severity
+func (s Severity) MarshalText() ([]byte, error) {
+	if !s.valid() {
+		return nil, fmt.Errorf("%d: %w", int(s), ErrUnknownSeverity)
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText parses the name of a Severity using ParseSeverity
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = severity
+
+	return nil
+}
+
+// MarshalJSON returns the Severity as a number, matching the Spectral JSON output
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int(s))
+}
+
+// UnmarshalJSON accepts both the number used in the Spectral JSON output and the name of a Severity
+func (s *Severity) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		return s.UnmarshalText([]byte(name))
+	}
+
+	var number int
+	if err := json.Unmarshal(b, &number); err != nil {
+		return err
+	}
+
+	*s = Severity(number)
+	if !s.valid() {
+		return fmt.Errorf("%d: %w", number, ErrUnknownSeverity)
+	}
+
+	return nil
+}
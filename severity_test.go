@@ -0,0 +1,113 @@
+package gospectral
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+	tests := map[string]Severity{
+		"error": SeverityError,
+		"warn":  SeverityWarn,
+		"info":  SeverityInfo,
+		"hint":  SeverityHint,
+		"WARN":  SeverityWarn,
+	}
+
+	for input, expected := range tests {
+		t.Run(input, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			actual, err := ParseSeverity(input)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestParseSeverity_Unknown(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := ParseSeverity("fatal")
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownSeverity)
+}
+
+func TestSeverity_String(t *testing.T) {
+	t.Parallel()
+	// Assert
+	assert.Equal(t, "error", SeverityError.String())
+	assert.Equal(t, "warn", SeverityWarn.String())
+	assert.Equal(t, "info", SeverityInfo.String())
+	assert.Equal(t, "hint", SeverityHint.String())
+	assert.Equal(t, "Severity(7)", Severity(7).String())
+}
+
+func TestSeverity_AtLeast(t *testing.T) {
+	t.Parallel()
+	// Assert
+	assert.True(t, SeverityError.AtLeast(SeverityWarn))
+	assert.True(t, SeverityWarn.AtLeast(SeverityWarn))
+	assert.False(t, SeverityHint.AtLeast(SeverityInfo))
+}
+
+func TestSeverity_MarshalText(t *testing.T) {
+	t.Parallel()
+	// Act
+	b, err := json.Marshal(map[Severity]int{SeverityWarn: 1})
+
+	// Assert
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"warn": 1}`, string(b))
+}
+
+func TestSeverity_MarshalText_Unknown(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := Severity(-1).MarshalText()
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownSeverity)
+}
+
+func TestSeverity_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	// Act
+	b, err := json.Marshal(SeverityInfo)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "2", string(b))
+}
+
+func TestSeverity_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var severities []Severity
+
+	// Act
+	err := json.Unmarshal([]byte(`[0, "warn", 2, "hint"]`), &severities)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Severity{SeverityError, SeverityWarn, SeverityInfo, SeverityHint}, severities)
+}
+
+func TestSeverity_UnmarshalJSON_Unknown(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var severity Severity
+
+	// Act
+	err := json.Unmarshal([]byte(`4`), &severity)
+
+	// Assert
+	require.ErrorIs(t, err, ErrUnknownSeverity)
+}